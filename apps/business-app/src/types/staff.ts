@@ -36,6 +36,14 @@ export type StaffApiResponse = {
   updated_at: string;
 };
 
+// Paginated response of GET /api/employees
+export type StaffApiPage = {
+  data: StaffApiResponse[];
+  total: number;
+  page: number;
+  page_size: number;
+};
+
 export type AvailableStaff = {
   staffId: string; // ID of the staff member
   serviceIds: string[]; // List of service IDs this staff can perform
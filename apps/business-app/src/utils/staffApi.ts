@@ -3,6 +3,7 @@ import {
   CreateStaffRequest,
   UpdateStaffRequest,
   StaffApiResponse,
+  StaffApiPage,
 } from "../types/staff";
 import axiosInstance from "../api/axios";
 
@@ -34,9 +35,17 @@ const transformStaffToApiRequest = (
 export const staffAPI = {
   // Get all staff members
   getAll: async (): Promise<Staff[]> => {
-    const { data: apiResponses } = await axiosInstance.get<StaffApiResponse[]>(
-      "/api/employees"
-    );
+    // The directory is paginated; collect every page
+    const apiResponses: StaffApiResponse[] = [];
+    for (let page = 1; ; page++) {
+      const { data } = await axiosInstance.get<StaffApiPage>("/api/employees", {
+        params: { page, page_size: 100 },
+      });
+      apiResponses.push(...data.data);
+      if (apiResponses.length >= data.total || data.data.length === 0) {
+        break;
+      }
+    }
 
     const transformedStaff = apiResponses.map(transformApiResponseToStaff);
     console.log("🔄 Transformed staff data:", transformedStaff);
@@ -33,7 +33,7 @@ echo -e "\n${YELLOW}📝 Please provide the following information:${NC}"
 read -p "GitHub Username: " GITHUB_USERNAME
 read -p "GitHub Repository Name: " GITHUB_REPO
 read -p "Neon Database URL: " DATABASE_URL
-read -s -p "PASETO Secret Key: " PASETO_SECRET
+read -s -p "PASETO Private Key (openssl rand -hex 32): " PASETO_SECRET
 echo
 read -s -p "SMTP Username: " SMTP_USERNAME
 echo
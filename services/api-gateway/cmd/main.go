@@ -25,6 +25,7 @@ func main() {
 	// Create a new Fiber app
 	app := fiber.New(fiber.Config{
 		ProxyHeader: fiber.HeaderXForwardedFor,
+		BodyLimit:   8 * 1024 * 1024, // Employee photo and import file uploads
 	})
 
 	// Add middleware
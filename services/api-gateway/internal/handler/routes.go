@@ -12,7 +12,7 @@ func SetupRoutes(app *fiber.App) {
 	// Health check endpoint
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
-			"status": "UP",
+			"status":  "UP",
 			"service": "api-gateway",
 		})
 	})
@@ -21,51 +21,241 @@ func SetupRoutes(app *fiber.App) {
 	auth := app.Group("/api/auth")
 	auth.Post("/register", proxy.ForwardToAuthService)
 	auth.Post("/login", proxy.ForwardToAuthService)
+	auth.Post("/login/2fa", proxy.ForwardToAuthService)
 	auth.Get("/verify-email", proxy.ForwardToAuthService)
 	auth.Post("/refresh", proxy.ForwardToAuthService)
 	auth.Post("/forgot-password", proxy.ForwardToAuthService)
 	auth.Post("/reset-password", proxy.ForwardToAuthService)
 	auth.Post("/logout", proxy.ForwardToAuthService)
 	auth.Post("/validate", proxy.ForwardToAuthService)
+	auth.Get("/keys", proxy.ForwardToAuthService)
 
-	// Protected routes - require authentication
+	// Two-factor routes authenticate in the auth service, enrolment also accepts the two-factor
+	// token of a login that must set it up first, which the auth middleware would reject
+	auth.Get("/2fa", proxy.ForwardToAuthService)
+	auth.Post("/2fa/enroll", proxy.ForwardToAuthService)
+	auth.Post("/2fa/verify", proxy.ForwardToAuthService)
+	auth.Post("/2fa/disable", proxy.ForwardToAuthService)
+	auth.Post("/2fa/recovery-codes", proxy.ForwardToAuthService)
+
+	// Employee photos - unprotected so they can be used directly in <img> tags.
+	// Registered before the protected group so the auth middleware does not run for them.
+	app.Get("/api/employees/:id/photo", proxy.ForwardToEmployeeService)
+
+	// Protected routes - require authentication. Every route also requires a permission of the
+	// caller's role, see middleware.RequirePermission for the matrix.
 	protected := app.Group("/api", middleware.AuthMiddleware())
 
 	// Employee Management Routes - all forwarded to employee service
 	employees := protected.Group("/employees")
-	employees.Get("/", proxy.ForwardToEmployeeService)        // GET /api/employees/ -> /employees
-	employees.Post("/", proxy.ForwardToEmployeeService)       // POST /api/employees/ -> /employees
-	employees.Get("/:id", proxy.ForwardToEmployeeService)     // GET /api/employees/:id -> /employees/:id
-	employees.Put("/:id", proxy.ForwardToEmployeeService)     // PUT /api/employees/:id -> /employees/:id
-	employees.Delete("/:id", proxy.ForwardToEmployeeService)  // DELETE /api/employees/:id -> /employees/:id
+	employees.Get("/", middleware.RequirePermission(middleware.PermEmployeesRead), proxy.ForwardToEmployeeService)                     // GET /api/employees/ -> /employees
+	employees.Post("/", middleware.RequirePermission(middleware.PermEmployeesWrite), proxy.ForwardToEmployeeService)                   // POST /api/employees/ -> /employees
+	employees.Post("/import", middleware.RequirePermission(middleware.PermEmployeesWrite), proxy.ForwardToEmployeeService)             // POST /api/employees/import -> /employees/import
+	employees.Get("/:id", middleware.RequirePermission(middleware.PermEmployeesRead), proxy.ForwardToEmployeeService)                  // GET /api/employees/:id -> /employees/:id
+	employees.Put("/:id", middleware.RequirePermission(middleware.PermEmployeesWrite), proxy.ForwardToEmployeeService)                 // PUT /api/employees/:id -> /employees/:id
+	employees.Delete("/:id", middleware.RequirePermission(middleware.PermEmployeesDelete), proxy.ForwardToEmployeeService)             // DELETE /api/employees/:id -> /employees/:id
+	employees.Get("/:id/leave-balances", middleware.RequirePermission(middleware.PermLeaveRead), proxy.ForwardToEmployeeService)       // GET /api/employees/:id/leave-balances -> /employees/:id/leave-balances
+	employees.Post("/:id/terminate", middleware.RequirePermission(middleware.PermEmployeesDelete), proxy.ForwardToEmployeeService)     // POST /api/employees/:id/terminate -> /employees/:id/terminate
+	employees.Post("/:id/restore", middleware.RequirePermission(middleware.PermEmployeesDelete), proxy.ForwardToEmployeeService)       // POST /api/employees/:id/restore -> /employees/:id/restore
+	employees.Post("/:id/photo", middleware.RequirePermission(middleware.PermEmployeesWrite), proxy.ForwardToEmployeeService)          // POST /api/employees/:id/photo -> /employees/:id/photo
+	employees.Delete("/:id/photo", middleware.RequirePermission(middleware.PermEmployeesWrite), proxy.ForwardToEmployeeService)        // DELETE /api/employees/:id/photo -> /employees/:id/photo
+	employees.Put("/:id/user", middleware.RequirePermission(middleware.PermUsersManage), proxy.ForwardToEmployeeService)               // PUT /api/employees/:id/user -> /employees/:id/user
+	employees.Delete("/:id/user", middleware.RequirePermission(middleware.PermUsersManage), proxy.ForwardToEmployeeService)            // DELETE /api/employees/:id/user -> /employees/:id/user
+	employees.Get("/:id/skills", middleware.RequirePermission(middleware.PermEmployeesRead), proxy.ForwardToEmployeeService)           // GET /api/employees/:id/skills -> /employees/:id/skills
+	employees.Post("/:id/skills", middleware.RequirePermission(middleware.PermSkillsWrite), proxy.ForwardToEmployeeService)            // POST /api/employees/:id/skills -> /employees/:id/skills
+	employees.Put("/:id/skills/:skillId", middleware.RequirePermission(middleware.PermSkillsWrite), proxy.ForwardToEmployeeService)    // PUT /api/employees/:id/skills/:skillId -> /employees/:id/skills/:skillId
+	employees.Delete("/:id/skills/:skillId", middleware.RequirePermission(middleware.PermSkillsWrite), proxy.ForwardToEmployeeService) // DELETE /api/employees/:id/skills/:skillId -> /employees/:id/skills/:skillId
+	employees.Get("/:id/role-check", middleware.RequirePermission(middleware.PermSkillsRead), proxy.ForwardToEmployeeService)          // GET /api/employees/:id/role-check -> /employees/:id/role-check
+	employees.Put("/:id/team", middleware.RequirePermission(middleware.PermTeamsWrite), proxy.ForwardToEmployeeService)                // PUT /api/employees/:id/team -> /employees/:id/team
+	employees.Put("/:id/manager", middleware.RequirePermission(middleware.PermTeamsWrite), proxy.ForwardToEmployeeService)             // PUT /api/employees/:id/manager -> /employees/:id/manager
+	employees.Get("/:id/reports", middleware.RequirePermission(middleware.PermTeamsRead), proxy.ForwardToEmployeeService)              // GET /api/employees/:id/reports -> /employees/:id/reports
+	employees.Get("/:id/custom-fields", middleware.RequirePermission(middleware.PermEmployeesRead), proxy.ForwardToEmployeeService)    // GET /api/employees/:id/custom-fields -> /employees/:id/custom-fields
+	employees.Put("/:id/custom-fields", middleware.RequirePermission(middleware.PermEmployeesWrite), proxy.ForwardToEmployeeService)   // PUT /api/employees/:id/custom-fields -> /employees/:id/custom-fields
+	employees.Get("/:id/history", middleware.RequirePermission(middleware.PermEmployeesHistory), proxy.ForwardToEmployeeService)       // GET /api/employees/:id/history -> /employees/:id/history
+
+	// Customer Records Routes - forwarded to employee service
+	customers := protected.Group("/customers")
+	customers.Get("/", middleware.RequirePermission(middleware.PermCustomersRead), proxy.ForwardToEmployeeService)                         // GET /api/customers/ -> /customers (search + pagination)
+	customers.Post("/", middleware.RequirePermission(middleware.PermCustomersWrite), proxy.ForwardToEmployeeService)                       // POST /api/customers/ -> /customers
+	customers.Get("/:id", middleware.RequirePermission(middleware.PermCustomersRead), proxy.ForwardToEmployeeService)                      // GET /api/customers/:id -> /customers/:id
+	customers.Put("/:id", middleware.RequirePermission(middleware.PermCustomersWrite), proxy.ForwardToEmployeeService)                     // PUT /api/customers/:id -> /customers/:id
+	customers.Delete("/:id", middleware.RequirePermission(middleware.PermCustomersDelete), proxy.ForwardToEmployeeService)                 // DELETE /api/customers/:id -> /customers/:id
+	customers.Put("/:id/preferred-employees", middleware.RequirePermission(middleware.PermCustomersWrite), proxy.ForwardToEmployeeService) // PUT /api/customers/:id/preferred-employees
+	customers.Get("/:id/duplicates", middleware.RequirePermission(middleware.PermCustomersRead), proxy.ForwardToEmployeeService)           // GET /api/customers/:id/duplicates
+	customers.Post("/:id/merge", middleware.RequirePermission(middleware.PermCustomersDelete), proxy.ForwardToEmployeeService)             // POST /api/customers/:id/merge
 
 	// Schedule Management Routes - forwarded to employee service
 	schedules := protected.Group("/schedules")
-	schedules.Get("/", proxy.ForwardToEmployeeService)        // GET /api/schedules/ -> /schedules
-	schedules.Post("/", proxy.ForwardToEmployeeService)       // POST /api/schedules/ -> /schedules
-	schedules.Get("/:id", proxy.ForwardToEmployeeService)     // GET /api/schedules/:id -> /schedules/:id
-	schedules.Put("/:id", proxy.ForwardToEmployeeService)     // PUT /api/schedules/:id -> /schedules/:id
-	schedules.Delete("/:id", proxy.ForwardToEmployeeService)  // DELETE /api/schedules/:id -> /schedules/:id
+	schedules.Get("/", middleware.RequirePermission(middleware.PermSchedulesRead), proxy.ForwardToEmployeeService)        // GET /api/schedules/ -> /schedules
+	schedules.Post("/", middleware.RequirePermission(middleware.PermSchedulesWrite), proxy.ForwardToEmployeeService)      // POST /api/schedules/ -> /schedules
+	schedules.Get("/:id", middleware.RequirePermission(middleware.PermSchedulesRead), proxy.ForwardToEmployeeService)     // GET /api/schedules/:id -> /schedules/:id
+	schedules.Put("/:id", middleware.RequirePermission(middleware.PermSchedulesWrite), proxy.ForwardToEmployeeService)    // PUT /api/schedules/:id -> /schedules/:id
+	schedules.Delete("/:id", middleware.RequirePermission(middleware.PermSchedulesWrite), proxy.ForwardToEmployeeService) // DELETE /api/schedules/:id -> /schedules/:id
 
 	// One-time Block Management Routes - forwarded to employee service
 	onetimeBlocks := protected.Group("/onetime-blocks")
-	onetimeBlocks.Get("/", proxy.ForwardToEmployeeService)        // GET /api/onetime-blocks/ -> /onetime-blocks
-	onetimeBlocks.Post("/", proxy.ForwardToEmployeeService)       // POST /api/onetime-blocks/ -> /onetime-blocks
-	onetimeBlocks.Get("/:id", proxy.ForwardToEmployeeService)     // GET /api/onetime-blocks/:id -> /onetime-blocks/:id
-	onetimeBlocks.Put("/:id", proxy.ForwardToEmployeeService)     // PUT /api/onetime-blocks/:id -> /onetime-blocks/:id
-	onetimeBlocks.Delete("/:id", proxy.ForwardToEmployeeService)  // DELETE /api/onetime-blocks/:id -> /onetime-blocks/:id
+	onetimeBlocks.Get("/", middleware.RequirePermission(middleware.PermSchedulesRead), proxy.ForwardToEmployeeService)        // GET /api/onetime-blocks/ -> /onetime-blocks
+	onetimeBlocks.Post("/", middleware.RequirePermission(middleware.PermSchedulesWrite), proxy.ForwardToEmployeeService)      // POST /api/onetime-blocks/ -> /onetime-blocks
+	onetimeBlocks.Get("/:id", middleware.RequirePermission(middleware.PermSchedulesRead), proxy.ForwardToEmployeeService)     // GET /api/onetime-blocks/:id -> /onetime-blocks/:id
+	onetimeBlocks.Put("/:id", middleware.RequirePermission(middleware.PermSchedulesWrite), proxy.ForwardToEmployeeService)    // PUT /api/onetime-blocks/:id -> /onetime-blocks/:id
+	onetimeBlocks.Delete("/:id", middleware.RequirePermission(middleware.PermSchedulesWrite), proxy.ForwardToEmployeeService) // DELETE /api/onetime-blocks/:id -> /onetime-blocks/:id
 
 	// Recurring Break Management Routes - forwarded to employee service
 	recurringBreaks := protected.Group("/recurring-breaks")
-	recurringBreaks.Get("/", proxy.ForwardToEmployeeService)        // GET /api/recurring-breaks/ -> /recurring-breaks
-	recurringBreaks.Post("/", proxy.ForwardToEmployeeService)       // POST /api/recurring-breaks/ -> /recurring-breaks
-	recurringBreaks.Get("/:id", proxy.ForwardToEmployeeService)     // GET /api/recurring-breaks/:id -> /recurring-breaks/:id
-	recurringBreaks.Put("/:id", proxy.ForwardToEmployeeService)     // PUT /api/recurring-breaks/:id -> /recurring-breaks/:id
-	recurringBreaks.Delete("/:id", proxy.ForwardToEmployeeService)  // DELETE /api/recurring-breaks/:id -> /recurring-breaks/:id
+	recurringBreaks.Get("/", middleware.RequirePermission(middleware.PermSchedulesRead), proxy.ForwardToEmployeeService)        // GET /api/recurring-breaks/ -> /recurring-breaks
+	recurringBreaks.Post("/", middleware.RequirePermission(middleware.PermSchedulesWrite), proxy.ForwardToEmployeeService)      // POST /api/recurring-breaks/ -> /recurring-breaks
+	recurringBreaks.Get("/:id", middleware.RequirePermission(middleware.PermSchedulesRead), proxy.ForwardToEmployeeService)     // GET /api/recurring-breaks/:id -> /recurring-breaks/:id
+	recurringBreaks.Put("/:id", middleware.RequirePermission(middleware.PermSchedulesWrite), proxy.ForwardToEmployeeService)    // PUT /api/recurring-breaks/:id -> /recurring-breaks/:id
+	recurringBreaks.Delete("/:id", middleware.RequirePermission(middleware.PermSchedulesWrite), proxy.ForwardToEmployeeService) // DELETE /api/recurring-breaks/:id -> /recurring-breaks/:id
 
 	// Availability Checking Routes - forwarded to employee service
 	availability := protected.Group("/availability")
-	availability.Post("/", proxy.ForwardToEmployeeService)          // POST /api/availability/ -> /availability
+	availability.Post("/", middleware.RequirePermission(middleware.PermAvailability), proxy.ForwardToEmployeeService)      // POST /api/availability/ -> /availability
+	availability.Post("/chain", middleware.RequirePermission(middleware.PermAvailability), proxy.ForwardToEmployeeService) // POST /api/availability/chain -> /availability/chain
+
+	// Resource Management Routes (chairs, rooms, equipment) - forwarded to employee service
+	resources := protected.Group("/resources")
+	resources.Get("/", middleware.RequirePermission(middleware.PermResourcesRead), proxy.ForwardToEmployeeService)                              // GET /api/resources/ -> /resources
+	resources.Post("/", middleware.RequirePermission(middleware.PermResourcesWrite), proxy.ForwardToEmployeeService)                            // POST /api/resources/ -> /resources
+	resources.Post("/availability", middleware.RequirePermission(middleware.PermAvailability), proxy.ForwardToEmployeeService)                  // POST /api/resources/availability -> /resources/availability
+	resources.Get("/:id", middleware.RequirePermission(middleware.PermResourcesRead), proxy.ForwardToEmployeeService)                           // GET /api/resources/:id -> /resources/:id
+	resources.Put("/:id", middleware.RequirePermission(middleware.PermResourcesWrite), proxy.ForwardToEmployeeService)                          // PUT /api/resources/:id -> /resources/:id
+	resources.Delete("/:id", middleware.RequirePermission(middleware.PermResourcesWrite), proxy.ForwardToEmployeeService)                       // DELETE /api/resources/:id -> /resources/:id
+	resources.Get("/:id/opening-hours", middleware.RequirePermission(middleware.PermResourcesRead), proxy.ForwardToEmployeeService)             // GET /api/resources/:id/opening-hours -> /resources/:id/opening-hours
+	resources.Post("/:id/opening-hours", middleware.RequirePermission(middleware.PermResourcesWrite), proxy.ForwardToEmployeeService)           // POST /api/resources/:id/opening-hours -> /resources/:id/opening-hours
+	resources.Delete("/:id/opening-hours/:hourId", middleware.RequirePermission(middleware.PermResourcesWrite), proxy.ForwardToEmployeeService) // DELETE /api/resources/:id/opening-hours/:hourId
+
+	// Resource Block Management Routes - forwarded to employee service
+	resourceBlocks := protected.Group("/resource-blocks")
+	resourceBlocks.Get("/", middleware.RequirePermission(middleware.PermResourcesRead), proxy.ForwardToEmployeeService)        // GET /api/resource-blocks/ -> /resource-blocks
+	resourceBlocks.Post("/", middleware.RequirePermission(middleware.PermResourcesWrite), proxy.ForwardToEmployeeService)      // POST /api/resource-blocks/ -> /resource-blocks
+	resourceBlocks.Put("/:id", middleware.RequirePermission(middleware.PermResourcesWrite), proxy.ForwardToEmployeeService)    // PUT /api/resource-blocks/:id -> /resource-blocks/:id
+	resourceBlocks.Delete("/:id", middleware.RequirePermission(middleware.PermResourcesWrite), proxy.ForwardToEmployeeService) // DELETE /api/resource-blocks/:id -> /resource-blocks/:id
+
+	// Leave Request Routes (time off with manager approval) - forwarded to employee service
+	leaveRequests := protected.Group("/leave-requests")
+	leaveRequests.Get("/", middleware.RequirePermission(middleware.PermLeaveRead), proxy.ForwardToEmployeeService)                // GET /api/leave-requests/ -> /leave-requests
+	leaveRequests.Post("/", middleware.RequirePermission(middleware.PermLeaveRequest), proxy.ForwardToEmployeeService)            // POST /api/leave-requests/ -> /leave-requests
+	leaveRequests.Get("/:id", middleware.RequirePermission(middleware.PermLeaveRead), proxy.ForwardToEmployeeService)             // GET /api/leave-requests/:id -> /leave-requests/:id (includes conflicts)
+	leaveRequests.Post("/:id/approve", middleware.RequirePermission(middleware.PermLeaveApprove), proxy.ForwardToEmployeeService) // POST /api/leave-requests/:id/approve
+	leaveRequests.Post("/:id/reject", middleware.RequirePermission(middleware.PermLeaveApprove), proxy.ForwardToEmployeeService)  // POST /api/leave-requests/:id/reject
+	leaveRequests.Post("/:id/cancel", middleware.RequirePermission(middleware.PermLeaveRequest), proxy.ForwardToEmployeeService)  // POST /api/leave-requests/:id/cancel
+
+	// Leave Entitlement Routes (yearly allowances, accrual, carry-over) - forwarded to employee service
+	leaveEntitlements := protected.Group("/leave-entitlements")
+	leaveEntitlements.Get("/", middleware.RequirePermission(middleware.PermLeaveRead), proxy.ForwardToEmployeeService)               // GET /api/leave-entitlements/ -> /leave-entitlements
+	leaveEntitlements.Post("/", middleware.RequirePermission(middleware.PermLeaveEntitlements), proxy.ForwardToEmployeeService)      // POST /api/leave-entitlements/ -> /leave-entitlements
+	leaveEntitlements.Put("/:id", middleware.RequirePermission(middleware.PermLeaveEntitlements), proxy.ForwardToEmployeeService)    // PUT /api/leave-entitlements/:id -> /leave-entitlements/:id
+	leaveEntitlements.Delete("/:id", middleware.RequirePermission(middleware.PermLeaveEntitlements), proxy.ForwardToEmployeeService) // DELETE /api/leave-entitlements/:id -> /leave-entitlements/:id
+
+	// Shift Swap Routes (offer -> accept -> manager approval) - forwarded to employee service
+	shiftSwaps := protected.Group("/shift-swaps")
+	shiftSwaps.Get("/", middleware.RequirePermission(middleware.PermShiftSwapsRead), proxy.ForwardToEmployeeService)                // GET /api/shift-swaps/ -> /shift-swaps
+	shiftSwaps.Post("/", middleware.RequirePermission(middleware.PermShiftSwapsRequest), proxy.ForwardToEmployeeService)            // POST /api/shift-swaps/ -> /shift-swaps
+	shiftSwaps.Get("/:id", middleware.RequirePermission(middleware.PermShiftSwapsRead), proxy.ForwardToEmployeeService)             // GET /api/shift-swaps/:id -> /shift-swaps/:id
+	shiftSwaps.Post("/:id/accept", middleware.RequirePermission(middleware.PermShiftSwapsRequest), proxy.ForwardToEmployeeService)  // POST /api/shift-swaps/:id/accept
+	shiftSwaps.Post("/:id/approve", middleware.RequirePermission(middleware.PermShiftSwapsApprove), proxy.ForwardToEmployeeService) // POST /api/shift-swaps/:id/approve
+	shiftSwaps.Post("/:id/reject", middleware.RequirePermission(middleware.PermShiftSwapsApprove), proxy.ForwardToEmployeeService)  // POST /api/shift-swaps/:id/reject
+	shiftSwaps.Post("/:id/cancel", middleware.RequirePermission(middleware.PermShiftSwapsRequest), proxy.ForwardToEmployeeService)  // POST /api/shift-swaps/:id/cancel
+
+	// Open Shift Routes (posted by managers, claimed by eligible employees) - forwarded to employee service
+	openShifts := protected.Group("/open-shifts")
+	openShifts.Get("/", middleware.RequirePermission(middleware.PermOpenShiftsRead), proxy.ForwardToEmployeeService)                               // GET /api/open-shifts/ -> /open-shifts
+	openShifts.Post("/", middleware.RequirePermission(middleware.PermOpenShiftsManage), proxy.ForwardToEmployeeService)                            // POST /api/open-shifts/ -> /open-shifts
+	openShifts.Get("/:id", middleware.RequirePermission(middleware.PermOpenShiftsRead), proxy.ForwardToEmployeeService)                            // GET /api/open-shifts/:id -> /open-shifts/:id (includes claims)
+	openShifts.Get("/:id/eligible-employees", middleware.RequirePermission(middleware.PermOpenShiftsManage), proxy.ForwardToEmployeeService)       // GET /api/open-shifts/:id/eligible-employees
+	openShifts.Post("/:id/claims", middleware.RequirePermission(middleware.PermOpenShiftsClaim), proxy.ForwardToEmployeeService)                   // POST /api/open-shifts/:id/claims
+	openShifts.Post("/:id/claims/:claimId/approve", middleware.RequirePermission(middleware.PermOpenShiftsManage), proxy.ForwardToEmployeeService) // POST /api/open-shifts/:id/claims/:claimId/approve
+	openShifts.Post("/:id/claims/:claimId/reject", middleware.RequirePermission(middleware.PermOpenShiftsManage), proxy.ForwardToEmployeeService)  // POST /api/open-shifts/:id/claims/:claimId/reject
+	openShifts.Post("/:id/cancel", middleware.RequirePermission(middleware.PermOpenShiftsManage), proxy.ForwardToEmployeeService)                  // POST /api/open-shifts/:id/cancel
+
+	// Time Clock Routes (clock-in/out, breaks, schedule variance) - forwarded to employee service
+	timeEntries := protected.Group("/time-entries")
+	timeEntries.Get("/", middleware.RequirePermission(middleware.PermTimeRead), proxy.ForwardToEmployeeService)              // GET /api/time-entries/ -> /time-entries
+	timeEntries.Post("/clock-in", middleware.RequirePermission(middleware.PermTimeClock), proxy.ForwardToEmployeeService)    // POST /api/time-entries/clock-in -> /time-entries/clock-in
+	timeEntries.Post("/clock-out", middleware.RequirePermission(middleware.PermTimeClock), proxy.ForwardToEmployeeService)   // POST /api/time-entries/clock-out -> /time-entries/clock-out
+	timeEntries.Post("/break-start", middleware.RequirePermission(middleware.PermTimeClock), proxy.ForwardToEmployeeService) // POST /api/time-entries/break-start -> /time-entries/break-start
+	timeEntries.Post("/break-end", middleware.RequirePermission(middleware.PermTimeClock), proxy.ForwardToEmployeeService)   // POST /api/time-entries/break-end -> /time-entries/break-end
+	timeEntries.Get("/variance", middleware.RequirePermission(middleware.PermTimeRead), proxy.ForwardToEmployeeService)      // GET /api/time-entries/variance -> /time-entries/variance
+	timeEntries.Get("/:id", middleware.RequirePermission(middleware.PermTimeRead), proxy.ForwardToEmployeeService)           // GET /api/time-entries/:id -> /time-entries/:id
+	timeEntries.Post("/", middleware.RequirePermission(middleware.PermTimeCorrect), proxy.ForwardToEmployeeService)          // POST /api/time-entries/ -> /time-entries
+	timeEntries.Put("/:id", middleware.RequirePermission(middleware.PermTimeCorrect), proxy.ForwardToEmployeeService)        // PUT /api/time-entries/:id -> /time-entries/:id
+
+	// Timesheet Routes (weekly hours, approval, payroll export) - forwarded to employee service
+	timesheets := protected.Group("/timesheets")
+	timesheets.Get("/", middleware.RequirePermission(middleware.PermTimesheetsRead), proxy.ForwardToEmployeeService)               // GET /api/timesheets/ -> /timesheets
+	timesheets.Post("/approve", middleware.RequirePermission(middleware.PermTimesheetsApprove), proxy.ForwardToEmployeeService)    // POST /api/timesheets/approve -> /timesheets/approve
+	timesheets.Get("/export", middleware.RequirePermission(middleware.PermPayrollExport), proxy.ForwardToEmployeeService)          // GET /api/timesheets/export -> /timesheets/export
+	timesheets.Post("/:id/reopen", middleware.RequirePermission(middleware.PermTimesheetsApprove), proxy.ForwardToEmployeeService) // POST /api/timesheets/:id/reopen -> /timesheets/:id/reopen
+
+	// Holiday Routes - forwarded to employee service
+	holidays := protected.Group("/holidays")
+	holidays.Get("/", middleware.RequirePermission(middleware.PermHolidaysRead), proxy.ForwardToEmployeeService)        // GET /api/holidays/ -> /holidays
+	holidays.Post("/", middleware.RequirePermission(middleware.PermHolidaysWrite), proxy.ForwardToEmployeeService)      // POST /api/holidays/ -> /holidays
+	holidays.Delete("/:id", middleware.RequirePermission(middleware.PermHolidaysWrite), proxy.ForwardToEmployeeService) // DELETE /api/holidays/:id -> /holidays/:id
+
+	// Skill Requirement Routes (role requirements, expiring certifications) - forwarded to employee service
+	roleRequirements := protected.Group("/role-requirements")
+	roleRequirements.Get("/", middleware.RequirePermission(middleware.PermSkillsRead), proxy.ForwardToEmployeeService)        // GET /api/role-requirements/ -> /role-requirements
+	roleRequirements.Post("/", middleware.RequirePermission(middleware.PermSkillsWrite), proxy.ForwardToEmployeeService)      // POST /api/role-requirements/ -> /role-requirements
+	roleRequirements.Delete("/:id", middleware.RequirePermission(middleware.PermSkillsWrite), proxy.ForwardToEmployeeService) // DELETE /api/role-requirements/:id -> /role-requirements/:id
+
+	certifications := protected.Group("/certifications")
+	certifications.Get("/expiring", middleware.RequirePermission(middleware.PermSkillsRead), proxy.ForwardToEmployeeService) // GET /api/certifications/expiring -> /certifications/expiring
+
+	// Self-service Routes (the caller's own employee data) - forwarded to employee service
+	me := protected.Group("/me")
+	me.Get("/", middleware.RequirePermission(middleware.PermSelf), proxy.ForwardToEmployeeService)             // GET /api/me/ -> /me
+	me.Get("/schedule", middleware.RequirePermission(middleware.PermSelf), proxy.ForwardToEmployeeService)     // GET /api/me/schedule -> /me/schedule
+	me.Get("/availability", middleware.RequirePermission(middleware.PermSelf), proxy.ForwardToEmployeeService) // GET /api/me/availability -> /me/availability
+	me.Get("/blocks", middleware.RequirePermission(middleware.PermSelf), proxy.ForwardToEmployeeService)       // GET /api/me/blocks -> /me/blocks
+	me.Get("/reports", middleware.RequirePermission(middleware.PermSelf), proxy.ForwardToEmployeeService)      // GET /api/me/reports -> /me/reports
+
+	// Team Routes - forwarded to employee service
+	teams := protected.Group("/teams")
+	teams.Get("/", middleware.RequirePermission(middleware.PermTeamsRead), proxy.ForwardToEmployeeService)            // GET /api/teams/ -> /teams
+	teams.Post("/", middleware.RequirePermission(middleware.PermTeamsWrite), proxy.ForwardToEmployeeService)          // POST /api/teams/ -> /teams
+	teams.Get("/:id", middleware.RequirePermission(middleware.PermTeamsRead), proxy.ForwardToEmployeeService)         // GET /api/teams/:id -> /teams/:id
+	teams.Put("/:id", middleware.RequirePermission(middleware.PermTeamsWrite), proxy.ForwardToEmployeeService)        // PUT /api/teams/:id -> /teams/:id
+	teams.Delete("/:id", middleware.RequirePermission(middleware.PermTeamsWrite), proxy.ForwardToEmployeeService)     // DELETE /api/teams/:id -> /teams/:id
+	teams.Get("/:id/members", middleware.RequirePermission(middleware.PermTeamsRead), proxy.ForwardToEmployeeService) // GET /api/teams/:id/members -> /teams/:id/members
+
+	// Location Routes - forwarded to employee service
+	locations := protected.Group("/locations")
+	locations.Get("/", middleware.RequirePermission(middleware.PermLocationsRead), proxy.ForwardToEmployeeService)                              // GET /api/locations/ -> /locations
+	locations.Post("/", middleware.RequirePermission(middleware.PermLocationsWrite), proxy.ForwardToEmployeeService)                            // POST /api/locations/ -> /locations
+	locations.Get("/:id", middleware.RequirePermission(middleware.PermLocationsRead), proxy.ForwardToEmployeeService)                           // GET /api/locations/:id -> /locations/:id
+	locations.Put("/:id", middleware.RequirePermission(middleware.PermLocationsWrite), proxy.ForwardToEmployeeService)                          // PUT /api/locations/:id -> /locations/:id
+	locations.Delete("/:id", middleware.RequirePermission(middleware.PermLocationsWrite), proxy.ForwardToEmployeeService)                       // DELETE /api/locations/:id -> /locations/:id
+	locations.Get("/:id/opening-hours", middleware.RequirePermission(middleware.PermLocationsRead), proxy.ForwardToEmployeeService)             // GET /api/locations/:id/opening-hours -> /locations/:id/opening-hours
+	locations.Post("/:id/opening-hours", middleware.RequirePermission(middleware.PermLocationsWrite), proxy.ForwardToEmployeeService)           // POST /api/locations/:id/opening-hours -> /locations/:id/opening-hours
+	locations.Delete("/:id/opening-hours/:hourId", middleware.RequirePermission(middleware.PermLocationsWrite), proxy.ForwardToEmployeeService) // DELETE /api/locations/:id/opening-hours/:hourId
+	locations.Get("/:id/coverage", middleware.RequirePermission(middleware.PermSchedulesRead), proxy.ForwardToEmployeeService)                  // GET /api/locations/:id/coverage -> /locations/:id/coverage
+
+	// Custom Field Routes - forwarded to employee service
+	customFields := protected.Group("/custom-fields")
+	customFields.Get("/", middleware.RequirePermission(middleware.PermCustomFieldsRead), proxy.ForwardToEmployeeService)        // GET /api/custom-fields/ -> /custom-fields
+	customFields.Post("/", middleware.RequirePermission(middleware.PermCustomFieldsWrite), proxy.ForwardToEmployeeService)      // POST /api/custom-fields/ -> /custom-fields
+	customFields.Get("/:id", middleware.RequirePermission(middleware.PermCustomFieldsRead), proxy.ForwardToEmployeeService)     // GET /api/custom-fields/:id -> /custom-fields/:id
+	customFields.Put("/:id", middleware.RequirePermission(middleware.PermCustomFieldsWrite), proxy.ForwardToEmployeeService)    // PUT /api/custom-fields/:id -> /custom-fields/:id
+	customFields.Delete("/:id", middleware.RequirePermission(middleware.PermCustomFieldsWrite), proxy.ForwardToEmployeeService) // DELETE /api/custom-fields/:id -> /custom-fields/:id
+
+	// Session Routes (the caller's signed-in devices) - forwarded to auth service
+	sessions := protected.Group("/auth/sessions")
+	sessions.Get("/", middleware.RequirePermission(middleware.PermOwnSessions), proxy.ForwardToAuthService)       // GET /api/auth/sessions/ -> /api/auth/sessions
+	sessions.Delete("/", middleware.RequirePermission(middleware.PermOwnSessions), proxy.ForwardToAuthService)    // DELETE /api/auth/sessions/ -> /api/auth/sessions (log out everywhere)
+	sessions.Delete("/:id", middleware.RequirePermission(middleware.PermOwnSessions), proxy.ForwardToAuthService) // DELETE /api/auth/sessions/:id -> /api/auth/sessions/:id
+
+	// User Administration Routes (roles, sessions and two-factor authentication) - forwarded to auth service
+	admin := protected.Group("/auth/admin")
+	admin.Get("/users", middleware.RequirePermission(middleware.PermUsersManage), proxy.ForwardToAuthService)                            // GET /api/auth/admin/users -> /api/auth/admin/users
+	admin.Put("/users/:id/role", middleware.RequirePermission(middleware.PermUsersManage), proxy.ForwardToAuthService)                   // PUT /api/auth/admin/users/:id/role -> /api/auth/admin/users/:id/role
+	admin.Get("/users/:id/sessions", middleware.RequirePermission(middleware.PermUsersManage), proxy.ForwardToAuthService)               // GET /api/auth/admin/users/:id/sessions
+	admin.Delete("/users/:id/sessions", middleware.RequirePermission(middleware.PermUsersManage), proxy.ForwardToAuthService)            // DELETE /api/auth/admin/users/:id/sessions
+	admin.Delete("/users/:id/sessions/:sessionId", middleware.RequirePermission(middleware.PermUsersManage), proxy.ForwardToAuthService) // DELETE /api/auth/admin/users/:id/sessions/:sessionId
+	admin.Delete("/users/:id/2fa", middleware.RequirePermission(middleware.PermUsersManage), proxy.ForwardToAuthService)                 // DELETE /api/auth/admin/users/:id/2fa
+	admin.Get("/2fa-roles", middleware.RequirePermission(middleware.PermUsersManage), proxy.ForwardToAuthService)                        // GET /api/auth/admin/2fa-roles
+	admin.Put("/2fa-roles", middleware.RequirePermission(middleware.PermUsersManage), proxy.ForwardToAuthService)                        // PUT /api/auth/admin/2fa-roles
 
 	// Future routes for additional services can be added here
 }
@@ -1,23 +1,52 @@
 package middleware
 
 import (
-	"encoding/json"
-	"net/http"
+	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/o1egl/paseto"
 )
 
-type ValidateResponse struct {
-	Valid  bool   `json:"valid"`
-	UserID string `json:"user_id"`
-	Role   string `json:"role"`
+// Tokens must be issued by the auth service for this API
+const (
+	tokenIssuer          = "auth-service"
+	defaultTokenAudience = "staff-scheduler-api"
+)
+
+// accessClaims are the claims the gateway uses from a verified access token
+type accessClaims struct {
+	UserID    string
+	Role      string
+	SessionID string
+}
+
+// tokenFooter names the key a token was signed with
+type tokenFooter struct {
+	KeyID string `json:"kid"`
 }
 
-// AuthMiddleware validates access tokens by calling the auth service
+// AuthMiddleware verifies PASETO v2.public access tokens with the public keys published by the
+// auth service. The keys and the list of revoked sessions are fetched in the background and
+// refreshed periodically, so requests do not wait for the auth service.
 func AuthMiddleware() fiber.Handler {
+	// Get auth service URL
+	authServiceURL := os.Getenv("AUTH_SERVICE_URL")
+	if authServiceURL == "" {
+		authServiceURL = "http://localhost:3004"
+	}
+	audience := os.Getenv("TOKEN_AUDIENCE")
+	if audience == "" {
+		audience = defaultTokenAudience
+	}
+
+	keys := newKeySet(authServiceURL)
+	go keys.refreshPeriodically(keyRefreshInterval())
+	revocations := newRevocationList(authServiceURL, accessTokenLifetime())
+	go revocations.pollPeriodically(revocationPollInterval())
+
 	return func(c *fiber.Ctx) error {
 		// Extract token from Authorization header
 		authHeader := c.Get("Authorization")
@@ -41,64 +70,68 @@ func AuthMiddleware() fiber.Handler {
 			})
 		}
 
-		// Get auth service URL
-		authServiceURL := os.Getenv("AUTH_SERVICE_URL")
-		if authServiceURL == "" {
-			authServiceURL = "http://localhost:3004"
-		}
-
-		// Create request to validate token with auth service
-		req, err := http.NewRequest("POST", authServiceURL+"/api/auth/validate", nil)
-		if err != nil {
-			return c.Status(500).JSON(fiber.Map{
-				"error": "failed to create validation request",
-			})
-		}
-
-		// Forward the Authorization header to auth service
-		req.Header.Set("Authorization", authHeader)
-		req.Header.Set("Content-Type", "application/json")
-
-		// Make request to auth service
-		client := &http.Client{
-			Timeout: 30 * time.Second, // Standard timeout for always-on services
-		}
-		resp, err := client.Do(req)
+		// Verify the token locally
+		claims, err := verifyAccessToken(token, keys, audience)
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{
-				"error": "auth service unavailable",
-				"details": err.Error(),
-			})
-		}
-		defer resp.Body.Close()
-
-		// Check if token is valid
-		if resp.StatusCode != 200 {
+			if !keys.loaded() {
+				return c.Status(503).JSON(fiber.Map{
+					"error": "auth service keys unavailable",
+				})
+			}
 			return c.Status(401).JSON(fiber.Map{
 				"error": "invalid or expired access token",
 			})
 		}
 
-		// Parse validation response
-		var validateResp ValidateResponse
-		if err := json.NewDecoder(resp.Body).Decode(&validateResp); err != nil {
-			return c.Status(500).JSON(fiber.Map{
-				"error": "invalid auth service response",
+		// Reject tokens of sessions that were signed out, and every token while the list is unknown or stale
+		if !revocations.loaded() {
+			return c.Status(503).JSON(fiber.Map{
+				"error": "revoked sessions unavailable",
 			})
 		}
-
-		// Double check the valid flag
-		if !validateResp.Valid {
+		if revocations.revoked(claims.SessionID) {
 			return c.Status(401).JSON(fiber.Map{
-				"error": "access token validation failed",
+				"error": "session has been revoked",
 			})
 		}
 
 		// Add user information to request context for downstream services
-		c.Locals("user_id", validateResp.UserID)
-		c.Locals("user_role", validateResp.Role)
+		c.Locals("user_id", claims.UserID)
+		c.Locals("user_role", claims.Role)
 
 		// Continue to the next handler
 		return c.Next()
 	}
 }
+
+// verifyAccessToken checks the signature, expiry, issuer, audience and type of an access token
+func verifyAccessToken(token string, keys *keySet, audience string) (*accessClaims, error) {
+	var footer tokenFooter
+	if err := paseto.ParseFooter(token, &footer); err != nil {
+		return nil, err
+	}
+	publicKey, err := keys.key(footer.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var jsonToken paseto.JSONToken
+	if err := paseto.NewV2().Verify(token, publicKey, &jsonToken, nil); err != nil {
+		return nil, err
+	}
+	if jsonToken.Expiration.IsZero() {
+		return nil, fmt.Errorf("token has no expiration")
+	}
+	if err := jsonToken.Validate(paseto.ValidAt(time.Now()), paseto.ForAudience(audience), paseto.IssuedBy(tokenIssuer)); err != nil {
+		return nil, err
+	}
+	if jsonToken.Get("token_type") != "access" {
+		return nil, fmt.Errorf("not an access token")
+	}
+
+	claims := &accessClaims{UserID: jsonToken.Get("user_id"), Role: jsonToken.Get("role"), SessionID: jsonToken.Get("sid")}
+	if claims.UserID == "" || claims.SessionID == "" {
+		return nil, fmt.Errorf("token has no user or session")
+	}
+	return claims, nil
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"crypto/ed25519"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/o1egl/paseto"
+)
+
+// signTestToken signs a token the way the auth service does, naming the key in the footer
+func signTestToken(t *testing.T, key ed25519.PrivateKey, keyID string, claims paseto.JSONToken) string {
+	t.Helper()
+	token, err := paseto.NewV2().Sign(key, claims, tokenFooter{KeyID: keyID})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	return token
+}
+
+func TestVerifyAccessToken(t *testing.T) {
+	key := testPrivateKey(t, testSeed)
+	server := newKeyServer(t, map[string]ed25519.PrivateKey{"current": key})
+
+	// accessToken returns the claims of a valid access token, changed by modify
+	accessToken := func(modify func(claims *paseto.JSONToken)) paseto.JSONToken {
+		now := time.Now()
+		claims := paseto.JSONToken{
+			Issuer:     tokenIssuer,
+			Audience:   defaultTokenAudience,
+			IssuedAt:   now,
+			NotBefore:  now,
+			Expiration: now.Add(time.Minute),
+		}
+		claims.Set("user_id", "user-1")
+		claims.Set("role", "manager")
+		claims.Set("sid", "session-1")
+		claims.Set("token_type", "access")
+		if modify != nil {
+			modify(&claims)
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		claims  paseto.JSONToken
+		signer  ed25519.PrivateKey // Defaults to the published key
+		keyID   string             // Defaults to the published key ID
+		wantErr string
+	}{
+		{name: "valid", claims: accessToken(nil)},
+		{name: "wrong audience", claims: accessToken(func(c *paseto.JSONToken) { c.Audience = "other-api" }), wantErr: "audience"},
+		{name: "wrong issuer", claims: accessToken(func(c *paseto.JSONToken) { c.Issuer = "someone-else" }), wantErr: "not issued by"},
+		{name: "refresh token", claims: accessToken(func(c *paseto.JSONToken) { c.Set("token_type", "refresh") }), wantErr: "not an access token"},
+		{name: "expired", claims: accessToken(func(c *paseto.JSONToken) { c.Expiration = time.Now().Add(-time.Minute) }), wantErr: "expired"},
+		{name: "no expiration", claims: accessToken(func(c *paseto.JSONToken) { c.Expiration = time.Time{} }), wantErr: "no expiration"},
+		{name: "no session", claims: accessToken(func(c *paseto.JSONToken) { c.Set("sid", "") }), wantErr: "no user or session"},
+		{name: "unknown kid", claims: accessToken(nil), keyID: "unknown", wantErr: "unknown key"},
+		{name: "signed with another key", claims: accessToken(nil), signer: testPrivateKey(t, otherTestSeed), wantErr: "invalid signature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := newKeySet(server.URL)
+			if err := keys.refresh(true); err != nil {
+				t.Fatalf("refresh: %v", err)
+			}
+			signer, keyID := key, "current"
+			if tt.signer != nil {
+				signer = tt.signer
+			}
+			if tt.keyID != "" {
+				keyID = tt.keyID
+			}
+
+			claims, err := verifyAccessToken(signTestToken(t, signer, keyID, tt.claims), keys, defaultTokenAudience)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("verifyAccessToken error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verifyAccessToken: %v", err)
+			}
+			want := accessClaims{UserID: "user-1", Role: "manager", SessionID: "session-1"}
+			if *claims != want {
+				t.Errorf("claims = %+v, want %+v", *claims, want)
+			}
+		})
+	}
+}
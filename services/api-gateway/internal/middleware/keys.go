@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Key refresh timing. Unknown key IDs trigger an early refresh so rotated keys are picked up
+// immediately, but at most once per minKeyFetchInterval.
+const (
+	defaultKeyRefreshInterval = 10 * time.Minute
+	minKeyFetchInterval       = 30 * time.Second
+	keyFetchTimeout           = 10 * time.Second
+)
+
+// publishedKeys is the response of the auth service keys endpoint
+type publishedKeys struct {
+	Keys []struct {
+		KeyID     string `json:"kid"`
+		Version   string `json:"version"`
+		PublicKey string `json:"public_key"`
+	} `json:"keys"`
+}
+
+// keySet caches the public keys of the auth service by key ID
+type keySet struct {
+	url         string
+	mutex       sync.RWMutex
+	keys        map[string]ed25519.PublicKey
+	lastAttempt time.Time
+}
+
+func newKeySet(authServiceURL string) *keySet {
+	return &keySet{
+		url:  authServiceURL + "/api/auth/keys",
+		keys: make(map[string]ed25519.PublicKey),
+	}
+}
+
+// key returns the public key with an ID, fetching the keys again when it is unknown
+func (s *keySet) key(keyID string) (ed25519.PublicKey, error) {
+	s.mutex.RLock()
+	key, ok := s.keys[keyID]
+	s.mutex.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	if err := s.refresh(false); err != nil {
+		return nil, err
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if key, ok := s.keys[keyID]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key %q", keyID)
+}
+
+// loaded reports whether any keys have been fetched yet
+func (s *keySet) loaded() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.keys) > 0
+}
+
+// refresh fetches the published keys. Unless forced, it does nothing when the keys were
+// fetched less than minKeyFetchInterval ago.
+func (s *keySet) refresh(force bool) error {
+	s.mutex.Lock()
+	if !force && time.Since(s.lastAttempt) < minKeyFetchInterval {
+		s.mutex.Unlock()
+		return nil
+	}
+	s.lastAttempt = time.Now()
+	s.mutex.Unlock()
+
+	client := &http.Client{Timeout: keyFetchTimeout}
+	resp, err := client.Get(s.url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch public keys: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch public keys: status %d", resp.StatusCode)
+	}
+
+	var published publishedKeys
+	if err := json.NewDecoder(resp.Body).Decode(&published); err != nil {
+		return fmt.Errorf("invalid public keys response: %v", err)
+	}
+	keys := make(map[string]ed25519.PublicKey, len(published.Keys))
+	for _, published := range published.Keys {
+		key, err := hex.DecodeString(published.PublicKey)
+		if published.Version != "v2.public" || err != nil || len(key) != ed25519.PublicKeySize {
+			continue
+		}
+		keys[published.KeyID] = ed25519.PublicKey(key)
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("auth service published no usable public keys")
+	}
+
+	// Replace the whole set so retired keys stop being accepted
+	s.mutex.Lock()
+	s.keys = keys
+	s.mutex.Unlock()
+	return nil
+}
+
+// refreshPeriodically keeps the keys up to date. Failures keep the previous keys.
+func (s *keySet) refreshPeriodically(interval time.Duration) {
+	for {
+		if err := s.refresh(true); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// keyRefreshInterval returns PUBLIC_KEY_REFRESH_INTERVAL or the default
+func keyRefreshInterval() time.Duration {
+	if intervalStr := os.Getenv("PUBLIC_KEY_REFRESH_INTERVAL"); intervalStr != "" {
+		if interval, err := time.ParseDuration(intervalStr); err == nil && interval > 0 {
+			return interval
+		}
+	}
+	return defaultKeyRefreshInterval
+}
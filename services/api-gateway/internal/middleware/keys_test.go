@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Hex encoded Ed25519 seeds of two signing keys
+const (
+	testSeed      = "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"
+	otherTestSeed = "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb"
+)
+
+// testPrivateKey returns the private key of a hex encoded seed
+func testPrivateKey(t *testing.T, seed string) ed25519.PrivateKey {
+	t.Helper()
+	b, err := hex.DecodeString(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ed25519.NewKeyFromSeed(b)
+}
+
+// keyServer stands in for the auth service keys endpoint and counts how often it is fetched
+type keyServer struct {
+	*httptest.Server
+	mutex sync.Mutex
+	keys  map[string]ed25519.PublicKey
+	hits  atomic.Int32
+}
+
+func newKeyServer(t *testing.T, keys map[string]ed25519.PrivateKey) *keyServer {
+	t.Helper()
+	server := &keyServer{}
+	server.publish(keys)
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/auth/keys" {
+			http.NotFound(w, r)
+			return
+		}
+		server.hits.Add(1)
+		var published publishedKeys
+		server.mutex.Lock()
+		for keyID, key := range server.keys {
+			published.Keys = append(published.Keys, struct {
+				KeyID     string `json:"kid"`
+				Version   string `json:"version"`
+				PublicKey string `json:"public_key"`
+			}{keyID, "v2.public", hex.EncodeToString(key)})
+		}
+		server.mutex.Unlock()
+		json.NewEncoder(w).Encode(published)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// publish replaces the published keys
+func (s *keyServer) publish(keys map[string]ed25519.PrivateKey) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keys = make(map[string]ed25519.PublicKey, len(keys))
+	for keyID, key := range keys {
+		s.keys[keyID] = key.Public().(ed25519.PublicKey)
+	}
+}
+
+func TestKeySet(t *testing.T) {
+	current, next := testPrivateKey(t, testSeed), testPrivateKey(t, otherTestSeed)
+
+	tests := []struct {
+		name      string
+		prepare   func(server *keyServer, keys *keySet) // Runs after the initial forced refresh
+		keyID     string
+		wantErr   string
+		wantFetch int32 // Fetches after the initial refresh
+	}{
+		{
+			name:  "known key",
+			keyID: "current",
+		},
+		{
+			name: "unknown kid fetches the rotated keys",
+			prepare: func(server *keyServer, keys *keySet) {
+				server.publish(map[string]ed25519.PrivateKey{"current": current, "next": next})
+			},
+			keyID:     "next",
+			wantFetch: 1,
+		},
+		{
+			name: "unknown kid within the rate limit",
+			prepare: func(server *keyServer, keys *keySet) {
+				keys.key("unknown") // Fetches once
+				server.publish(map[string]ed25519.PrivateKey{"current": current, "next": next})
+			},
+			keyID:     "next",
+			wantErr:   "unknown key",
+			wantFetch: 1,
+		},
+		{
+			name: "unknown kid after the rate limit",
+			prepare: func(server *keyServer, keys *keySet) {
+				keys.key("unknown")
+				server.publish(map[string]ed25519.PrivateKey{"current": current, "next": next})
+				keys.lastAttempt = time.Now().Add(-minKeyFetchInterval)
+			},
+			keyID:     "next",
+			wantFetch: 2,
+		},
+		{
+			name: "retired key dropped after refresh",
+			prepare: func(server *keyServer, keys *keySet) {
+				server.publish(map[string]ed25519.PrivateKey{"next": next})
+				if err := keys.refresh(true); err != nil {
+					t.Fatalf("refresh: %v", err)
+				}
+			},
+			keyID:     "current",
+			wantErr:   "unknown key",
+			wantFetch: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newKeyServer(t, map[string]ed25519.PrivateKey{"current": current})
+			keys := newKeySet(server.URL)
+			if keys.loaded() {
+				t.Fatal("loaded before the first refresh")
+			}
+			if err := keys.refresh(true); err != nil {
+				t.Fatalf("refresh: %v", err)
+			}
+			keys.lastAttempt = time.Now().Add(-minKeyFetchInterval) // Refreshed a while ago
+			initialFetches := server.hits.Load()
+			if tt.prepare != nil {
+				tt.prepare(server, keys)
+			}
+
+			key, err := keys.key(tt.keyID)
+			if fetches := server.hits.Load() - initialFetches; fetches != tt.wantFetch {
+				t.Errorf("fetched the keys %d times, want %d", fetches, tt.wantFetch)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("key(%q) error = %v, want one containing %q", tt.keyID, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("key(%q): %v", tt.keyID, err)
+			}
+			if len(key) != ed25519.PublicKeySize {
+				t.Errorf("key(%q) has %d bytes, want %d", tt.keyID, len(key), ed25519.PublicKeySize)
+			}
+		})
+	}
+}
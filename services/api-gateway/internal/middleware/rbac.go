@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// Roles assigned to users by the auth service
+const (
+	RoleOwner        = "owner"
+	RoleManager      = "manager"
+	RoleStaff        = "staff"
+	RoleReceptionist = "receptionist"
+)
+
+// Permission names an action on a group of routes
+type Permission string
+
+// Permissions checked by the gateway routes
+const (
+	PermEmployeesRead    Permission = "employees:read"
+	PermEmployeesWrite   Permission = "employees:write"
+	PermEmployeesDelete  Permission = "employees:delete" // Delete, terminate and restore
+	PermEmployeesHistory Permission = "employees:history"
+
+	PermCustomersRead   Permission = "customers:read"
+	PermCustomersWrite  Permission = "customers:write"
+	PermCustomersDelete Permission = "customers:delete" // Delete and merge
+
+	PermSchedulesRead  Permission = "schedules:read" // Schedules, blocks, breaks and location coverage
+	PermSchedulesWrite Permission = "schedules:write"
+	PermAvailability   Permission = "availability:read"
+
+	PermResourcesRead  Permission = "resources:read"
+	PermResourcesWrite Permission = "resources:write" // Resources, their opening hours and blocks
+
+	PermLeaveRead         Permission = "leave:read"
+	PermLeaveRequest      Permission = "leave:request" // Request and cancel leave
+	PermLeaveApprove      Permission = "leave:approve"
+	PermLeaveEntitlements Permission = "leave:entitlements" // Manage yearly allowances
+
+	PermShiftSwapsRead    Permission = "shift_swaps:read"
+	PermShiftSwapsRequest Permission = "shift_swaps:request" // Offer, accept and cancel swaps
+	PermShiftSwapsApprove Permission = "shift_swaps:approve"
+
+	PermOpenShiftsRead   Permission = "open_shifts:read"
+	PermOpenShiftsClaim  Permission = "open_shifts:claim"
+	PermOpenShiftsManage Permission = "open_shifts:manage" // Post, cancel and decide on claims
+
+	PermTimeClock         Permission = "time:clock"
+	PermTimeRead          Permission = "time:read"
+	PermTimeCorrect       Permission = "time:correct" // Record missed entries and correct punches
+	PermTimesheetsRead    Permission = "timesheets:read"
+	PermTimesheetsApprove Permission = "timesheets:approve" // Approve and reopen
+	PermPayrollExport     Permission = "payroll:export"
+
+	PermHolidaysRead  Permission = "holidays:read"
+	PermHolidaysWrite Permission = "holidays:write"
+
+	PermSkillsRead  Permission = "skills:read" // Role requirements and expiring certifications
+	PermSkillsWrite Permission = "skills:write"
+
+	PermSelf Permission = "self:read" // The caller's own employee data
+
+	PermTeamsRead  Permission = "teams:read"
+	PermTeamsWrite Permission = "teams:write"
+
+	PermLocationsRead  Permission = "locations:read"
+	PermLocationsWrite Permission = "locations:write"
+
+	PermCustomFieldsRead  Permission = "custom_fields:read"
+	PermCustomFieldsWrite Permission = "custom_fields:write"
+
+	PermOwnSessions Permission = "sessions:own" // List and revoke the caller's own sessions
+	PermUsersManage Permission = "users:manage" // List users, assign roles and revoke sessions
+)
+
+// frontDeskPermissions are shared by staff and receptionists
+var frontDeskPermissions = []Permission{
+	PermEmployeesRead,
+	PermCustomersRead,
+	PermSchedulesRead,
+	PermAvailability,
+	PermResourcesRead,
+	PermLeaveRead, PermLeaveRequest,
+	PermShiftSwapsRead, PermShiftSwapsRequest,
+	PermOpenShiftsRead, PermOpenShiftsClaim,
+	PermTimeClock, PermTimeRead,
+	PermTimesheetsRead,
+	PermHolidaysRead,
+	PermSkillsRead,
+	PermSelf,
+	PermOwnSessions,
+	PermTeamsRead,
+	PermLocationsRead,
+	PermCustomFieldsRead,
+}
+
+// managerPermissions run the day-to-day business; setup, payroll and user management stay with owners
+var managerPermissions = append([]Permission{
+	PermEmployeesWrite, PermEmployeesHistory,
+	PermCustomersWrite, PermCustomersDelete,
+	PermSchedulesWrite,
+	PermResourcesWrite,
+	PermLeaveApprove, PermLeaveEntitlements,
+	PermShiftSwapsApprove,
+	PermOpenShiftsManage,
+	PermTimeCorrect,
+	PermTimesheetsApprove,
+	PermHolidaysWrite,
+	PermSkillsWrite,
+	PermTeamsWrite,
+}, frontDeskPermissions...)
+
+// rolePermissions is the permission matrix. Roles not listed here, such as the "user" role of new
+// registrations and of accounts registered before roles existed, have no permissions.
+var rolePermissions = map[string][]Permission{
+	RoleOwner: append([]Permission{
+		PermEmployeesDelete,
+		PermPayrollExport,
+		PermLocationsWrite,
+		PermCustomFieldsWrite,
+		PermUsersManage,
+	}, managerPermissions...),
+	RoleManager:      managerPermissions,
+	RoleReceptionist: append([]Permission{PermCustomersWrite}, frontDeskPermissions...),
+	RoleStaff:        frontDeskPermissions,
+}
+
+// permissionIndex is rolePermissions as a lookup table
+var permissionIndex = buildPermissionIndex()
+
+func buildPermissionIndex() map[string]map[Permission]bool {
+	index := make(map[string]map[Permission]bool, len(rolePermissions))
+	for role, permissions := range rolePermissions {
+		index[role] = make(map[Permission]bool, len(permissions))
+		for _, permission := range permissions {
+			index[role][permission] = true
+		}
+	}
+	return index
+}
+
+// HasPermission reports whether a role grants a permission
+func HasPermission(role string, permission Permission) bool {
+	return permissionIndex[role][permission]
+}
+
+// RequirePermission rejects requests whose user role lacks the permission.
+// It must run after AuthMiddleware, which puts the role into the request locals.
+func RequirePermission(permission Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("user_role").(string)
+		if !HasPermission(role, permission) {
+			return c.Status(403).JSON(fiber.Map{
+				"error":               "insufficient permissions",
+				"required_permission": permission,
+				"role":                role,
+			})
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRevocationPollInterval = 5 * time.Second
+	revocationFetchTimeout        = 10 * time.Second
+	defaultAccessTokenLifetime    = 15 * time.Minute
+)
+
+// revocationList holds the sessions revoked while their access tokens may still be unexpired.
+// Tokens of a revoked session are rejected from the next poll on.
+type revocationList struct {
+	url         string
+	maxAge      time.Duration // How long the list may be trusted after the last successful refresh
+	mutex       sync.RWMutex
+	sessionIDs  map[string]bool
+	lastRefresh time.Time // Zero until the list has been fetched
+}
+
+func newRevocationList(authServiceURL string, maxAge time.Duration) *revocationList {
+	return &revocationList{
+		url:        authServiceURL + "/api/auth/revoked-sessions",
+		maxAge:     maxAge,
+		sessionIDs: make(map[string]bool),
+	}
+}
+
+// revoked reports whether a session is on the list
+func (l *revocationList) revoked(sessionID string) bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.sessionIDs[sessionID]
+}
+
+// loaded reports whether the list is current. Until it has been fetched, revoked sessions cannot be
+// told apart from active ones. Once the last successful refresh is older than an access token lives,
+// tokens of sessions revoked since then may still be unexpired, so a stale list counts as not loaded.
+func (l *revocationList) loaded() bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return !l.lastRefresh.IsZero() && time.Since(l.lastRefresh) <= l.maxAge
+}
+
+// refresh replaces the list with the one published by the auth service
+func (l *revocationList) refresh() error {
+	client := &http.Client{Timeout: revocationFetchTimeout}
+	resp, err := client.Get(l.url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch revoked sessions: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch revoked sessions: status %d", resp.StatusCode)
+	}
+
+	var published struct {
+		SessionIDs []string `json:"session_ids"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&published); err != nil {
+		return fmt.Errorf("invalid revoked sessions response: %v", err)
+	}
+	sessionIDs := make(map[string]bool, len(published.SessionIDs))
+	for _, sessionID := range published.SessionIDs {
+		sessionIDs[sessionID] = true
+	}
+
+	l.mutex.Lock()
+	l.sessionIDs = sessionIDs
+	l.lastRefresh = time.Now()
+	l.mutex.Unlock()
+	return nil
+}
+
+// pollPeriodically keeps the list up to date. Failures keep the previous list until it goes stale.
+func (l *revocationList) pollPeriodically(interval time.Duration) {
+	for {
+		if err := l.refresh(); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// revocationPollInterval returns REVOCATION_POLL_INTERVAL or the default
+func revocationPollInterval() time.Duration {
+	if intervalStr := os.Getenv("REVOCATION_POLL_INTERVAL"); intervalStr != "" {
+		if interval, err := time.ParseDuration(intervalStr); err == nil && interval > 0 {
+			return interval
+		}
+	}
+	return defaultRevocationPollInterval
+}
+
+// accessTokenLifetime returns ACCESS_TOKEN_EXPIRATION, the lifetime of access tokens issued by the
+// auth service, or its default
+func accessTokenLifetime() time.Duration {
+	if durationStr := os.Getenv("ACCESS_TOKEN_EXPIRATION"); durationStr != "" {
+		if duration, err := time.ParseDuration(durationStr); err == nil && duration > 0 {
+			return duration
+		}
+	}
+	return defaultAccessTokenLifetime
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRevocationListLoaded(t *testing.T) {
+	var unavailable atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unavailable.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"session_ids":["session-1"]}`))
+	}))
+	defer server.Close()
+
+	list := newRevocationList(server.URL, time.Minute)
+	if list.loaded() {
+		t.Fatal("loaded before the first refresh")
+	}
+	if err := list.refresh(); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if !list.loaded() || !list.revoked("session-1") || list.revoked("session-2") {
+		t.Fatalf("after refresh loaded = %v, revoked session-1 = %v, session-2 = %v", list.loaded(), list.revoked("session-1"), list.revoked("session-2"))
+	}
+
+	// The auth service goes down: the list is kept until it is older than an access token lives
+	unavailable.Store(true)
+	if err := list.refresh(); err == nil {
+		t.Fatal("refresh succeeded while the auth service is down")
+	}
+	if !list.loaded() {
+		t.Error("not loaded right after a failed refresh")
+	}
+	list.lastRefresh = time.Now().Add(-2 * time.Minute)
+	if list.loaded() {
+		t.Error("loaded with a list older than the access token lifetime")
+	}
+	if !list.revoked("session-1") {
+		t.Error("stale list forgot the revoked session")
+	}
+}
+
+func TestAccessTokenLifetime(t *testing.T) {
+	tests := []struct {
+		env  string
+		want time.Duration
+	}{
+		{"", defaultAccessTokenLifetime},
+		{"5m", 5 * time.Minute},
+		{"not a duration", defaultAccessTokenLifetime},
+		{"-1m", defaultAccessTokenLifetime},
+	}
+	for _, tt := range tests {
+		t.Setenv("ACCESS_TOKEN_EXPIRATION", tt.env)
+		if got := accessTokenLifetime(); got != tt.want {
+			t.Errorf("accessTokenLifetime() with %q = %s, want %s", tt.env, got, tt.want)
+		}
+	}
+}
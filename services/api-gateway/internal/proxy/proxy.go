@@ -40,6 +40,12 @@ type ProxyResponse struct {
 	Error      error
 }
 
+// Headers carrying the authenticated user to downstream services
+const (
+	HeaderUserID   = "X-User-ID"
+	HeaderUserRole = "X-User-Role"
+)
+
 // Global service registry
 var (
 	serviceRegistry = make(map[string]*ServiceState)
@@ -365,6 +371,17 @@ func makeDirectRequest(c *fiber.Ctx, targetURL string) *ProxyResponse {
 	req.Header.Set("X-Forwarded-Host", string(c.Request().Host()))
 	req.Header.Set("X-Forwarded-Proto", "https")
 	
+	// Pass the authenticated user on to downstream services. Client supplied
+	// values are always dropped so the identity can only come from AuthMiddleware.
+	req.Header.Del(HeaderUserID)
+	req.Header.Del(HeaderUserRole)
+	if userID, ok := c.Locals("user_id").(string); ok && userID != "" {
+		req.Header.Set(HeaderUserID, userID)
+	}
+	if userRole, ok := c.Locals("user_role").(string); ok && userRole != "" {
+		req.Header.Set(HeaderUserRole, userRole)
+	}
+	
 	// Make request with timeout
 	client := &http.Client{
 		Timeout: 45 * time.Second, // Reasonable timeout for healthy services
@@ -50,12 +50,23 @@ func main() {
 		AutoCleanTables: autoClean,
 	})
 
-	// AutoMigrate user model
-	err := db.DB.AutoMigrate(&model.User{})
+	// AutoMigrate user, session and two-factor models
+	err := db.DB.AutoMigrate(&model.User{}, &model.Session{}, &model.RefreshToken{}, &model.RecoveryCode{}, &model.TwoFactorRequiredRole{})
 	if err != nil {
 		log.Fatalf("AutoMigrate failed: %v", err)
 	}
 
+	// OWNER_EMAIL names the account made owner at startup, so that somebody can assign the other roles
+	if ownerEmail := os.Getenv("OWNER_EMAIL"); ownerEmail != "" {
+		result := db.DB.Model(&model.User{}).Where("email = ?", ownerEmail).Update("role", model.RoleOwner)
+		if result.Error != nil {
+			log.Fatalf("Owner bootstrap failed: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			log.Println("Warning: OWNER_EMAIL does not belong to a registered user.")
+		}
+	}
+
 	app := fiber.New()
 
 	// CORS is handled by API Gateway - no need to set it here
@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"strings"
+
+	"services/shared/db"
+	"services/shared/utils"
+
+	"github.com/salobook/services/auth-service/internal/model"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type UpdateRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type TwoFactorRolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+// RequireOwner only lets owners through and must run after RequireAuth. The API gateway checks
+// permissions as well; this keeps the admin routes closed when the auth service is reached directly.
+func RequireOwner(c *fiber.Ctx) error {
+	if c.Locals("user_role") != model.RoleOwner {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "insufficient permissions",
+		})
+	}
+	return c.Next()
+}
+
+// ListUsers returns all users with their roles
+func ListUsers(c *fiber.Ctx) error {
+	var users []model.User
+	if err := db.DB.Order("email ASC").Find(&users).Error; err != nil {
+		utils.Error("Failed to list users: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not list users",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"users": users,
+		"roles": model.Roles,
+	})
+}
+
+// UpdateUserRole assigns a role to a user. The new role applies from the next access token,
+// at the latest when the current one expires and is refreshed.
+func UpdateUserRole(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	var req UpdateRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Cannot parse JSON",
+		})
+	}
+	role := strings.ToLower(strings.TrimSpace(req.Role))
+	if !model.IsValidRole(role) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid role, must be one of: " + strings.Join(model.Roles, ", "),
+		})
+	}
+
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	var user model.User
+	if err := tx.Where("id = ?", userID).First(&user).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+
+	// Somebody has to be able to assign roles
+	if user.Role == model.RoleOwner && role != model.RoleOwner {
+		var owners int64
+		if err := tx.Model(&model.User{}).Where("role = ?", model.RoleOwner).Count(&owners).Error; err != nil {
+			utils.Error("Failed to count owners: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Could not update role",
+			})
+		}
+		if owners <= 1 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Cannot remove the last owner",
+			})
+		}
+	}
+
+	if err := tx.Model(&user).Update("role", role).Error; err != nil {
+		utils.Error("Failed to update role: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not update role",
+		})
+	}
+	if err := tx.Commit().Error; err != nil {
+		utils.Error("Transaction commit failed: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not update role",
+		})
+	}
+
+	utils.Info("User " + user.ID.String() + " was given role " + role + " by " + c.Locals("user_id").(uuid.UUID).String())
+	return c.JSON(user)
+}
+
+// ListUserSessions returns the active sessions of any user
+func ListUserSessions(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	sessions, err := activeSessions(userID)
+	if err != nil {
+		utils.Error("Failed to list sessions: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not list sessions",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"sessions": sessions,
+	})
+}
+
+// RevokeUserSession ends one session of any user
+func RevokeUserSession(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+	sessionID, err := uuid.Parse(c.Params("sessionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid session ID",
+		})
+	}
+
+	found, err := revokeUserSession(userID, sessionID, model.SessionRevokedByAdmin)
+	if err != nil {
+		utils.Error("Failed to revoke session: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not revoke session",
+		})
+	}
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Session not found",
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// RevokeAllUserSessions signs any user out everywhere
+func RevokeAllUserSessions(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	if err := revokeUserSessions(db.DB, userID, model.SessionRevokedByAdmin); err != nil {
+		utils.Error("Failed to revoke sessions: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not revoke sessions",
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// ListTwoFactorRoles returns the roles whose users must use two-factor authentication
+func ListTwoFactorRoles(c *fiber.Ctx) error {
+	roles := []string{}
+	if err := db.DB.Model(&model.TwoFactorRequiredRole{}).Order("role ASC").Pluck("role", &roles).Error; err != nil {
+		utils.Error("Failed to list two-factor roles: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not list two-factor roles",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"roles": roles,
+	})
+}
+
+// UpdateTwoFactorRoles replaces the roles that require two-factor authentication. Users of a newly
+// added role without it are asked to set it up at their next login or token refresh.
+func UpdateTwoFactorRoles(c *fiber.Ctx) error {
+	var req TwoFactorRolesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Cannot parse JSON",
+		})
+	}
+
+	required := make([]model.TwoFactorRequiredRole, 0, len(req.Roles))
+	seen := make(map[string]bool)
+	for _, role := range req.Roles {
+		role = strings.ToLower(strings.TrimSpace(role))
+		if !model.IsValidRole(role) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid role, must be one of: " + strings.Join(model.Roles, ", "),
+			})
+		}
+		if !seen[role] {
+			seen[role] = true
+			required = append(required, model.TwoFactorRequiredRole{Role: role})
+		}
+	}
+
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Where("1 = 1").Delete(&model.TwoFactorRequiredRole{}).Error; err != nil {
+		utils.Error("Failed to clear two-factor roles: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not update two-factor roles",
+		})
+	}
+	if len(required) > 0 {
+		if err := tx.Create(&required).Error; err != nil {
+			utils.Error("Failed to save two-factor roles: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Could not update two-factor roles",
+			})
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		utils.Error("Transaction commit failed: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not update two-factor roles",
+		})
+	}
+
+	roles := make([]string, len(required))
+	for i, r := range required {
+		roles[i] = r.Role
+	}
+	utils.Info("Two-factor authentication required for roles [" + strings.Join(roles, ", ") + "] by " + c.Locals("user_id").(uuid.UUID).String())
+	return c.JSON(fiber.Map{
+		"roles": roles,
+	})
+}
+
+// ResetUserTwoFactor removes the second factor of any user, for example after a lost phone.
+// If their role requires two-factor authentication they set it up again at their next login.
+func ResetUserTwoFactor(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	var user model.User
+	if err := db.DB.Where("id = ?", userID).First(&user).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+
+	if err := disableTwoFactor(user.ID); err != nil {
+		utils.Error("Failed to reset two-factor authentication: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not reset two-factor authentication",
+		})
+	}
+
+	utils.Info("Two-factor authentication of user " + user.ID.String() + " reset by " + c.Locals("user_id").(uuid.UUID).String())
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
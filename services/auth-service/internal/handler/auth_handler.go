@@ -77,7 +77,7 @@ func Register(c *fiber.Ctx) error {
 		Email:             req.Email,
 		Username:          req.Username,
 		Password:          hashedPassword,
-		Role:              "user",
+		Role:              model.RoleUser, // No access until an owner assigns a role
 		IsVerified:        false,
 		VerificationToken: token,
 		TokenExpiresAt:    expiresAt,
@@ -137,57 +137,64 @@ func Login(c *fiber.Ctx) error {
 		})
 	}
 
-	// Generate access token
-	accessToken, err := authUtils.CreateAccessToken(user.ID.String(), user.Username, user.Role)
+	// With two-factor authentication the login finishes at LoginTwoFactor
+	if user.TOTPEnabled {
+		return twoFactorChallenge(c, user, authUtils.TokenTypeTwoFactor, "two_factor_required")
+	}
+
+	// Users whose role requires two-factor authentication set it up before they are signed in
+	required, err := roleRequiresTwoFactor(user.Role)
 	if err != nil {
-		utils.Error("Failed to create access token: " + err.Error())
+		utils.Error("Failed to check two-factor roles: " + err.Error())
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Could not create access token",
+			"error": "Something went wrong. Please try again later.",
 		})
 	}
+	if required {
+		return twoFactorChallenge(c, user, authUtils.TokenTypeTwoFactorSetup, "two_factor_setup_required")
+	}
+
+	return completeLogin(c, user, nil)
+}
 
-	// Generate refresh token
-	refreshToken, err := authUtils.GenerateRefreshToken()
+// completeLogin signs a user in whose credentials were checked. extra is added to the response.
+func completeLogin(c *fiber.Ctx, user model.User, extra fiber.Map) error {
+	// Start a session for this device, other devices stay signed in
+	session, refreshToken, refreshExpiresAt, err := createSession(user, c)
 	if err != nil {
-		utils.Error("Failed to generate refresh token: " + err.Error())
+		utils.Error("Failed to create session: " + err.Error())
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Could not create refresh token",
 		})
 	}
 
-	// Store refresh token in the database
-	refreshExpiresAt := time.Now().Add(7 * 24 * time.Hour) // 7 days
-	user.RefreshToken = refreshToken
-	user.RefreshExpiresAt = refreshExpiresAt
-
-	if err := db.DB.Save(&user).Error; err != nil {
-		utils.Error("Failed to save refresh token: " + err.Error())
+	// Generate access token
+	accessToken, err := authUtils.CreateAccessToken(user.ID.String(), user.Username, user.Role, session.ID.String())
+	if err != nil {
+		utils.Error("Failed to create access token: " + err.Error())
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Could not save refresh token",
+			"error": "Could not create access token",
 		})
 	}
 
 	// Set refresh token as HttpOnly cookie
-	c.Cookie(&fiber.Cookie{
-		Name:     "refresh_token",
-		Value:    refreshToken,
-		Expires:  refreshExpiresAt,
-		HTTPOnly: true,
-		Secure:   false, // Set to false for development
-		SameSite: "lax",
-		Path:     "/api/auth",
-	})
+	setRefreshTokenCookie(c, refreshToken, refreshExpiresAt)
 
-	return c.JSON(fiber.Map{
+	response := fiber.Map{
 		"access_token": accessToken,
-		"expires_in":   900, // 15 minutes in seconds
+		"expires_in":   int(authUtils.AccessTokenDuration().Seconds()),
 		"user": fiber.Map{
-			"id":       user.ID,
-			"username": user.Username,
-			"email":    user.Email,
-			"role":     user.Role,
+			"id":           user.ID,
+			"username":     user.Username,
+			"email":        user.Email,
+			"role":         user.Role,
+			"totp_enabled": user.TOTPEnabled,
 		},
-	})
+	}
+	for key, value := range extra {
+		response[key] = value
+	}
+	return c.JSON(response)
 }
 
 func RefreshToken(c *fiber.Ctx) error {
@@ -205,80 +212,88 @@ func RefreshToken(c *fiber.Ctx) error {
 		}
 	}
 
-	var user model.User
-	if err := db.DB.Where("refresh_token = ? AND refresh_expires_at > ?", refreshToken, time.Now()).First(&user).Error; err != nil {
+	// Rotate the refresh token, reuse of an old token revokes the session
+	session, newRefreshToken, refreshExpiresAt, err := rotateRefreshToken(refreshToken, c)
+	if err != nil {
+		if err == errRefreshTokenReused {
+			utils.Warning("Refresh token reuse detected, revoked session " + session.ID.String())
+		} else if err != errInvalidRefreshToken {
+			utils.Error("Failed to rotate refresh token: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Could not create refresh token",
+			})
+		}
+		clearRefreshTokenCookie(c)
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"error": "Invalid or expired refresh token",
 		})
 	}
 
-	// Generate new access token
-	accessToken, err := authUtils.CreateAccessToken(user.ID.String(), user.Username, user.Role)
-	if err != nil {
-		utils.Error("Failed to create access token: " + err.Error())
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Could not create access token",
+	var user model.User
+	if err := db.DB.Where("id = ?", session.UserID).First(&user).Error; err != nil {
+		clearRefreshTokenCookie(c)
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid or expired refresh token",
 		})
 	}
 
-	// Generate new refresh token
-	newRefreshToken, err := authUtils.GenerateRefreshToken()
-	if err != nil {
-		utils.Error("Failed to generate refresh token: " + err.Error())
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Could not create refresh token",
-		})
+	// Sessions started before the role required two-factor authentication end here, the user
+	// sets it up on their next login
+	if !user.TOTPEnabled {
+		required, err := roleRequiresTwoFactor(user.Role)
+		if err != nil {
+			utils.Error("Failed to check two-factor roles: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Something went wrong. Please try again later.",
+			})
+		}
+		if required {
+			if _, err := revokeUserSession(user.ID, session.ID, model.SessionRevokedTwoFactorNeeded); err != nil {
+				utils.Error("Failed to revoke session: " + err.Error())
+			}
+			clearRefreshTokenCookie(c)
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error":                     "Two-factor authentication is required for your role. Please log in again.",
+				"two_factor_setup_required": true,
+			})
+		}
 	}
 
-	// Update refresh token in database
-	refreshExpiresAt := time.Now().Add(7 * 24 * time.Hour)
-	user.RefreshToken = newRefreshToken
-	user.RefreshExpiresAt = refreshExpiresAt
-
-	if err := db.DB.Save(&user).Error; err != nil {
-		utils.Error("Failed to save refresh token: " + err.Error())
+	// Generate new access token
+	accessToken, err := authUtils.CreateAccessToken(user.ID.String(), user.Username, user.Role, session.ID.String())
+	if err != nil {
+		utils.Error("Failed to create access token: " + err.Error())
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Could not save refresh token",
+			"error": "Could not create access token",
 		})
 	}
 
 	// Set new refresh token as cookie
-	c.Cookie(&fiber.Cookie{
-		Name:     "refresh_token",
-		Value:    newRefreshToken,
-		Expires:  refreshExpiresAt,
-		HTTPOnly: true,
-		Secure:   false,
-		SameSite: "lax",
-		Path:     "/api/auth",
-	})
+	setRefreshTokenCookie(c, newRefreshToken, refreshExpiresAt)
 
 	return c.JSON(fiber.Map{
 		"access_token": accessToken,
-		"expires_in":   900, // 15 minutes in seconds
+		"expires_in":   int(authUtils.AccessTokenDuration().Seconds()),
 	})
 }
 
 func Logout(c *fiber.Ctx) error {
 	refreshToken := c.Cookies("refresh_token")
+	if refreshToken == "" {
+		var req RefreshTokenRequest
+		if err := c.BodyParser(&req); err == nil {
+			refreshToken = req.RefreshToken
+		}
+	}
 	if refreshToken != "" {
-		// Clear refresh token from database
-		db.DB.Model(&model.User{}).Where("refresh_token = ?", refreshToken).Updates(map[string]interface{}{
-			"refresh_token":     "",
-			"refresh_expires_at": time.Now(),
-		})
+		// End the session of this device
+		if err := revokeSessionByToken(refreshToken, model.SessionRevokedLogout); err != nil {
+			utils.Error("Failed to revoke session: " + err.Error())
+		}
 	}
 
 	// Clear refresh token cookie
-	c.Cookie(&fiber.Cookie{
-		Name:     "refresh_token",
-		Value:    "",
-		Expires:  time.Now().Add(-1 * time.Hour),
-		HTTPOnly: true,
-		Secure:   false,
-		SameSite: "lax",
-		Path:     "/api/auth",
-	})
+	clearRefreshTokenCookie(c)
 
 	return c.JSON(fiber.Map{
 		"message": "Logged out successfully",
@@ -416,12 +431,28 @@ func ResetPassword(c *fiber.Ctx) error {
 	user.ResetPasswordToken = ""
 	user.ResetTokenExpiresAt = time.Now()
 
-	if err := db.DB.Save(&user).Error; err != nil {
+	// Sign out every device, whoever knew the old password may still have a session
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Save(&user).Error; err != nil {
 		utils.Error("Failed to reset password: " + err.Error())
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Could not reset password",
 		})
 	}
+	if err := revokeUserSessions(tx, user.ID, model.SessionRevokedPasswordReset); err != nil {
+		utils.Error("Failed to revoke sessions: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not reset password",
+		})
+	}
+	if err := tx.Commit().Error; err != nil {
+		utils.Error("Transaction commit failed: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not reset password",
+		})
+	}
 
 	return c.JSON(fiber.Map{
 		"message": "Password reset successfully. You can now log in with your new password.",
@@ -459,9 +490,36 @@ func ValidateToken(c *fiber.Ctx) error {
 		})
 	}
 
+	// Tokens of revoked sessions are rejected
+	active, err := sessionActive(claims.SessionID)
+	if err != nil || !active {
+		return c.Status(401).JSON(fiber.Map{
+			"valid": false,
+			"error": "session has been revoked",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"valid":      true,
+		"user_id":    userID,
+		"role":       claims.Role,
+		"session_id": claims.SessionID,
+	})
+} 
+// PublicKeys publishes the keys access tokens can be verified with
+func PublicKeys(c *fiber.Ctx) error {
+	keys, err := authUtils.PublicKeys()
+	if err != nil {
+		utils.Error("Failed to load public keys: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not load public keys",
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
 	return c.JSON(fiber.Map{
-		"valid":   true,
-		"user_id": userID,
-		"role":    claims.Role,
+		"issuer":   authUtils.TokenIssuer,
+		"audience": authUtils.TokenAudience(),
+		"keys":     keys,
 	})
-} 
\ No newline at end of file
+}
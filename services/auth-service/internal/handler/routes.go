@@ -19,12 +19,44 @@ func SetupRoutes(app *fiber.App) {
 
 	auth.Post("/register", Register)
 	auth.Post("/login", Login)
+	auth.Post("/login/2fa", LoginTwoFactor)
 	auth.Get("/verify-email", VerifyEmail)
 	auth.Post("/refresh", RefreshToken)
 	auth.Post("/forgot-password", ForgotPassword)
 	auth.Post("/reset-password", ResetPassword)
 	auth.Post("/logout", Logout)
 
-	// Token validation endpoint for API Gateway
+	// Token validation endpoint for services that cannot verify tokens themselves
 	auth.Post("/validate", ValidateToken)
+
+	// Public keys for verifying access tokens locally
+	auth.Get("/keys", PublicKeys)
+
+	// Sessions revoked while their access tokens may still be valid, polled by the API Gateway
+	auth.Get("/revoked-sessions", RevokedSessions)
+
+	// Session management for the signed-in user
+	sessions := auth.Group("/sessions", RequireAuth)
+	sessions.Get("/", ListSessions)
+	sessions.Delete("/", LogoutEverywhere)
+	sessions.Delete("/:id", RevokeSession)
+
+	// Two-factor authentication. Enrolment is also open to logins that must set it up first.
+	twoFactor := auth.Group("/2fa")
+	twoFactor.Get("/", RequireAuth, TwoFactorStatus)
+	twoFactor.Post("/enroll", RequireAuthOrTwoFactorSetup, EnrollTwoFactor)
+	twoFactor.Post("/verify", RequireAuthOrTwoFactorSetup, VerifyTwoFactor)
+	twoFactor.Post("/disable", RequireAuth, DisableTwoFactor)
+	twoFactor.Post("/recovery-codes", RequireAuth, RegenerateRecoveryCodes)
+
+	// User administration - owners only
+	admin := auth.Group("/admin", RequireAuth, RequireOwner)
+	admin.Get("/users", ListUsers)
+	admin.Put("/users/:id/role", UpdateUserRole)
+	admin.Get("/users/:id/sessions", ListUserSessions)
+	admin.Delete("/users/:id/sessions", RevokeAllUserSessions)
+	admin.Delete("/users/:id/sessions/:sessionId", RevokeUserSession)
+	admin.Delete("/users/:id/2fa", ResetUserTwoFactor)
+	admin.Get("/2fa-roles", ListTwoFactorRoles)
+	admin.Put("/2fa-roles", UpdateTwoFactorRoles)
 } 
\ No newline at end of file
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"services/shared/db"
+
+	"github.com/salobook/services/auth-service/internal/model"
+	authUtils "github.com/salobook/services/auth-service/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// refreshTokenLifetime is how long a refresh token can be used. Every refresh starts a new one,
+// so a session stays alive while the device keeps using it.
+const refreshTokenLifetime = 7 * 24 * time.Hour
+
+var (
+	errInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	errRefreshTokenReused  = errors.New("refresh token reuse detected")
+)
+
+// createSession starts a session for a user and returns its first refresh token
+func createSession(user model.User, c *fiber.Ctx) (model.Session, string, time.Time, error) {
+	var session model.Session
+	token, err := authUtils.GenerateRefreshToken()
+	if err != nil {
+		return session, "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(refreshTokenLifetime)
+
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	session = model.Session{
+		UserID:     user.ID,
+		UserAgent:  truncate(c.Get(fiber.HeaderUserAgent), 512),
+		IPAddress:  clientIP(c),
+		LastUsedAt: now,
+		ExpiresAt:  expiresAt,
+	}
+	if err := tx.Create(&session).Error; err != nil {
+		return session, "", time.Time{}, err
+	}
+
+	refreshToken := model.RefreshToken{
+		SessionID: session.ID,
+		TokenHash: authUtils.HashRefreshToken(token),
+		ExpiresAt: expiresAt,
+	}
+	if err := tx.Create(&refreshToken).Error; err != nil {
+		return session, "", time.Time{}, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return session, "", time.Time{}, err
+	}
+	return session, token, expiresAt, nil
+}
+
+// rotateRefreshToken exchanges a refresh token for a new one of the same session. A token that was
+// already rotated is a sign it was stolen, so presenting it again revokes the whole session.
+func rotateRefreshToken(token string, c *fiber.Ctx) (model.Session, string, time.Time, error) {
+	var session model.Session
+	now := time.Now()
+
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	// 1. Find the token and its session, locking the token against concurrent rotation
+	var current model.RefreshToken
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", authUtils.HashRefreshToken(token)).
+		First(&current).Error
+	if err == gorm.ErrRecordNotFound {
+		return session, "", time.Time{}, errInvalidRefreshToken
+	} else if err != nil {
+		return session, "", time.Time{}, err
+	}
+	if err := tx.Where("id = ?", current.SessionID).First(&session).Error; err != nil {
+		return session, "", time.Time{}, err
+	}
+	if session.RevokedAt != nil {
+		return session, "", time.Time{}, errInvalidRefreshToken
+	}
+
+	// 2. Reuse of a rotated token revokes the family
+	if current.UsedAt != nil {
+		if err := tx.Model(&session).Updates(map[string]interface{}{
+			"revoked_at":     now,
+			"revoked_reason": model.SessionRevokedReuse,
+		}).Error; err != nil {
+			return session, "", time.Time{}, err
+		}
+		if err := tx.Commit().Error; err != nil {
+			return session, "", time.Time{}, err
+		}
+		return session, "", time.Time{}, errRefreshTokenReused
+	}
+	if current.ExpiresAt.Before(now) {
+		return session, "", time.Time{}, errInvalidRefreshToken
+	}
+
+	// 3. Replace the token with its child
+	newToken, err := authUtils.GenerateRefreshToken()
+	if err != nil {
+		return session, "", time.Time{}, err
+	}
+	expiresAt := now.Add(refreshTokenLifetime)
+	if err := tx.Model(&current).Update("used_at", now).Error; err != nil {
+		return session, "", time.Time{}, err
+	}
+	child := model.RefreshToken{
+		SessionID: session.ID,
+		ParentID:  &current.ID,
+		TokenHash: authUtils.HashRefreshToken(newToken),
+		ExpiresAt: expiresAt,
+	}
+	if err := tx.Create(&child).Error; err != nil {
+		return session, "", time.Time{}, err
+	}
+
+	// 4. Record where the session was used last
+	if err := tx.Model(&session).Updates(map[string]interface{}{
+		"last_used_at": now,
+		"expires_at":   expiresAt,
+		"user_agent":   truncate(c.Get(fiber.HeaderUserAgent), 512),
+		"ip_address":   clientIP(c),
+	}).Error; err != nil {
+		return session, "", time.Time{}, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return session, "", time.Time{}, err
+	}
+	return session, newToken, expiresAt, nil
+}
+
+// revokeSessionByToken ends the session a refresh token belongs to
+func revokeSessionByToken(token string, reason string) error {
+	sessionIDs := db.DB.Model(&model.RefreshToken{}).
+		Select("session_id").
+		Where("token_hash = ?", authUtils.HashRefreshToken(token))
+	return db.DB.Model(&model.Session{}).
+		Where("id IN (?) AND revoked_at IS NULL", sessionIDs).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason}).Error
+}
+
+// revokeUserSessions ends all sessions of a user
+func revokeUserSessions(tx *gorm.DB, userID uuid.UUID, reason string) error {
+	return tx.Model(&model.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason}).Error
+}
+
+// revokeUserSession ends one session of a user. found is false when the user has no such active session.
+func revokeUserSession(userID uuid.UUID, sessionID uuid.UUID, reason string) (found bool, err error) {
+	result := db.DB.Model(&model.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason})
+	return result.RowsAffected > 0, result.Error
+}
+
+// activeSessions returns the sessions of a user that are neither revoked nor expired, most recently used first
+func activeSessions(userID uuid.UUID) ([]model.Session, error) {
+	sessions := []model.Session{}
+	err := db.DB.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// sessionActive reports whether access tokens of a session are still accepted
+func sessionActive(sessionID string) (bool, error) {
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return false, nil
+	}
+	var count int64
+	err = db.DB.Model(&model.Session{}).Where("id = ? AND revoked_at IS NULL", id).Count(&count).Error
+	return count > 0, err
+}
+
+// recentlyRevokedSessionIDs returns the sessions revoked since a time
+func recentlyRevokedSessionIDs(since time.Time) ([]uuid.UUID, error) {
+	sessionIDs := []uuid.UUID{}
+	err := db.DB.Model(&model.Session{}).
+		Where("revoked_at IS NOT NULL AND revoked_at > ?", since).
+		Pluck("id", &sessionIDs).Error
+	return sessionIDs, err
+}
+
+// setRefreshTokenCookie stores the refresh token in an HttpOnly cookie
+func setRefreshTokenCookie(c *fiber.Ctx, token string, expiresAt time.Time) {
+	c.Cookie(&fiber.Cookie{
+		Name:     "refresh_token",
+		Value:    token,
+		Expires:  expiresAt,
+		HTTPOnly: true,
+		Secure:   false, // Set to false for development
+		SameSite: "lax",
+		Path:     "/api/auth",
+	})
+}
+
+// clearRefreshTokenCookie removes the refresh token cookie
+func clearRefreshTokenCookie(c *fiber.Ctx) {
+	setRefreshTokenCookie(c, "", time.Now().Add(-1*time.Hour))
+}
+
+// clientIP returns the address of the client, as forwarded by the gateway when present
+func clientIP(c *fiber.Ctx) string {
+	if forwarded := c.Get(fiber.HeaderXForwardedFor); forwarded != "" {
+		return truncate(strings.TrimSpace(strings.Split(forwarded, ",")[0]), 64)
+	}
+	return c.IP()
+}
+
+// truncate shortens a string to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"strings"
+	"time"
+
+	"services/shared/db"
+	"services/shared/utils"
+
+	"github.com/salobook/services/auth-service/internal/model"
+	authUtils "github.com/salobook/services/auth-service/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// RequireAuth verifies the access token of a request and that its session was not revoked.
+// It puts the user ID, role and session ID into the request locals.
+func RequireAuth(c *fiber.Ctx) error {
+	token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+	if token == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "missing access token",
+		})
+	}
+
+	claims, err := authUtils.ValidateToken(token)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "invalid or expired access token",
+		})
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "invalid or expired access token",
+		})
+	}
+
+	active, err := sessionActive(claims.SessionID)
+	if err != nil {
+		utils.Error("Failed to check session: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Something went wrong. Please try again later.",
+		})
+	}
+	if !active {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "session has been revoked",
+		})
+	}
+
+	c.Locals("user_id", userID)
+	c.Locals("user_role", claims.Role)
+	c.Locals("session_id", claims.SessionID)
+	return c.Next()
+}
+
+// ListSessions returns the active sessions of the caller. The session of the access token is marked current.
+func ListSessions(c *fiber.Ctx) error {
+	sessions, err := activeSessions(c.Locals("user_id").(uuid.UUID))
+	if err != nil {
+		utils.Error("Failed to list sessions: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not list sessions",
+		})
+	}
+
+	currentID := c.Locals("session_id").(string)
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID.String() == currentID
+	}
+
+	return c.JSON(fiber.Map{
+		"sessions": sessions,
+	})
+}
+
+// RevokeSession ends one session of the caller, for example a lost phone
+func RevokeSession(c *fiber.Ctx) error {
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid session ID",
+		})
+	}
+
+	found, err := revokeUserSession(c.Locals("user_id").(uuid.UUID), sessionID, model.SessionRevokedByUser)
+	if err != nil {
+		utils.Error("Failed to revoke session: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not revoke session",
+		})
+	}
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Session not found",
+		})
+	}
+
+	if sessionID.String() == c.Locals("session_id").(string) {
+		clearRefreshTokenCookie(c)
+	}
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// LogoutEverywhere ends all sessions of the caller, including the current one
+func LogoutEverywhere(c *fiber.Ctx) error {
+	if err := revokeUserSessions(db.DB, c.Locals("user_id").(uuid.UUID), model.SessionRevokedLogoutEverywhere); err != nil {
+		utils.Error("Failed to revoke sessions: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not revoke sessions",
+		})
+	}
+
+	clearRefreshTokenCookie(c)
+	return c.JSON(fiber.Map{
+		"message": "Logged out on all devices",
+	})
+}
+
+// RevokedSessions lists the sessions revoked while their access tokens may still be unexpired.
+// Services that verify tokens themselves poll it to reject tokens of revoked sessions.
+func RevokedSessions(c *fiber.Ctx) error {
+	window := authUtils.AccessTokenDuration()
+	sessionIDs, err := recentlyRevokedSessionIDs(time.Now().Add(-window))
+	if err != nil {
+		utils.Error("Failed to list revoked sessions: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not list revoked sessions",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"session_ids": sessionIDs,
+		"window":      window.String(),
+	})
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"services/shared/db"
+	"services/shared/utils"
+
+	"github.com/salobook/services/auth-service/internal/model"
+	authUtils "github.com/salobook/services/auth-service/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Two-factor limits. After maxTwoFactorAttempts wrong codes in a row, codes are not checked
+// for twoFactorLockout, which makes guessing a six digit code impractical.
+const (
+	recoveryCodeCount    = 10
+	maxTwoFactorAttempts = 5
+	twoFactorLockout     = 15 * time.Minute
+)
+
+var (
+	errInvalidTwoFactorCode = errors.New("invalid two-factor code")
+	errTwoFactorLocked      = errors.New("too many invalid two-factor codes")
+)
+
+// roleRequiresTwoFactor reports whether owners made two-factor authentication mandatory for a role
+func roleRequiresTwoFactor(role string) (bool, error) {
+	var count int64
+	err := db.DB.Model(&model.TwoFactorRequiredRole{}).Where("role = ?", role).Count(&count).Error
+	return count > 0, err
+}
+
+// checkTwoFactorCode accepts a TOTP code of the user's secret or, when allowRecovery is set, an
+// unused recovery code, which is used up. usedRecovery tells which of the two matched.
+func checkTwoFactorCode(user *model.User, code string, allowRecovery bool) (usedRecovery bool, err error) {
+	now := time.Now()
+	if user.TOTPLockedUntil != nil && user.TOTPLockedUntil.After(now) {
+		return false, errTwoFactorLocked
+	}
+
+	// 1. TOTP code. Storing the step only if it is newer than the last one keeps concurrent
+	//    requests from using the same code twice.
+	if step, ok := authUtils.ValidateTOTP(user.TOTPSecret, code, user.TOTPLastStep, now); ok {
+		result := db.DB.Model(&model.User{}).
+			Where("id = ? AND totp_last_step < ?", user.ID, step).
+			Where("totp_locked_until IS NULL OR totp_locked_until <= ?", now).
+			Updates(map[string]interface{}{
+				"totp_last_step":       step,
+				"totp_failed_attempts": 0,
+				"totp_locked_until":    nil,
+			})
+		if result.Error != nil {
+			return false, result.Error
+		}
+		if result.RowsAffected > 0 {
+			user.TOTPLastStep = step
+			return false, nil
+		}
+	}
+
+	// 2. Recovery code, marked used in the same statement that finds it
+	if allowRecovery {
+		result := db.DB.Model(&model.RecoveryCode{}).
+			Where("user_id = ? AND code_hash = ? AND used_at IS NULL", user.ID, authUtils.HashRecoveryCode(code)).
+			Update("used_at", now)
+		if result.Error != nil {
+			return false, result.Error
+		}
+		if result.RowsAffected > 0 {
+			if err := db.DB.Model(&model.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+				"totp_failed_attempts": 0,
+				"totp_locked_until":    nil,
+			}).Error; err != nil {
+				return true, err
+			}
+			return true, nil
+		}
+	}
+
+	// 3. Count the failure, locking the second factor when there were too many. The count is
+	//    incremented and read in one statement so concurrent failures are all counted.
+	var attempts int
+	if err := db.DB.Raw("UPDATE users SET totp_failed_attempts = totp_failed_attempts + 1 WHERE id = ? RETURNING totp_failed_attempts", user.ID).
+		Scan(&attempts).Error; err != nil {
+		return false, err
+	}
+	if attempts >= maxTwoFactorAttempts {
+		if err := db.DB.Model(&model.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+			"totp_failed_attempts": 0,
+			"totp_locked_until":    now.Add(twoFactorLockout),
+		}).Error; err != nil {
+			return false, err
+		}
+	}
+	return false, errInvalidTwoFactorCode
+}
+
+// replaceRecoveryCodes discards the recovery codes of a user and creates new ones
+func replaceRecoveryCodes(tx *gorm.DB, userID uuid.UUID) ([]string, error) {
+	codes, err := authUtils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+	recoveryCodes := make([]model.RecoveryCode, len(codes))
+	for i, code := range codes {
+		recoveryCodes[i] = model.RecoveryCode{
+			UserID:   userID,
+			CodeHash: authUtils.HashRecoveryCode(code),
+		}
+	}
+	if err := tx.Create(&recoveryCodes).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// remainingRecoveryCodes counts the unused recovery codes of a user
+func remainingRecoveryCodes(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := db.DB.Model(&model.RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// disableTwoFactor removes the second factor of a user together with their recovery codes
+func disableTwoFactor(userID uuid.UUID) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Model(&model.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"totp_secret":          "",
+		"totp_enabled":         false,
+		"totp_last_step":       0,
+		"totp_failed_attempts": 0,
+		"totp_locked_until":    nil,
+	}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// twoFactorChallenge answers a correct password with a two-factor token instead of signing the
+// user in. flag tells the client whether a code or the enrolment of an authenticator is expected.
+func twoFactorChallenge(c *fiber.Ctx, user model.User, tokenType string, flag string) error {
+	token, err := authUtils.CreateTwoFactorToken(user.ID.String(), user.Username, tokenType)
+	if err != nil {
+		utils.Error("Failed to create two-factor token: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not create two-factor token",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		flag:               true,
+		"two_factor_token": token,
+		"expires_in":       int(authUtils.TwoFactorTokenDuration.Seconds()),
+	})
+}
+
+// twoFactorCodeError answers a failed checkTwoFactorCode
+func twoFactorCodeError(c *fiber.Ctx, err error) error {
+	switch err {
+	case errInvalidTwoFactorCode:
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid two-factor code",
+		})
+	case errTwoFactorLocked:
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": "Too many invalid codes. Please try again later.",
+		})
+	default:
+		utils.Error("Failed to check two-factor code: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Something went wrong. Please try again later.",
+		})
+	}
+}
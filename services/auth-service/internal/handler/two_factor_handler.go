@@ -0,0 +1,326 @@
+package handler
+
+import (
+	"strings"
+
+	"services/shared/db"
+	"services/shared/utils"
+
+	"github.com/salobook/services/auth-service/internal/model"
+	authUtils "github.com/salobook/services/auth-service/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type LoginTwoFactorRequest struct {
+	TwoFactorToken string `json:"two_factor_token"`
+	Code           string `json:"code"`
+}
+
+// RequireAuthOrTwoFactorSetup lets signed-in users through like RequireAuth, and also users half
+// way through a login who must set up two-factor authentication before they get an access token.
+func RequireAuthOrTwoFactorSetup(c *fiber.Ctx) error {
+	token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+	if claims, err := authUtils.ValidateTwoFactorToken(token, authUtils.TokenTypeTwoFactorSetup); err == nil {
+		userID, err := uuid.Parse(claims.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid or expired two-factor token",
+			})
+		}
+		c.Locals("user_id", userID)
+		c.Locals("two_factor_setup", true)
+		return c.Next()
+	}
+	return RequireAuth(c)
+}
+
+// currentUser loads the user of the request locals
+func currentUser(c *fiber.Ctx) (model.User, error) {
+	var user model.User
+	err := db.DB.Where("id = ?", c.Locals("user_id").(uuid.UUID)).First(&user).Error
+	return user, err
+}
+
+// TwoFactorStatus tells the caller whether two-factor authentication is enabled and required
+func TwoFactorStatus(c *fiber.Ctx) error {
+	user, err := currentUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+
+	required, err := roleRequiresTwoFactor(user.Role)
+	if err != nil {
+		utils.Error("Failed to check two-factor roles: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Something went wrong. Please try again later.",
+		})
+	}
+	remaining, err := remainingRecoveryCodes(user.ID)
+	if err != nil {
+		utils.Error("Failed to count recovery codes: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Something went wrong. Please try again later.",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"enabled":                  user.TOTPEnabled,
+		"required":                 required,
+		"recovery_codes_remaining": remaining,
+	})
+}
+
+// EnrollTwoFactor creates a new TOTP secret for the caller. It is not used for logins until
+// VerifyTwoFactor confirms the authenticator produces matching codes.
+func EnrollTwoFactor(c *fiber.Ctx) error {
+	user, err := currentUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+	if user.TOTPEnabled {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Two-factor authentication is already enabled",
+		})
+	}
+
+	secret, err := authUtils.GenerateTOTPSecret()
+	if err != nil {
+		utils.Error("Failed to generate TOTP secret: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Something went wrong. Please try again later.",
+		})
+	}
+	if err := db.DB.Model(&user).Updates(map[string]interface{}{
+		"totp_secret":    secret,
+		"totp_last_step": 0,
+	}).Error; err != nil {
+		utils.Error("Failed to save TOTP secret: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not start two-factor enrolment",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"secret":           secret,
+		"provisioning_uri": authUtils.TOTPProvisioningURI(secret, user.Email),
+	})
+}
+
+// VerifyTwoFactor enables two-factor authentication once the caller proves their authenticator
+// works, and returns the recovery codes. They are shown this once only. Users who enrolled during
+// login are signed in as well.
+func VerifyTwoFactor(c *fiber.Ctx) error {
+	var req TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Cannot parse JSON",
+		})
+	}
+
+	user, err := currentUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+	if user.TOTPEnabled {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Two-factor authentication is already enabled",
+		})
+	}
+	if user.TOTPSecret == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Start two-factor enrolment first",
+		})
+	}
+
+	if _, err := checkTwoFactorCode(&user, req.Code, false); err != nil {
+		return twoFactorCodeError(c, err)
+	}
+
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Model(&user).Update("totp_enabled", true).Error; err != nil {
+		utils.Error("Failed to enable two-factor authentication: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not enable two-factor authentication",
+		})
+	}
+	codes, err := replaceRecoveryCodes(tx, user.ID)
+	if err != nil {
+		utils.Error("Failed to create recovery codes: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not enable two-factor authentication",
+		})
+	}
+	if err := tx.Commit().Error; err != nil {
+		utils.Error("Transaction commit failed: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not enable two-factor authentication",
+		})
+	}
+
+	utils.Info("Two-factor authentication enabled for user " + user.ID.String())
+	if c.Locals("two_factor_setup") == true {
+		return completeLogin(c, user, fiber.Map{"recovery_codes": codes})
+	}
+	return c.JSON(fiber.Map{
+		"message":        "Two-factor authentication enabled",
+		"recovery_codes": codes,
+	})
+}
+
+// DisableTwoFactor turns two-factor authentication off, unless the caller's role requires it
+func DisableTwoFactor(c *fiber.Ctx) error {
+	var req TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Cannot parse JSON",
+		})
+	}
+
+	user, err := currentUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+	if !user.TOTPEnabled {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Two-factor authentication is not enabled",
+		})
+	}
+
+	required, err := roleRequiresTwoFactor(user.Role)
+	if err != nil {
+		utils.Error("Failed to check two-factor roles: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Something went wrong. Please try again later.",
+		})
+	}
+	if required {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Two-factor authentication is required for your role",
+		})
+	}
+
+	if _, err := checkTwoFactorCode(&user, req.Code, true); err != nil {
+		return twoFactorCodeError(c, err)
+	}
+
+	if err := disableTwoFactor(user.ID); err != nil {
+		utils.Error("Failed to disable two-factor authentication: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not disable two-factor authentication",
+		})
+	}
+
+	utils.Info("Two-factor authentication disabled for user " + user.ID.String())
+	return c.JSON(fiber.Map{
+		"message": "Two-factor authentication disabled",
+	})
+}
+
+// RegenerateRecoveryCodes replaces the caller's recovery codes, for example when they ran low
+func RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	var req TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Cannot parse JSON",
+		})
+	}
+
+	user, err := currentUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+	if !user.TOTPEnabled {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Two-factor authentication is not enabled",
+		})
+	}
+
+	if _, err := checkTwoFactorCode(&user, req.Code, false); err != nil {
+		return twoFactorCodeError(c, err)
+	}
+
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	codes, err := replaceRecoveryCodes(tx, user.ID)
+	if err != nil {
+		utils.Error("Failed to create recovery codes: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not create recovery codes",
+		})
+	}
+	if err := tx.Commit().Error; err != nil {
+		utils.Error("Transaction commit failed: " + err.Error())
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Could not create recovery codes",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"recovery_codes": codes,
+	})
+}
+
+// LoginTwoFactor is the second login step. It exchanges the two-factor token from Login and a
+// TOTP or recovery code for an access token.
+func LoginTwoFactor(c *fiber.Ctx) error {
+	var req LoginTwoFactorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Cannot parse JSON",
+		})
+	}
+	if req.TwoFactorToken == "" || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Two-factor token and code are required",
+		})
+	}
+
+	claims, err := authUtils.ValidateTwoFactorToken(req.TwoFactorToken, authUtils.TokenTypeTwoFactor)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid or expired two-factor token",
+		})
+	}
+
+	var user model.User
+	if err := db.DB.Where("id = ?", claims.UserID).First(&user).Error; err != nil || !user.TOTPEnabled {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid or expired two-factor token",
+		})
+	}
+
+	usedRecovery, err := checkTwoFactorCode(&user, req.Code, true)
+	if err != nil {
+		return twoFactorCodeError(c, err)
+	}
+
+	// Tell the user when a recovery code was spent so they can replace them in time
+	var extra fiber.Map
+	if usedRecovery {
+		remaining, err := remainingRecoveryCodes(user.ID)
+		if err != nil {
+			utils.Error("Failed to count recovery codes: " + err.Error())
+		}
+		extra = fiber.Map{"recovery_codes_remaining": remaining}
+	}
+	return completeLogin(c, user, extra)
+}
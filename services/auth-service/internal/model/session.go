@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session revocation reasons
+const (
+	SessionRevokedLogout           = "logout"
+	SessionRevokedLogoutEverywhere = "logout_everywhere"
+	SessionRevokedByUser           = "revoked_by_user"
+	SessionRevokedByAdmin          = "revoked_by_admin"
+	SessionRevokedReuse            = "reuse_detected"
+	SessionRevokedPasswordReset    = "password_reset"
+	SessionRevokedTwoFactorNeeded  = "two_factor_required" // The role started requiring two-factor authentication
+)
+
+// Session is one signed-in device. Every refresh rotates its refresh token; the tokens of a
+// session form a family, and presenting a token that was already rotated revokes the session.
+type Session struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID        uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	UserAgent     string     `json:"user_agent" gorm:"size:512"`
+	IPAddress     string     `json:"ip_address" gorm:"size:64"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	LastUsedAt    time.Time  `json:"last_used_at"`
+	ExpiresAt     time.Time  `json:"expires_at"` // Expiry of the current refresh token
+	RevokedAt     *time.Time `json:"revoked_at"`
+	RevokedReason string     `json:"revoked_reason,omitempty" gorm:"size:32"`
+	Current       bool       `json:"current" gorm:"-"` // The session of the access token used to list sessions
+}
+
+// RefreshToken is one token of a session family. Only the SHA-256 hash of the token is stored.
+type RefreshToken struct {
+	ID        uuid.UUID  `json:"-" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	SessionID uuid.UUID  `json:"-" gorm:"type:uuid;not null;index"`
+	ParentID  *uuid.UUID `json:"-" gorm:"type:uuid"` // Token this one replaced, nil for the first token of a session
+	TokenHash string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"-"`
+	UsedAt    *time.Time `json:"-"` // Set when the token is rotated
+	CreatedAt time.Time  `json:"-" gorm:"autoCreateTime"`
+}
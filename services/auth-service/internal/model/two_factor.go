@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryCode signs a user in once when their authenticator is not at hand. Only the hash is stored.
+type RecoveryCode struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	CodeHash  string     `json:"-" gorm:"size:64;not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TwoFactorRequiredRole marks a role whose users must use two-factor authentication
+type TwoFactorRequiredRole struct {
+	Role      string    `json:"role" gorm:"primaryKey;size:32"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -6,19 +6,45 @@ import (
 	"github.com/google/uuid"
 )
 
+// Roles a user can have. The API gateway maps every role to its permissions. RoleUser has none; new
+// registrations start with it until an owner assigns them a role.
+const (
+	RoleOwner        = "owner"
+	RoleManager      = "manager"
+	RoleStaff        = "staff"
+	RoleReceptionist = "receptionist"
+	RoleUser         = "user"
+)
+
+// Roles lists the valid roles
+var Roles = []string{RoleOwner, RoleManager, RoleStaff, RoleReceptionist, RoleUser}
+
+// IsValidRole reports whether role is one of Roles
+func IsValidRole(role string) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 type User struct {
-	ID                  uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	Email               string    `json:"email" gorm:"uniqueIndex;not null"`
-	Username            string    `json:"username" gorm:"uniqueIndex;not null"`
-	Password            string    `json:"-" gorm:"not null"` // "-" means this field won't appear in JSON
-	Role                string    `json:"role" gorm:"not null;default:'user'"`
-	CreatedAt           time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt           time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-	IsVerified          bool      `json:"is_verified" gorm:"default:false"`
-	VerificationToken   string    `json:"-" gorm:"index"`
-	TokenExpiresAt      time.Time `json:"-"`
-	ResetPasswordToken  string    `json:"-" gorm:"index"`
-	ResetTokenExpiresAt time.Time `json:"-"`
-	RefreshToken        string    `json:"-" gorm:"size:512"`
-	RefreshExpiresAt    time.Time `json:"-"`
-} 
\ No newline at end of file
+	ID                  uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Email               string     `json:"email" gorm:"uniqueIndex;not null"`
+	Username            string     `json:"username" gorm:"uniqueIndex;not null"`
+	Password            string     `json:"-" gorm:"not null"`                   // "-" means this field won't appear in JSON
+	Role                string     `json:"role" gorm:"not null;default:'user'"` // owner, manager, staff, receptionist, user
+	CreatedAt           time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	IsVerified          bool       `json:"is_verified" gorm:"default:false"`
+	VerificationToken   string     `json:"-" gorm:"index"`
+	TokenExpiresAt      time.Time  `json:"-"`
+	ResetPasswordToken  string     `json:"-" gorm:"index"`
+	ResetTokenExpiresAt time.Time  `json:"-"`
+	TOTPSecret          string     `json:"-" gorm:"size:64"` // Set at enrolment, in use once TOTPEnabled
+	TOTPEnabled         bool       `json:"totp_enabled" gorm:"default:false"`
+	TOTPLastStep        int64      `json:"-"` // Time step of the last accepted code, so codes cannot be replayed
+	TOTPFailedAttempts  int        `json:"-" gorm:"default:0"`
+	TOTPLockedUntil     *time.Time `json:"-"`
+}
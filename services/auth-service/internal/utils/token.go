@@ -1,53 +1,136 @@
 package utils
 
 import (
+	"crypto/ed25519"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/o1egl/paseto"
 )
 
+// Tokens are signed with PASETO v2.public so that other services can verify them with the
+// published public keys instead of calling the auth service.
+const (
+	TokenIssuer          = "auth-service"
+	TokenVersion         = "v2.public"
+	defaultTokenAudience = "staff-scheduler-api"
+)
+
 type TokenClaims struct {
 	UserID    string `json:"user_id"`    // Changed to string to match UUID
 	Username  string `json:"username"`
 	Role      string `json:"role"`
 	TokenType string `json:"token_type"` // "access" for access tokens
+	SessionID string `json:"sid"`        // Session the token was issued for, revoking it invalidates the token
 }
 
-// CreateToken generates a new PASETO token with specified duration
-func CreateToken(claims TokenClaims, duration time.Duration) (string, error) {
-	v2 := paseto.NewV2()
+// TokenFooter names the key a token was signed with
+type TokenFooter struct {
+	KeyID string `json:"kid"`
+}
 
-	// Get PASETO secret key from environment
-	secretKey := os.Getenv("PASETO_SECRET_KEY")
-	if secretKey == "" {
-		return "", fmt.Errorf("PASETO_SECRET_KEY not set")
+// PublicKey is a published token verification key
+type PublicKey struct {
+	KeyID     string `json:"kid"`
+	Version   string `json:"version"`
+	PublicKey string `json:"public_key"` // Hex encoded Ed25519 public key
+}
+
+// TokenAudience returns the audience tokens are issued for, TOKEN_AUDIENCE or the default
+func TokenAudience() string {
+	if audience := os.Getenv("TOKEN_AUDIENCE"); audience != "" {
+		return audience
 	}
+	return defaultTokenAudience
+}
 
-	// Convert hex string to bytes
-	key, err := hex.DecodeString(secretKey)
+// signingKey reads the Ed25519 private key from PASETO_PRIVATE_KEY, given as a hex encoded
+// 32 byte seed or 64 byte private key
+func signingKey() (ed25519.PrivateKey, error) {
+	privateKey := os.Getenv("PASETO_PRIVATE_KEY")
+	if privateKey == "" {
+		return nil, fmt.Errorf("PASETO_PRIVATE_KEY not set")
+	}
+
+	key, err := hex.DecodeString(privateKey)
 	if err != nil {
-		return "", fmt.Errorf("invalid hex format: %v", err)
+		return nil, fmt.Errorf("invalid hex format: %v", err)
 	}
 
-	if len(key) != 32 {
-		return "", fmt.Errorf("invalid key length: got %d bytes, need 32", len(key))
+	switch len(key) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(key), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(key), nil
 	}
+	return nil, fmt.Errorf("invalid key length: got %d bytes, need %d or %d", len(key), ed25519.SeedSize, ed25519.PrivateKeySize)
+}
 
-	// Token expires based on provided duration
-	expiration := time.Now().Add(duration)
+// KeyID derives the key ID published for a public key
+func KeyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:8])
+}
+
+// PublicKeys returns the keys tokens can be verified with: the current signing key and the
+// retired keys in PASETO_RETIRED_PUBLIC_KEYS (comma separated hex), which stay published
+// until the tokens they signed have expired.
+func PublicKeys() ([]PublicKey, error) {
+	privateKey, err := signingKey()
+	if err != nil {
+		return nil, err
+	}
+	current := privateKey.Public().(ed25519.PublicKey)
+	keys := []PublicKey{{KeyID: KeyID(current), Version: TokenVersion, PublicKey: hex.EncodeToString(current)}}
 
-	// Create a JSONToken with proper expiration
+	for _, retired := range strings.Split(os.Getenv("PASETO_RETIRED_PUBLIC_KEYS"), ",") {
+		retired = strings.TrimSpace(retired)
+		if retired == "" {
+			continue
+		}
+		key, err := hex.DecodeString(retired)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid retired public key %q", retired)
+		}
+		keys = append(keys, PublicKey{KeyID: KeyID(key), Version: TokenVersion, PublicKey: retired})
+	}
+	return keys, nil
+}
+
+// CreateToken generates a new signed PASETO token with specified duration
+func CreateToken(claims TokenClaims, duration time.Duration) (string, error) {
+	v2 := paseto.NewV2()
+
+	privateKey, err := signingKey()
+	if err != nil {
+		return "", err
+	}
+
+	// Token expires based on provided duration
+	now := time.Now()
 	jsonToken := paseto.JSONToken{
-		Expiration: expiration,
+		Audience:   TokenAudience(),
+		Issuer:     TokenIssuer,
+		Subject:    claims.UserID,
+		IssuedAt:   now,
+		NotBefore:  now,
+		Expiration: now.Add(duration),
 	}
+	jsonToken.Set("user_id", claims.UserID)
+	jsonToken.Set("username", claims.Username)
+	jsonToken.Set("role", claims.Role)
+	jsonToken.Set("token_type", claims.TokenType)
+	jsonToken.Set("sid", claims.SessionID)
 
-	// Encrypt the token with the claims and expiration
-	token, err := v2.Encrypt(key, claims, &jsonToken)
+	// Sign the claims, the footer tells verifiers which key to use
+	footer := TokenFooter{KeyID: KeyID(privateKey.Public().(ed25519.PublicKey))}
+	token, err := v2.Sign(privateKey, jsonToken, footer)
 	if err != nil {
 		return "", fmt.Errorf("failed to create token: %v", err)
 	}
@@ -55,15 +138,8 @@ func CreateToken(claims TokenClaims, duration time.Duration) (string, error) {
 	return token, nil
 }
 
-// CreateAccessToken creates a short-lived access token (15 minutes by default)
-func CreateAccessToken(userID, username, role string) (string, error) {
-	claims := TokenClaims{
-		UserID:    userID,
-		Username:  username,
-		Role:      role,
-		TokenType: "access",
-	}
-
+// AccessTokenDuration returns the lifetime of access tokens (15 minutes by default)
+func AccessTokenDuration() time.Duration {
 	// Get token expiration from environment or use default
 	accessTokenDuration := 15 * time.Minute
 	if durationStr := os.Getenv("ACCESS_TOKEN_EXPIRATION"); durationStr != "" {
@@ -71,8 +147,20 @@ func CreateAccessToken(userID, username, role string) (string, error) {
 			accessTokenDuration = duration
 		}
 	}
+	return accessTokenDuration
+}
+
+// CreateAccessToken creates a short-lived access token for a session
+func CreateAccessToken(userID, username, role, sessionID string) (string, error) {
+	claims := TokenClaims{
+		UserID:    userID,
+		Username:  username,
+		Role:      role,
+		TokenType: TokenTypeAccess,
+		SessionID: sessionID,
+	}
 
-	return CreateToken(claims, accessTokenDuration)
+	return CreateToken(claims, AccessTokenDuration())
 }
 
 // GenerateRefreshToken creates a cryptographically secure random string
@@ -88,42 +176,88 @@ func GenerateRefreshToken() (string, error) {
 	return base64.URLEncoding.EncodeToString(b), nil
 }
 
-// ValidateToken validates and decodes a PASETO token
+// HashRefreshToken returns the hash a refresh token is stored as
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Token types. Two-factor tokens only prove the password was correct; they are exchanged for an
+// access token once the second factor is verified or set up.
+const (
+	TokenTypeAccess         = "access"
+	TokenTypeTwoFactor      = "two_factor"       // Login waiting for a TOTP or recovery code
+	TokenTypeTwoFactorSetup = "two_factor_setup" // Login waiting for required two-factor enrolment
+	TwoFactorTokenDuration  = 5 * time.Minute
+)
+
+// CreateTwoFactorToken creates the short-lived token handed out between the login steps
+func CreateTwoFactorToken(userID, username, tokenType string) (string, error) {
+	claims := TokenClaims{
+		UserID:    userID,
+		Username:  username,
+		TokenType: tokenType,
+	}
+	return CreateToken(claims, TwoFactorTokenDuration)
+}
+
+// ValidateToken verifies and decodes an access token
 func ValidateToken(tokenString string) (*TokenClaims, error) {
+	return verifyToken(tokenString, TokenTypeAccess)
+}
+
+// ValidateTwoFactorToken verifies and decodes a two-factor token of the given type
+func ValidateTwoFactorToken(tokenString string, tokenType string) (*TokenClaims, error) {
+	return verifyToken(tokenString, tokenType)
+}
+
+// verifyToken checks the signature, expiry, audience, issuer and type of a token
+func verifyToken(tokenString string, tokenType string) (*TokenClaims, error) {
 	v2 := paseto.NewV2()
 
-	// Get PASETO secret key from environment
-	secretKey := os.Getenv("PASETO_SECRET_KEY")
-	if secretKey == "" {
-		return nil, fmt.Errorf("PASETO_SECRET_KEY not set")
+	// Find the key the token was signed with
+	var footer TokenFooter
+	if err := paseto.ParseFooter(tokenString, &footer); err != nil {
+		return nil, fmt.Errorf("invalid token: %v", err)
 	}
-
-	// Convert hex string to bytes
-	key, err := hex.DecodeString(secretKey)
+	keys, err := PublicKeys()
 	if err != nil {
-		return nil, fmt.Errorf("invalid hex format: %v", err)
+		return nil, err
 	}
-
-	if len(key) != 32 {
-		return nil, fmt.Errorf("invalid key length: got %d bytes, need 32", len(key))
+	var publicKey ed25519.PublicKey
+	for _, key := range keys {
+		if key.KeyID == footer.KeyID {
+			publicKey, _ = hex.DecodeString(key.PublicKey)
+		}
+	}
+	if publicKey == nil {
+		return nil, fmt.Errorf("invalid token: unknown key")
 	}
 
-	var claims TokenClaims
-	// Create a footer validation rule with proper parsing
-	var footer paseto.JSONToken
+	var jsonToken paseto.JSONToken
+	if err := v2.Verify(tokenString, publicKey, &jsonToken, nil); err != nil {
+		return nil, fmt.Errorf("invalid token: %v", err)
+	}
 
-	// Decrypt with footer validation
-	err = v2.Decrypt(tokenString, key, &claims, &footer)
-	if err != nil {
+	if jsonToken.Expiration.IsZero() {
+		return nil, fmt.Errorf("invalid token: no expiration")
+	}
+	if err := jsonToken.Validate(paseto.ValidAt(time.Now()), paseto.ForAudience(TokenAudience()), paseto.IssuedBy(TokenIssuer)); err != nil {
 		return nil, fmt.Errorf("invalid token: %v", err)
 	}
 
-	// Manually check expiration if needed
-	if footer.Expiration.Before(time.Now()) {
-		return nil, fmt.Errorf("token expired")
+	claims := &TokenClaims{
+		UserID:    jsonToken.Get("user_id"),
+		Username:  jsonToken.Get("username"),
+		Role:      jsonToken.Get("role"),
+		TokenType: jsonToken.Get("token_type"),
+		SessionID: jsonToken.Get("sid"),
+	}
+	if claims.TokenType != tokenType {
+		return nil, fmt.Errorf("invalid token: not a %s token", tokenType)
 	}
 
-	return &claims, nil
+	return claims, nil
 }
 
 // GenerateVerificationToken generates a secure token for email verification
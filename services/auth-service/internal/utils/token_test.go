@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Hex encoded Ed25519 seeds of two signing keys
+const (
+	testSeed      = "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"
+	otherTestSeed = "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb"
+)
+
+// publicKeyHex returns the hex encoded public key of a hex encoded seed
+func publicKeyHex(t *testing.T, seed string) string {
+	t.Helper()
+	b, err := hex.DecodeString(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(ed25519.NewKeyFromSeed(b).Public().(ed25519.PublicKey))
+}
+
+func TestVerifyToken(t *testing.T) {
+	access := TokenClaims{UserID: "user-1", Username: "alice", Role: "staff", TokenType: TokenTypeAccess, SessionID: "session-1"}
+
+	tests := []struct {
+		name     string
+		claims   TokenClaims
+		duration time.Duration
+		audience string // TOKEN_AUDIENCE when the token is created
+		rotate   bool   // Sign with another key before verifying
+		retire   bool   // Publish the old key as retired after rotating
+		wantErr  string
+	}{
+		{name: "valid", claims: access, duration: time.Minute},
+		{name: "expired", claims: access, duration: -time.Minute, wantErr: "expired"},
+		{name: "wrong audience", claims: access, duration: time.Minute, audience: "other-api", wantErr: "audience"},
+		{name: "wrong token type", claims: TokenClaims{UserID: "user-1", TokenType: TokenTypeTwoFactor}, duration: time.Minute, wantErr: "not a access token"},
+		{name: "unknown kid", claims: access, duration: time.Minute, rotate: true, wantErr: "unknown key"},
+		{name: "retired kid", claims: access, duration: time.Minute, rotate: true, retire: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PASETO_PRIVATE_KEY", testSeed)
+			t.Setenv("PASETO_RETIRED_PUBLIC_KEYS", "")
+			t.Setenv("TOKEN_AUDIENCE", tt.audience)
+
+			token, err := CreateToken(tt.claims, tt.duration)
+			if err != nil {
+				t.Fatalf("CreateToken: %v", err)
+			}
+
+			t.Setenv("TOKEN_AUDIENCE", "")
+			if tt.rotate {
+				t.Setenv("PASETO_PRIVATE_KEY", otherTestSeed)
+			}
+			if tt.retire {
+				t.Setenv("PASETO_RETIRED_PUBLIC_KEYS", publicKeyHex(t, testSeed))
+			}
+
+			claims, err := ValidateToken(token)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ValidateToken error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateToken: %v", err)
+			}
+			if *claims != tt.claims {
+				t.Errorf("claims = %+v, want %+v", *claims, tt.claims)
+			}
+		})
+	}
+}
+
+func TestValidateTwoFactorToken(t *testing.T) {
+	t.Setenv("PASETO_PRIVATE_KEY", testSeed)
+
+	token, err := CreateTwoFactorToken("user-1", "alice", TokenTypeTwoFactor)
+	if err != nil {
+		t.Fatalf("CreateTwoFactorToken: %v", err)
+	}
+	if _, err := ValidateTwoFactorToken(token, TokenTypeTwoFactor); err != nil {
+		t.Errorf("ValidateTwoFactorToken: %v", err)
+	}
+	if _, err := ValidateTwoFactorToken(token, TokenTypeTwoFactorSetup); err == nil {
+		t.Error("two-factor token accepted as a setup token")
+	}
+	if _, err := ValidateToken(token); err == nil {
+		t.Error("two-factor token accepted as an access token")
+	}
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 Appendix B test vectors, "12345678901234567890",
+// base32 encoded as authenticator apps receive it
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// rfc6238Vectors are the SHA-1 test vectors of RFC 6238 Appendix B, truncated to six digits
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestTOTPCode(t *testing.T) {
+	key, err := base32NoPadding.DecodeString(rfc6238Secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range rfc6238Vectors {
+		if got := totpCode(key, tt.unix/totpPeriod); got != tt.code {
+			t.Errorf("totpCode at %d = %s, want %s", tt.unix, got, tt.code)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	for _, tt := range rfc6238Vectors {
+		now := time.Unix(tt.unix, 0)
+		step, ok := ValidateTOTP(rfc6238Secret, tt.code, 0, now)
+		if !ok || step != tt.unix/totpPeriod {
+			t.Errorf("ValidateTOTP(%s) at %d = %d, %v, want %d, true", tt.code, tt.unix, step, ok, tt.unix/totpPeriod)
+		}
+	}
+
+	now := time.Unix(1234567890, 0)
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		lastStep int64
+		now      time.Time
+		want     bool
+	}{
+		{"lower case secret", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "005924", 0, now, true},
+		{"surrounding spaces", rfc6238Secret, " 005924 ", 0, now, true},
+		{"previous step within skew", rfc6238Secret, "005924", 0, now.Add(totpPeriod * time.Second), true},
+		{"next step within skew", rfc6238Secret, "005924", 0, now.Add(-totpPeriod * time.Second), true},
+		{"two steps late", rfc6238Secret, "005924", 0, now.Add(2 * totpPeriod * time.Second), false},
+		{"replayed code", rfc6238Secret, "005924", now.Unix() / totpPeriod, now, false},
+		{"wrong code", rfc6238Secret, "005925", 0, now, false},
+		{"too short", rfc6238Secret, "05924", 0, now, false},
+		{"invalid secret", "not base32!", "005924", 0, now, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := ValidateTOTP(tt.secret, tt.code, tt.lastStep, tt.now); ok != tt.want {
+				t.Errorf("ValidateTOTP = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
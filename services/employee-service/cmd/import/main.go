@@ -0,0 +1,96 @@
+// Command import creates employees in bulk from a CSV or XLSX file, like POST /employees/import.
+//
+//	go run ./cmd/import -file staff.xlsx -dry-run
+//
+// Invalid rows are reported and skipped; the valid rows are created in one transaction.
+// The exit status is 1 when the file cannot be imported and 2 when rows were skipped.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"services/shared/db"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/salobook/services/employee-service/internal/importer"
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+func main() {
+	filePath := flag.String("file", "", "CSV or XLSX file to import")
+	dryRun := flag.Bool("dry-run", false, "validate the file and report without creating employees")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+	if *filePath == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Load environment variables the same way the service does
+	for _, path := range []string{
+		filepath.Join("..", "..", "..", ".env"),
+		filepath.Join("..", "..", ".env"),
+		filepath.Join(".env"),
+	} {
+		absPath, _ := filepath.Abs(path)
+		if _, err := os.Stat(absPath); err == nil && godotenv.Load(absPath) == nil {
+			break
+		}
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		log.Fatalf("Failed to open import file: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := importer.ReadAll(*filePath, file)
+	if err != nil {
+		log.Fatalf("Failed to read import file: %v", err)
+	}
+
+	db.InitPostgres(db.DBConfig{ServiceName: "EMPLOYEE"})
+
+	report, err := importer.Import(rows, *dryRun)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	} else {
+		printReport(report)
+	}
+
+	if report.InvalidRows > 0 {
+		os.Exit(2)
+	}
+}
+
+// printReport writes a readable summary with the problems of every row
+func printReport(report *model.EmployeeImportReport) {
+	for _, row := range report.Rows {
+		for _, warning := range row.Warnings {
+			fmt.Printf("row %d (%s): warning: %s\n", row.Row, row.Email, warning)
+		}
+		if !row.Valid {
+			fmt.Printf("row %d (%s): %s\n", row.Row, row.Email, strings.Join(row.Errors, "; "))
+		}
+	}
+
+	fmt.Printf("%d rows, %d valid, %d invalid\n", report.TotalRows, report.ValidRows, report.InvalidRows)
+	if report.DryRun {
+		fmt.Println("Dry run, no employees were created")
+		return
+	}
+	fmt.Printf("Imported %d employees with %d schedules\n", report.ImportedEmployees, report.ImportedSchedules)
+}
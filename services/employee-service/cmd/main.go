@@ -8,7 +8,11 @@ import (
 	"services/shared/utils"
 
 	"github.com/salobook/services/employee-service/internal/handler"
+	"github.com/salobook/services/employee-service/internal/importer"
 	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/storage"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/joho/godotenv"
@@ -56,12 +60,51 @@ func main() {
 		&model.Schedule{},
 		&model.RecurringBreak{},
 		&model.OnetimeBlock{},
+		&model.Resource{},
+		&model.ResourceOpeningHour{},
+		&model.ResourceBlock{},
+		&model.Customer{},
+		&model.CustomerPreferredEmployee{},
+		&model.LeaveRequest{},
+		&model.LeaveEntitlement{},
+		&model.ShiftSwap{},
+		&model.OpenShift{},
+		&model.OpenShiftClaim{},
+		&model.TimeEntry{},
+		&model.TimeEntryBreak{},
+		&model.Holiday{},
+		&model.Timesheet{},
+		&model.TimesheetDay{},
+		&model.EmployeeSkill{},
+		&model.RoleRequirement{},
+		&model.EmployeePhoto{},
+		&model.Team{},
+		&model.Location{},
+		&model.LocationOpeningHour{},
+		&model.CustomFieldDefinition{},
+		&model.CustomFieldOption{},
+		&model.EmployeeCustomFieldValue{},
+		&model.EmployeeHistory{},
 	)
     if err != nil {
         log.Fatalf("AutoMigrate failed: %v", err)
     }
 
-    app := fiber.New()
+    // Trigram indexes for the directory and customer searches
+    if err := repository.CreateSearchIndexes(); err != nil {
+        utils.Warning("Could not create search indexes, searches will be slower: " + err.Error())
+    }
+
+    // Blob storage for employee photos
+    blobStore, err := storage.NewFromEnv()
+    if err != nil {
+        log.Fatalf("Blob storage setup failed: %v", err)
+    }
+
+    // Leave room for photo and import file uploads plus multipart overhead
+    app := fiber.New(fiber.Config{
+        BodyLimit: max(service.PhotoMaxBytes(), importer.MaxFileBytes) + 1<<20,
+    })
 
     // Setup all routes
     handler.SetupEmployeeRoutes(app)
@@ -69,6 +112,21 @@ func main() {
     handler.SetupRecurringBreakRoutes(app)
     handler.SetupOnetimeBlockRoutes(app)
     handler.SetupAvailabilityRoutes(app)
+    handler.SetupResourceRoutes(app)
+    handler.SetupCustomerRoutes(app)
+    handler.SetupLeaveRequestRoutes(app)
+    handler.SetupLeaveEntitlementRoutes(app)
+    handler.SetupShiftSwapRoutes(app)
+    handler.SetupOpenShiftRoutes(app)
+    handler.SetupTimeEntryRoutes(app)
+    handler.SetupTimesheetRoutes(app)
+    handler.SetupSkillRoutes(app)
+    handler.SetupPhotoRoutes(app, blobStore)
+    handler.SetupSelfServiceRoutes(app)
+    handler.SetupTeamRoutes(app)
+    handler.SetupLocationRoutes(app)
+    handler.SetupCustomFieldRoutes(app)
+    handler.SetupEmployeeImportRoutes(app)
 
 
     // Get service-specific port or use default
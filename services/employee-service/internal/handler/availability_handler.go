@@ -13,6 +13,9 @@ import (
 func SetupAvailabilityRoutes(app *fiber.App) {
 	// POST /availability - Get employee availability for a specific date
 	app.Post("/availability", getEmployeeAvailability)
+
+	// POST /availability/chain - Search consecutive slots for an ordered list of legs across employees
+	app.Post("/availability/chain", getChainedAvailability)
 }
 
 // getEmployeeAvailability handles the POST /availability endpoint
@@ -51,6 +54,22 @@ func getEmployeeAvailability(c *fiber.Ctx) error {
 		})
 	}
 
+	// Step 4b: Validate and parse optional resource IDs
+	resourceIDs, err := validator.ValidateAvailabilityResourceIDs(req.ResourceIDs)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Step 4c: Validate and parse optional location ID
+	locationID, err := validator.ValidateLocationFilter(req.LocationID)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	// Step 5: Optional validations (you can enable/disable these based on business requirements)
 	
 	// Uncomment if you want to prevent queries for past dates
@@ -69,7 +88,7 @@ func getEmployeeAvailability(c *fiber.Ctx) error {
 
 	// Step 6: Create availability service and get employee availability
 	availabilityService := service.NewAvailabilityService()
-	availability, err := availabilityService.GetEmployeeAvailability(employeeID, date)
+	availability, err := availabilityService.GetEmployeeAvailabilityWithResources(employeeID, date, locationID, resourceIDs)
 	if err != nil {
 		// Handle specific error cases
 		switch err.Error() {
@@ -77,6 +96,10 @@ func getEmployeeAvailability(c *fiber.Ctx) error {
 			return c.Status(404).JSON(fiber.Map{
 				"error": "Employee not found",
 			})
+		case "resource not found":
+			return c.Status(404).JSON(fiber.Map{
+				"error": "Resource not found",
+			})
 		case "no schedule found for employee on this date":
 			return c.Status(404).JSON(fiber.Map{
 				"error": "No schedule found for employee on this date",
@@ -96,4 +119,66 @@ func getEmployeeAvailability(c *fiber.Ctx) error {
 	// Step 7: Return the availability response
 	utils.Info("Successfully retrieved availability for employee " + employeeID.String() + " on date " + date.Format("2006-01-02"))
 	return c.Status(200).JSON(availability)
-} 
\ No newline at end of file
+}
+
+// getChainedAvailability handles the POST /availability/chain endpoint
+// Returns sequences of consecutive slots, one per leg, possibly served by different employees
+func getChainedAvailability(c *fiber.Ctx) error {
+	// Step 1: Parse request body
+	var req model.ChainAvailabilityRequest
+	if err := c.BodyParser(&req); err != nil {
+		utils.Error("Failed to parse chained availability request: " + err.Error())
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Step 2: Validate legs and search options
+	legs, step, maxResults, err := validator.ValidateChainAvailabilityRequest(req)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Step 3: Validate and parse date
+	date, err := validator.ValidateAndParseAvailabilityDate(req.Date)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err := validator.ValidateAvailabilityDateRange(date); err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	locationID, err := validator.ValidateLocationFilter(req.LocationID)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Step 4: Search for chained sequences
+	availabilityService := service.NewAvailabilityService()
+	result, err := availabilityService.FindChainedAvailability(date, locationID, legs, step, maxResults)
+	if err != nil {
+		switch err.Error() {
+		case "employee not found":
+			return c.Status(404).JSON(fiber.Map{
+				"error": "Employee not found",
+			})
+		case "resource not found":
+			return c.Status(404).JSON(fiber.Map{
+				"error": "Resource not found",
+			})
+		default:
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Internal server error",
+			})
+		}
+	}
+
+	return c.Status(200).JSON(result)
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"services/shared/utils"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupCustomFieldRoutes configures the routes for custom employee field definitions and values.
+func SetupCustomFieldRoutes(app *fiber.App) {
+	// Define a new custom field
+	app.Post("/custom-fields", func(c *fiber.Ctx) error {
+		// 1. Parse input
+		var input validator.CustomFieldDefinitionInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for custom field"})
+		}
+
+		// 2. Validate required fields, key and type
+		if err := validator.ValidateCustomFieldRequiredFields(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		key, err := validator.ValidateCustomFieldKey(input.Key)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		fieldType, err := validator.ValidateCustomFieldType(input.Type)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Build model and validate its rules
+		field := &model.CustomFieldDefinition{Key: key, Type: fieldType}
+		applyCustomFieldInput(field, input)
+		if err := validator.ValidateCustomFieldRules(field); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 4. Check for duplicate keys
+		if err := service.CheckForDuplicateCustomField(field); err != nil {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 5. Save to database
+		if err := repository.CreateCustomFieldDefinition(field); err != nil {
+			utils.Error("Failed to create custom field: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create custom field"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(field)
+	})
+
+	// Get all custom field definitions
+	app.Get("/custom-fields", func(c *fiber.Ctx) error {
+		fields, err := repository.GetAllCustomFieldDefinitions()
+		if err != nil {
+			utils.Error("Failed to get custom fields: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get custom fields"})
+		}
+
+		return c.JSON(fields)
+	})
+
+	// Get custom field definition by ID
+	app.Get("/custom-fields/:id", func(c *fiber.Ctx) error {
+		field, err := findCustomField(c)
+		if field == nil {
+			return err
+		}
+
+		return c.JSON(field)
+	})
+
+	// Update custom field definition; key and type cannot be changed
+	app.Put("/custom-fields/:id", func(c *fiber.Ctx) error {
+		// 1. Check if custom field exists
+		field, err := findCustomField(c)
+		if field == nil {
+			return err
+		}
+		existing := *field
+
+		// 2. Parse input
+		var input validator.CustomFieldDefinitionInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for custom field"})
+		}
+		if input.Key != "" && strings.ToLower(strings.TrimSpace(input.Key)) != field.Key {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "key cannot be changed"})
+		}
+		if input.Type != "" && strings.ToLower(strings.TrimSpace(input.Type)) != field.Type {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "type cannot be changed"})
+		}
+
+		// 3. Update fields if provided and validate the rules
+		applyCustomFieldInput(field, input)
+		if err := validator.ValidateCustomFieldRules(field); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 4. Enum options can only be removed while no employee uses them
+		if err := service.CheckRemovedCustomFieldOptions(existing, *field); err != nil {
+			if _, ok := err.(*service.CustomFieldInUseError); ok {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+
+		// 5. Save changes
+		if err := repository.UpdateCustomFieldDefinition(field); err != nil {
+			utils.Error("Failed to update custom field: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update custom field"})
+		}
+
+		return c.JSON(field)
+	})
+
+	// Delete custom field definition together with the values of all employees
+	app.Delete("/custom-fields/:id", func(c *fiber.Ctx) error {
+		field, err := findCustomField(c)
+		if field == nil {
+			return err
+		}
+
+		if err := repository.DeleteCustomFieldDefinition(field.ID); err != nil {
+			utils.Error("Failed to delete custom field: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete custom field"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+
+	// Get the custom field values of an employee
+	app.Get("/employees/:id/custom-fields", func(c *fiber.Ctx) error {
+		employee, err := findHierarchyEmployee(c)
+		if employee == nil {
+			return err
+		}
+
+		customFields, err := service.GetEmployeeCustomFields(employee.ID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+
+		return c.JSON(customFields)
+	})
+
+	// Set custom field values of an employee by key; null removes a value
+	app.Put("/employees/:id/custom-fields", func(c *fiber.Ctx) error {
+		// 1. Check if employee exists
+		employee, err := findHierarchyEmployee(c)
+		if employee == nil {
+			return err
+		}
+
+		// 2. Parse input
+		var input map[string]interface{}
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for custom fields"})
+		}
+
+		// 3. Validate values against their definitions
+		values, removeFieldIDs, err := service.BuildEmployeeCustomFieldValues(&employee.ID, input)
+		if err != nil {
+			return respondWithCustomFieldError(c, err)
+		}
+
+		// 4. Save values
+		if err := repository.SaveEmployeeCustomFieldValues(employee.ID, values, removeFieldIDs); err != nil {
+			utils.Error("Failed to save custom field values: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to save custom fields"})
+		}
+
+		customFields, err := service.GetEmployeeCustomFields(employee.ID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+		return c.JSON(customFields)
+	})
+}
+
+// applyCustomFieldInput copies the provided label, rules and options onto a custom field
+func applyCustomFieldInput(field *model.CustomFieldDefinition, input validator.CustomFieldDefinitionInput) {
+	if strings.TrimSpace(input.Label) != "" {
+		field.Label = strings.TrimSpace(input.Label)
+	}
+	if input.Required != nil {
+		field.Required = *input.Required
+	}
+	if input.MaxLength != nil {
+		field.MaxLength = input.MaxLength
+	}
+	if input.Pattern != nil {
+		field.Pattern = *input.Pattern
+	}
+	if input.MinValue != nil {
+		field.MinValue = input.MinValue
+	}
+	if input.MaxValue != nil {
+		field.MaxValue = input.MaxValue
+	}
+	if input.Options != nil {
+		field.Options = input.Options
+	}
+	if input.Position != nil {
+		field.Position = *input.Position
+	}
+}
+
+// findCustomField loads the custom field of the :id route parameter, writing the error response when it fails
+func findCustomField(c *fiber.Ctx) (*model.CustomFieldDefinition, error) {
+	fieldID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid custom field ID format"})
+	}
+
+	field, err := repository.GetCustomFieldDefinitionByID(fieldID)
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "custom field not found"})
+	}
+	return &field, nil
+}
+
+// customFieldFilters collects the cf.<key>=<value> query parameters of a list endpoint
+func customFieldFilters(c *fiber.Ctx) map[string]string {
+	filters := make(map[string]string)
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		if name, ok := strings.CutPrefix(string(key), "cf."); ok && name != "" {
+			filters[name] = string(value)
+		}
+	})
+	return filters
+}
+
+// respondWithCustomFieldError writes the response for custom field value errors
+func respondWithCustomFieldError(c *fiber.Ctx, err error) error {
+	if _, ok := err.(*service.CustomFieldValueError); ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if missing, ok := err.(*service.MissingCustomFieldError); ok {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error(), "missing": missing.Keys})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"services/shared/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupCustomerRoutes configures the routes for customer records management.
+func SetupCustomerRoutes(app *fiber.App) {
+	// Create a new customer
+	app.Post("/customers", func(c *fiber.Ctx) error {
+		// 1. Parse input
+		var input validator.CustomerInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for customer"})
+		}
+
+		// 2. Validate required fields
+		if err := validator.ValidateCustomerRequiredFields(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Validate and normalize contact details
+		email, err := validator.ValidateAndNormalizeCustomerEmail(input.Email)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		phone, err := validator.ValidateAndNormalizePhone(input.Phone)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 4. Build customer model
+		customer := &model.Customer{
+			FirstName:            strings.TrimSpace(input.FirstName),
+			LastName:             strings.TrimSpace(input.LastName),
+			Email:                email,
+			Phone:                phone,
+			PreferredEmployeeIDs: []uuid.UUID{},
+		}
+		if input.Notes != nil {
+			customer.Notes = *input.Notes
+		}
+		if input.MarketingEmailConsent != nil || input.MarketingSMSConsent != nil {
+			now := time.Now()
+			customer.MarketingEmailConsent = input.MarketingEmailConsent != nil && *input.MarketingEmailConsent
+			customer.MarketingSMSConsent = input.MarketingSMSConsent != nil && *input.MarketingSMSConsent
+			customer.ConsentUpdatedAt = &now
+		}
+
+		// 5. Save to database
+		if err := repository.CreateCustomer(customer); err != nil {
+			utils.Error("Failed to create customer: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create customer"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(customer)
+	})
+
+	// Search customers by name, phone or email with pagination
+	app.Get("/customers", func(c *fiber.Ctx) error {
+		page, pageSize, err := validator.ValidatePagination(c.Query("page"), c.Query("page_size"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		term := strings.TrimSpace(c.Query("q"))
+		customers, total, err := repository.SearchCustomers(term, validator.NormalizePhoneDigits(term), page, pageSize)
+		if err != nil {
+			utils.Error("Failed to search customers: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get customers"})
+		}
+
+		return c.JSON(model.CustomerPage{
+			Data:     customers,
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+		})
+	})
+
+	// Get customer by ID
+	app.Get("/customers/:id", func(c *fiber.Ctx) error {
+		customerID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid customer ID format"})
+		}
+
+		customer, err := repository.GetCustomerByID(customerID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "customer not found"})
+		}
+
+		return c.JSON(customer)
+	})
+
+	// Update customer
+	app.Put("/customers/:id", func(c *fiber.Ctx) error {
+		// 1. Parse and validate customer ID
+		customerID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid customer ID format"})
+		}
+
+		// 2. Check if customer exists and is not merged away
+		customer, err := repository.GetCustomerByID(customerID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "customer not found"})
+		}
+		if customer.MergedIntoID != nil {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":          "customer was merged into another customer",
+				"merged_into_id": customer.MergedIntoID,
+			})
+		}
+
+		// 3. Parse input
+		var input validator.CustomerInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for customer"})
+		}
+
+		// 4. Update fields if provided
+		if strings.TrimSpace(input.FirstName) != "" {
+			customer.FirstName = strings.TrimSpace(input.FirstName)
+		}
+		if strings.TrimSpace(input.LastName) != "" {
+			customer.LastName = strings.TrimSpace(input.LastName)
+		}
+		if input.Email != "" {
+			email, err := validator.ValidateAndNormalizeCustomerEmail(input.Email)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			customer.Email = email
+		}
+		if input.Phone != "" {
+			phone, err := validator.ValidateAndNormalizePhone(input.Phone)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			customer.Phone = phone
+		}
+		if input.Notes != nil {
+			customer.Notes = *input.Notes
+		}
+
+		// 5. Record when marketing consent changes
+		consentChanged := false
+		if input.MarketingEmailConsent != nil && *input.MarketingEmailConsent != customer.MarketingEmailConsent {
+			customer.MarketingEmailConsent = *input.MarketingEmailConsent
+			consentChanged = true
+		}
+		if input.MarketingSMSConsent != nil && *input.MarketingSMSConsent != customer.MarketingSMSConsent {
+			customer.MarketingSMSConsent = *input.MarketingSMSConsent
+			consentChanged = true
+		}
+		if consentChanged {
+			now := time.Now()
+			customer.ConsentUpdatedAt = &now
+		}
+
+		// 6. Save changes
+		if err := repository.UpdateCustomer(&customer); err != nil {
+			utils.Error("Failed to update customer: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update customer"})
+		}
+
+		return c.JSON(customer)
+	})
+
+	// Delete customer
+	app.Delete("/customers/:id", func(c *fiber.Ctx) error {
+		customerID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid customer ID format"})
+		}
+
+		if _, err := repository.GetCustomerByID(customerID); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "customer not found"})
+		}
+
+		if err := repository.DeleteCustomer(customerID); err != nil {
+			if err == repository.ErrCustomerHasMergedRecords {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "other customers were merged into this customer, delete them first"})
+			}
+			utils.Error("Failed to delete customer: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete customer"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+
+	// Replace the preferred employees of a customer
+	app.Put("/customers/:id/preferred-employees", func(c *fiber.Ctx) error {
+		// 1. Parse and validate customer ID
+		customerID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid customer ID format"})
+		}
+		if _, err := repository.GetCustomerByID(customerID); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "customer not found"})
+		}
+
+		// 2. Parse and validate employee IDs
+		var input validator.PreferredEmployeesInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for preferred employees"})
+		}
+		employeeIDs, err := validator.ValidateUUIDList(input.EmployeeIDs, "employee ID")
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if err := service.CheckEmployeesExist(employeeIDs); err != nil {
+			if _, ok := err.(*service.UnknownEmployeeError); ok {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+
+		// 3. Save preferred employees
+		if err := repository.ReplaceCustomerPreferredEmployees(customerID, employeeIDs); err != nil {
+			utils.Error("Failed to update preferred employees: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update preferred employees"})
+		}
+
+		customer, err := repository.GetCustomerByID(customerID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+		return c.JSON(customer)
+	})
+
+	// List likely duplicates of a customer (same email or phone)
+	app.Get("/customers/:id/duplicates", func(c *fiber.Ctx) error {
+		customerID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid customer ID format"})
+		}
+
+		customer, err := repository.GetCustomerByID(customerID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "customer not found"})
+		}
+
+		duplicates, err := repository.FindDuplicateCustomers(customer)
+		if err != nil {
+			utils.Error("Failed to find duplicate customers: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to find duplicate customers"})
+		}
+		if duplicates == nil {
+			duplicates = []model.Customer{}
+		}
+
+		return c.JSON(duplicates)
+	})
+
+	// Merge duplicates into a customer
+	app.Post("/customers/:id/merge", func(c *fiber.Ctx) error {
+		customerID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid customer ID format"})
+		}
+
+		var input validator.CustomerMergeInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for customer merge"})
+		}
+		duplicateIDs, err := validator.ValidateUUIDList(input.DuplicateIDs, "customer ID")
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		merged, err := service.MergeCustomerDuplicates(customerID, duplicateIDs)
+		if err != nil {
+			if _, ok := err.(*service.CustomerMergeError); ok {
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+			}
+			if err.Error() == "customer not found" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to merge customers"})
+		}
+
+		return c.JSON(merged)
+	})
+}
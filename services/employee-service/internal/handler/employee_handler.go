@@ -2,11 +2,15 @@ package handler
 
 import (
 	"services/shared/utils"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/salobook/services/employee-service/internal/model"
 	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
 )
 
 // SetupEmployeeRoutes configures the routes for employee management
@@ -28,6 +32,7 @@ func SetupEmployeeRoutes(app *fiber.App) {
 			Picture   string `json:"picture"`
 			Role      string `json:"role"`
 			IsActive  bool   `json:"is_active"`
+			CustomFields map[string]interface{} `json:"custom_fields"`
 		}
 
 		if err := c.BodyParser(&input); err != nil {
@@ -40,8 +45,11 @@ func SetupEmployeeRoutes(app *fiber.App) {
 		}
 
 		// Check if employee with email already exists
-		_, err := repository.GetEmployeeByEmail(input.Email)
+		existingEmployee, err := repository.GetEmployeeByEmail(input.Email)
 		if err == nil {
+			if existingEmployee.DeletedAt.Valid {
+				return c.Status(409).JSON(fiber.Map{"error": "email belongs to a former employee, restore them instead"})
+			}
 			return c.Status(409).JSON(fiber.Map{"error": "employee with this email already exists"})
 		}
 
@@ -55,23 +63,103 @@ func SetupEmployeeRoutes(app *fiber.App) {
 			IsActive:  input.IsActive, 
 		}
 
+		// Validate custom field values, required fields must be given
+		customFieldValues, _, err := service.BuildEmployeeCustomFieldValues(nil, input.CustomFields)
+		if err != nil {
+			return respondWithCustomFieldError(c, err)
+		}
+
 		// Save to database
-		if err := repository.CreateEmployee(employee); err != nil {
+		if err := repository.CreateEmployeeWithCustomFields(employee, customFieldValues); err != nil {
 			utils.Error("Failed to create employee: " + err.Error())
 			return c.Status(500).JSON(fiber.Map{"error": "failed to create employee"})
 		}
+		if employee.CustomFields, err = service.GetEmployeeCustomFields(employee.ID); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "internal server error"})
+		}
 
 		return c.Status(201).JSON(employee)
 	})
 
-	// Get all employees
+	// Search employees with filtering, sorting and pagination.
+	// Former staff are only included with include_former=true
 	app.Get("/employees", func(c *fiber.Ctx) error {
-		employees, err := repository.GetAllEmployees()
+		// 1. Parse pagination and sorting
+		page, pageSize, err := validator.ValidatePagination(c.Query("page"), c.Query("page_size"))
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		sort, descending, err := validator.ValidateEmployeeSort(c.Query("sort"), c.Query("order"))
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 2. Parse filters
+		isActive, err := validator.ValidateOptionalBool(c.Query("is_active"), "is_active")
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		skill, minLevel, err := validator.ValidateSkillFilter(c.Query("skill"), c.Query("min_level"))
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		managedIDs, ok, err := managedEmployeeScope(c)
+		if !ok {
+			return err
+		}
+		locationID, err := validator.ValidateLocationFilter(c.Query("location_id"))
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		customFields, err := service.ResolveCustomFieldFilters(customFieldFilters(c))
+		if err != nil {
+			return respondWithCustomFieldError(c, err)
+		}
+		asOf, err := validator.ValidateOptionalAsOfDate(c.Query("as_of"))
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		date := time.Now().UTC().Truncate(24 * time.Hour)
+		if asOf != nil {
+			date = *asOf
+		}
+
+		// 3. Search
+		employees, total, err := repository.SearchEmployees(model.EmployeeFilter{
+			Search:        strings.TrimSpace(c.Query("q")),
+			Role:          strings.TrimSpace(c.Query("role")),
+			IsActive:      isActive,
+			Skill:         skill,
+			MinLevel:      minLevel,
+			IncludeFormer: c.QueryBool("include_former"),
+			EmployeeIDs:   managedIDs,
+			LocationID:    locationID,
+			CustomFields:  customFields,
+			AsOf:          date,
+			Historic:      asOf != nil,
+			Sort:          sort,
+			Descending:    descending,
+			Page:          page,
+			PageSize:      pageSize,
+		})
 		if err != nil {
 			utils.Error("Failed to fetch employees: " + err.Error())
 			return c.Status(500).JSON(fiber.Map{"error": "failed to fetch employees"})
 		}
-		return c.JSON(employees)
+
+		// 4. Show the values valid on as_of
+		if asOf != nil {
+			if err := service.ApplyEmployeeHistoryAsOf(employees, *asOf); err != nil {
+				return c.Status(500).JSON(fiber.Map{"error": "failed to fetch employees"})
+			}
+		}
+
+		return c.JSON(model.EmployeePage{
+			Data:     employees,
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+		})
 	})
 
 	// Get employee by ID
@@ -81,14 +169,62 @@ func SetupEmployeeRoutes(app *fiber.App) {
 			return c.Status(400).JSON(fiber.Map{"error": "invalid employee ID"})
 		}
 
-		employee, err := repository.GetEmployeeByID(id)
+		var employee model.Employee
+		if c.QueryBool("include_former") {
+			employee, err = repository.GetEmployeeByIDUnscoped(id)
+		} else {
+			employee, err = repository.GetEmployeeByID(id)
+		}
 		if err != nil {
 			return c.Status(404).JSON(fiber.Map{"error": "employee not found"})
 		}
+		if ok, err := checkManagedRecord(c, employee.ID); !ok {
+			return err
+		}
+
+		// Show the role, status, location and pay grade valid on as_of
+		asOf, err := validator.ValidateOptionalAsOfDate(c.Query("as_of"))
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		if asOf != nil {
+			var employed bool
+			employee, employed, err = service.GetEmployeeAsOf(employee, *asOf)
+			if err != nil {
+				return c.Status(500).JSON(fiber.Map{"error": "internal server error"})
+			}
+			if !employed {
+				return c.Status(404).JSON(fiber.Map{"error": "employee not found on this date"})
+			}
+		}
+
+		if employee.CustomFields, err = service.GetEmployeeCustomFields(employee.ID); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "internal server error"})
+		}
 
 		return c.JSON(employee)
 	})
 
+	// Get the effective-dated history of role, active status, location and pay grade
+	app.Get("/employees/:id/history", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid employee ID"})
+		}
+
+		employee, err := repository.GetEmployeeByIDUnscoped(id)
+		if err != nil {
+			return c.Status(404).JSON(fiber.Map{"error": "employee not found"})
+		}
+
+		versions, err := service.GetEmployeeHistory(employee)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "failed to get employee history"})
+		}
+
+		return c.JSON(versions)
+	})
+
 	// Update employee
 	app.Put("/employees/:id", func(c *fiber.Ctx) error {
 		id, err := uuid.Parse(c.Params("id"))
@@ -110,11 +246,20 @@ func SetupEmployeeRoutes(app *fiber.App) {
 			Picture   string `json:"picture"`
 			Role      string `json:"role"`
 			IsActive  *bool  `json:"is_active"` // Using pointer for partial updates
+			LocationID    *string `json:"location_id"` // Home location, empty string clears it
+			PayGrade      *string `json:"pay_grade"`
+			EffectiveFrom string  `json:"effective_from"` // YYYY-MM-DD, defaults to today
+			Reason        string  `json:"reason"`
 		}
 
 		if err := c.BodyParser(&input); err != nil {
 			return c.Status(400).JSON(fiber.Map{"error": "invalid input"})
 		}
+		effectiveFrom, err := validator.ValidateEffectiveFrom(input.EffectiveFrom)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		previous := employee
 
 		// Update fields if provided
 		if input.FirstName != "" {
@@ -132,18 +277,42 @@ func SetupEmployeeRoutes(app *fiber.App) {
 			employee.Email = input.Email
 		}
 		
-		// Update picture and role (allow empty strings to clear these fields)
-		employee.Picture = input.Picture
+		// Update picture and role (allow empty strings to clear these fields).
+		// A managed photo can only be replaced by uploading or deleting it.
+		photo, err := repository.GetEmployeePhoto(employee.ID)
+		if err != nil {
+			utils.Error("Failed to get employee photo: " + err.Error())
+			return c.Status(500).JSON(fiber.Map{"error": "failed to update employee"})
+		}
+		if photo == nil {
+			employee.Picture = input.Picture
+		}
 		employee.Role = input.Role
 		
 		// Only update IsActive if it was included in the request
 		if input.IsActive != nil {
 			employee.IsActive = *input.IsActive
 		}
+		if input.LocationID != nil {
+			if *input.LocationID == "" {
+				employee.LocationID = nil
+			} else {
+				locationID, err := validator.ValidateLocationExists(*input.LocationID)
+				if err != nil {
+					return respondWithLocationError(c, err)
+				}
+				employee.LocationID = &locationID
+			}
+		}
+		if input.PayGrade != nil {
+			employee.PayGrade = strings.TrimSpace(*input.PayGrade)
+		}
 
-		// Save changes
-		if err := repository.UpdateEmployee(&employee); err != nil {
-			utils.Error("Failed to update employee: " + err.Error())
+		// Save changes, recording role, status, location and pay grade changes in the history
+		if err := service.SaveEmployeeChanges(previous, &employee, effectiveFrom, currentUserID(c), strings.TrimSpace(input.Reason)); err != nil {
+			if _, ok := err.(*service.EmployeeHistoryError); ok {
+				return c.Status(422).JSON(fiber.Map{"error": err.Error()})
+			}
 			return c.Status(500).JSON(fiber.Map{"error": "failed to update employee"})
 		}
 
@@ -158,17 +327,71 @@ func SetupEmployeeRoutes(app *fiber.App) {
 		}
 
 		// Check if employee exists
-		_, err = repository.GetEmployeeByID(id)
+		employee, err := repository.GetEmployeeByID(id)
 		if err != nil {
 			return c.Status(404).JSON(fiber.Map{"error": "employee not found"})
 		}
 
-		// Delete employee
-		if err := repository.DeleteEmployee(id); err != nil {
-			utils.Error("Failed to delete employee: " + err.Error())
+		// Terminate if needed and soft-delete employee
+		if err := service.OffboardEmployee(employee, currentUserID(c)); err != nil {
 			return c.Status(500).JSON(fiber.Map{"error": "failed to delete employee"})
 		}
 
 		return c.Status(204).Send(nil)
 	})
+
+	// Terminate employee as of their last working day
+	app.Post("/employees/:id/terminate", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid employee ID"})
+		}
+
+		employee, err := repository.GetEmployeeByID(id)
+		if err != nil {
+			return c.Status(404).JSON(fiber.Map{"error": "employee not found"})
+		}
+
+		var input validator.TerminationInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid input"})
+		}
+		terminationDate, err := validator.ValidateAndParseTerminationDate(input.TerminationDate)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		terminated, err := service.TerminateEmployee(employee, terminationDate, currentUserID(c))
+		if err != nil {
+			if _, ok := err.(*service.EmploymentError); ok {
+				return c.Status(409).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(500).JSON(fiber.Map{"error": "failed to terminate employee"})
+		}
+
+		return c.JSON(terminated)
+	})
+
+	// Restore a deleted or terminated employee
+	app.Post("/employees/:id/restore", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid employee ID"})
+		}
+
+		employee, err := repository.GetEmployeeByIDUnscoped(id)
+		if err != nil {
+			return c.Status(404).JSON(fiber.Map{"error": "employee not found"})
+		}
+		if !employee.DeletedAt.Valid && employee.TerminationDate == nil {
+			return c.Status(409).JSON(fiber.Map{"error": "employee is not a former employee"})
+		}
+
+		if err := repository.RestoreEmployee(&employee); err != nil {
+			utils.Error("Failed to restore employee: " + err.Error())
+			return c.Status(500).JSON(fiber.Map{"error": "failed to restore employee"})
+		}
+
+		return c.JSON(employee)
+	})
 } 
\ No newline at end of file
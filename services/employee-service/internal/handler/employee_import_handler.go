@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/salobook/services/employee-service/internal/importer"
+)
+
+// SetupEmployeeImportRoutes configures the route for importing employees from CSV and XLSX files.
+func SetupEmployeeImportRoutes(app *fiber.App) {
+	// Import employees (multipart form field "file"); dry_run=true only validates and reports
+	app.Post("/employees/import", func(c *fiber.Ctx) error {
+		// 1. Read the uploaded file
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "import file is required"})
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "import file could not be read"})
+		}
+		defer file.Close()
+
+		// 2. Parse rows
+		rows, err := importer.ReadAll(fileHeader.Filename, file)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Validate and import
+		dryRun := c.QueryBool("dry_run")
+		report, err := importer.Import(rows, dryRun)
+		if err != nil {
+			if _, ok := err.(*importer.FileError); ok {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		if dryRun || report.ImportedEmployees == 0 {
+			return c.JSON(report)
+		}
+		return c.Status(fiber.StatusCreated).JSON(report)
+	})
+}
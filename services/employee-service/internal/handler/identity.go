@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// headerUserID and headerUserRole are set by the API gateway for authenticated requests.
+// The gateway strips any client-supplied value before forwarding.
+const (
+	headerUserID   = "X-User-ID"
+	headerUserRole = "X-User-Role"
+)
+
+// Roles forwarded by the gateway that act on behalf of other employees
+const (
+	roleOwner   = "owner"
+	roleManager = "manager"
+)
+
+// currentUserID returns the ID of the authenticated user forwarded by the gateway, or "" if absent
+func currentUserID(c *fiber.Ctx) string {
+	return strings.TrimSpace(c.Get(headerUserID))
+}
+
+// currentUserRole returns the role of the authenticated user forwarded by the gateway, or "" if absent
+func currentUserRole(c *fiber.Ctx) string {
+	return strings.TrimSpace(c.Get(headerUserRole))
+}
+
+// isManagerRole reports whether the caller manages other employees, as owners and managers do
+func isManagerRole(c *fiber.Ctx) bool {
+	role := currentUserRole(c)
+	return role == roleOwner || role == roleManager
+}
+
+// checkActingEmployee checks that the caller may act for the employee named in a request.
+// Owners act for anyone and managers for themselves and the employees they manage. Everyone
+// else acts only for the employee linked to their account. When the caller may not act for
+// the employee it writes the error response and returns false.
+func checkActingEmployee(c *fiber.Ctx, employeeID uuid.UUID) (bool, error) {
+	if isManagerRole(c) {
+		if err := checkManagerScope(c, true, employeeID); err != nil {
+			return false, respondWithScopeError(c, err)
+		}
+		return true, nil
+	}
+
+	employee, err := currentEmployee(c)
+	if employee == nil {
+		return false, err
+	}
+	if employee.ID != employeeID {
+		return false, c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "you can only act for your own employee record"})
+	}
+	return true, nil
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"services/shared/utils"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupLeaveEntitlementRoutes configures the routes for leave entitlements and balances.
+func SetupLeaveEntitlementRoutes(app *fiber.App) {
+	// Create a new leave entitlement
+	app.Post("/leave-entitlements", func(c *fiber.Ctx) error {
+		// 1. Parse input
+		var input validator.LeaveEntitlementInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for leave entitlement"})
+		}
+
+		// 2. Validate required fields
+		if err := validator.ValidateLeaveEntitlementRequiredFields(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if err := validator.ValidateLeaveType(input.LeaveType); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Apply defaults and validate values
+		accrualMethod := input.AccrualMethod
+		if accrualMethod == "" {
+			accrualMethod = model.AccrualUpfront
+		}
+		carryOverCap := 0.0
+		if input.CarryOverCapHours != nil {
+			carryOverCap = *input.CarryOverCapHours
+		}
+		if err := validator.ValidateLeaveEntitlementValues(input.Year, *input.AllowanceHours, accrualMethod, carryOverCap); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 4. Parse employee ID and check existence
+		employeeID, err := uuid.Parse(input.EmployeeID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+		}
+		exists, err := repository.CheckEmployeeExists(employeeID)
+		if err != nil {
+			utils.Error("Failed to check employee existence: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+		if !exists {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "employee not found"})
+		}
+
+		// 5. Build model and check for duplicates
+		entitlement := &model.LeaveEntitlement{
+			EmployeeID:        employeeID,
+			LeaveType:         input.LeaveType,
+			Year:              input.Year,
+			AllowanceHours:    *input.AllowanceHours,
+			AccrualMethod:     accrualMethod,
+			CarryOverCapHours: carryOverCap,
+		}
+		if err := service.CheckForDuplicateLeaveEntitlement(entitlement, nil); err != nil {
+			if _, ok := err.(*service.DuplicateLeaveEntitlementError); ok {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+
+		// 6. Save to database
+		if err := repository.CreateLeaveEntitlement(entitlement); err != nil {
+			utils.Error("Failed to create leave entitlement: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create leave entitlement"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(entitlement)
+	})
+
+	// Get leave entitlements with optional filtering by employee and year
+	app.Get("/leave-entitlements", func(c *fiber.Ctx) error {
+		var employeeID *uuid.UUID
+		if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+			parsedID, err := uuid.Parse(employeeIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+			}
+			employeeID = &parsedID
+		}
+
+		year, err := validator.ValidateYear(c.Query("year"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		entitlements, err := repository.GetFilteredLeaveEntitlements(employeeID, year)
+		if err != nil {
+			utils.Error("Failed to get leave entitlements: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get leave entitlements"})
+		}
+		if entitlements == nil {
+			entitlements = []model.LeaveEntitlement{}
+		}
+
+		return c.JSON(entitlements)
+	})
+
+	// Update leave entitlement amounts and accrual method
+	app.Put("/leave-entitlements/:id", func(c *fiber.Ctx) error {
+		// 1. Parse and validate entitlement ID
+		entitlementID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid leave entitlement ID format"})
+		}
+		entitlement, err := repository.GetLeaveEntitlementByID(entitlementID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "leave entitlement not found"})
+		}
+
+		// 2. Parse input
+		var input validator.LeaveEntitlementInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for leave entitlement"})
+		}
+
+		// 3. Update fields if provided
+		if input.AllowanceHours != nil {
+			entitlement.AllowanceHours = *input.AllowanceHours
+		}
+		if input.AccrualMethod != "" {
+			entitlement.AccrualMethod = input.AccrualMethod
+		}
+		if input.CarryOverCapHours != nil {
+			entitlement.CarryOverCapHours = *input.CarryOverCapHours
+		}
+		if err := validator.ValidateLeaveEntitlementValues(entitlement.Year, entitlement.AllowanceHours, entitlement.AccrualMethod, entitlement.CarryOverCapHours); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 4. Save changes
+		if err := repository.UpdateLeaveEntitlement(&entitlement); err != nil {
+			utils.Error("Failed to update leave entitlement: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update leave entitlement"})
+		}
+
+		return c.JSON(entitlement)
+	})
+
+	// Delete leave entitlement
+	app.Delete("/leave-entitlements/:id", func(c *fiber.Ctx) error {
+		entitlementID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid leave entitlement ID format"})
+		}
+
+		if _, err := repository.GetLeaveEntitlementByID(entitlementID); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "leave entitlement not found"})
+		}
+
+		if err := repository.DeleteLeaveEntitlement(entitlementID); err != nil {
+			utils.Error("Failed to delete leave entitlement: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete leave entitlement"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+
+	// Get the leave balances of an employee for a year (defaults to the year of as_of)
+	app.Get("/employees/:id/leave-balances", func(c *fiber.Ctx) error {
+		// 1. Parse and validate employee ID
+		employeeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+		}
+		exists, err := repository.CheckEmployeeExists(employeeID)
+		if err != nil {
+			utils.Error("Failed to check employee existence: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+		if !exists {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "employee not found"})
+		}
+
+		// 2. Parse as_of date and year
+		asOf, err := validator.ValidateAndParseAsOfDate(c.Query("as_of"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		year, err := validator.ValidateYear(c.Query("year"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if year == nil {
+			asOfYear := asOf.Year()
+			year = &asOfYear
+		} else if c.Query("as_of") == "" && *year != asOf.Year() {
+			// Without an explicit as_of, past and future years are reported as of their last day
+			asOf = time.Date(*year, 12, 31, 0, 0, 0, 0, time.UTC)
+		}
+
+		// 3. Compute balances
+		balances, err := service.GetEmployeeLeaveBalances(employeeID, *year, asOf)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to compute leave balances"})
+		}
+
+		return c.JSON(balances)
+	})
+}
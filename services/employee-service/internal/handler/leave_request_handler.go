@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"services/shared/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupLeaveRequestRoutes configures the routes for leave requests and their approval workflow.
+func SetupLeaveRequestRoutes(app *fiber.App) {
+	// Create a new leave request
+	app.Post("/leave-requests", func(c *fiber.Ctx) error {
+		// 1. Parse input
+		var input validator.LeaveRequestInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for leave request"})
+		}
+
+		// 2. Validate required fields and leave type
+		if err := validator.ValidateLeaveRequestRequiredFields(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if err := validator.ValidateLeaveType(input.Type); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Parse and validate employee ID
+		employeeID, err := uuid.Parse(input.EmployeeID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+		}
+		if ok, err := checkActingEmployee(c, employeeID); !ok {
+			return err
+		}
+
+		// 4. Check if employee exists
+		exists, err := repository.CheckEmployeeExists(employeeID)
+		if err != nil {
+			utils.Error("Failed to check employee existence: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+		if !exists {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "employee not found"})
+		}
+
+		// 5. Parse and validate date-times
+		startDateTime, endDateTime, err := validator.ValidateAndParseLeaveDateTimes(input.StartDateTime, input.EndDateTime)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 6. Build and save leave request
+		leaveRequest := service.BuildLeaveRequestModel(employeeID, input.Type, startDateTime, endDateTime, strings.TrimSpace(input.Reason), currentUserID(c))
+		if err := repository.CreateLeaveRequest(leaveRequest); err != nil {
+			utils.Error("Failed to create leave request: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create leave request"})
+		}
+
+		// 7. Return the request with its conflicts so the requester sees them immediately
+		return respondWithLeaveDetail(c, fiber.StatusCreated, *leaveRequest)
+	})
+
+	// Get leave requests with optional filtering
+	app.Get("/leave-requests", func(c *fiber.Ctx) error {
+		// Parse employee ID if provided
+		var employeeID *uuid.UUID
+		if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+			parsedID, err := uuid.Parse(employeeIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+			}
+			employeeID = &parsedID
+		}
+
+		// Restrict managers to their employees, and anyone to the employees of a manager if requested
+		managedIDs, ok, err := managedEmployeeScope(c)
+		if !ok {
+			return err
+		}
+
+		// Validate status if provided
+		status := c.Query("status")
+		if err := validator.ValidateLeaveStatus(status); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// Parse start date if provided
+		var startDate *time.Time
+		if startDateStr := c.Query("start_date"); startDateStr != "" {
+			parsedDate, err := time.Parse("2006-01-02", startDateStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid start_date format, use YYYY-MM-DD"})
+			}
+			startDate = &parsedDate
+		}
+
+		// Parse end date if provided
+		var endDate *time.Time
+		if endDateStr := c.Query("end_date"); endDateStr != "" {
+			parsedDate, err := time.Parse("2006-01-02", endDateStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid end_date format, use YYYY-MM-DD"})
+			}
+			// Set the time to the end of the day for inclusive end date filtering
+			parsedDate = time.Date(parsedDate.Year(), parsedDate.Month(), parsedDate.Day(), 23, 59, 59, 999999999, parsedDate.Location())
+			endDate = &parsedDate
+		}
+
+		leaveRequests, err := repository.GetFilteredLeaveRequests(employeeID, managedIDs, status, startDate, endDate)
+		if err != nil {
+			utils.Error("Failed to get leave requests: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get leave requests"})
+		}
+		if leaveRequests == nil {
+			leaveRequests = []model.LeaveRequest{}
+		}
+
+		return c.JSON(leaveRequests)
+	})
+
+	// Get leave request by ID, including its current conflicts
+	app.Get("/leave-requests/:id", func(c *fiber.Ctx) error {
+		leaveRequestID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid leave request ID format"})
+		}
+
+		leaveRequest, err := repository.GetLeaveRequestByID(leaveRequestID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "leave request not found"})
+		}
+		if ok, err := checkManagedRecord(c, leaveRequest.EmployeeID); !ok {
+			return err
+		}
+
+		return respondWithLeaveDetail(c, fiber.StatusOK, leaveRequest)
+	})
+
+	// Approve a pending leave request, creating its one-time block
+	app.Post("/leave-requests/:id/approve", func(c *fiber.Ctx) error {
+		return decideLeaveRequest(c, service.ApproveLeaveRequest, false)
+	})
+
+	// Reject a pending leave request
+	app.Post("/leave-requests/:id/reject", func(c *fiber.Ctx) error {
+		return decideLeaveRequest(c, service.RejectLeaveRequest, false)
+	})
+
+	// Cancel a pending or approved leave request, removing its one-time block
+	app.Post("/leave-requests/:id/cancel", func(c *fiber.Ctx) error {
+		return decideLeaveRequest(c, service.CancelLeaveRequest, true)
+	})
+}
+
+// decideLeaveRequest runs a status transition on a leave request on behalf of the current user.
+// allowSelf lets employees run the transition on their own requests.
+func decideLeaveRequest(c *fiber.Ctx, decide func(model.LeaveRequest, string, string) (*model.LeaveRequest, error), allowSelf bool) error {
+	// 1. The acting user is recorded on every decision
+	userID := currentUserID(c)
+	if userID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authenticated user required"})
+	}
+
+	// 2. Parse and validate leave request ID
+	leaveRequestID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid leave request ID format"})
+	}
+	leaveRequest, err := repository.GetLeaveRequestByID(leaveRequestID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "leave request not found"})
+	}
+
+	// 3. Managers can only decide on requests of the employees they manage
+	if err := checkManagerScope(c, allowSelf, leaveRequest.EmployeeID); err != nil {
+		return respondWithScopeError(c, err)
+	}
+
+	// 4. Parse optional decision note
+	note, err := parseDecisionNote(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for leave decision"})
+	}
+
+	// 5. Apply the decision
+	updated, err := decide(leaveRequest, userID, note)
+	if err != nil {
+		if conflictErr, ok := err.(*service.LeaveConflictError); ok {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":     conflictErr.Error(),
+				"conflicts": conflictErr.Conflicts,
+			})
+		}
+		if _, ok := err.(*service.LeaveStatusError); ok {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update leave request"})
+	}
+
+	return c.JSON(updated)
+}
+
+// parseDecisionNote reads the optional note of an approval, rejection or cancellation; the body may be empty
+func parseDecisionNote(c *fiber.Ctx) (string, error) {
+	var input validator.DecisionInput
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&input); err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimSpace(input.Note), nil
+}
+
+// respondWithLeaveDetail writes a leave request together with its current conflicts
+func respondWithLeaveDetail(c *fiber.Ctx, status int, leaveRequest model.LeaveRequest) error {
+	conflicts, err := service.GetLeaveConflicts(leaveRequest)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check leave conflicts"})
+	}
+
+	return c.Status(status).JSON(model.LeaveRequestDetail{
+		LeaveRequest: leaveRequest,
+		Conflicts:    conflicts,
+	})
+}
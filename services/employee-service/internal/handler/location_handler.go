@@ -0,0 +1,316 @@
+package handler
+
+import (
+	"services/shared/utils"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupLocationRoutes configures the routes for salon locations, their opening hours and coverage.
+func SetupLocationRoutes(app *fiber.App) {
+	// Create a new location
+	app.Post("/locations", func(c *fiber.Ctx) error {
+		// 1. Parse input
+		var input validator.LocationInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for location"})
+		}
+
+		// 2. Validate required fields
+		if err := validator.ValidateLocationRequiredFields(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Validate time zone and country
+		timeZone, err := validator.ValidateTimeZone(input.TimeZone)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		country, err := validator.ValidateCountryCode(input.Country)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 4. Build location model
+		location := &model.Location{
+			Name:       strings.TrimSpace(input.Name),
+			Street:     strings.TrimSpace(input.Street),
+			City:       strings.TrimSpace(input.City),
+			PostalCode: strings.TrimSpace(input.PostalCode),
+			Country:    country,
+			Phone:      strings.TrimSpace(input.Phone),
+			TimeZone:   timeZone,
+			IsActive:   input.IsActive == nil || *input.IsActive,
+		}
+
+		// 5. Check for duplicate names
+		if err := service.CheckForDuplicateLocation(location); err != nil {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 6. Save to database
+		if err := repository.CreateLocation(location); err != nil {
+			utils.Error("Failed to create location: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create location"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(location)
+	})
+
+	// Get locations, only active ones with active=true
+	app.Get("/locations", func(c *fiber.Ctx) error {
+		locations, err := repository.GetFilteredLocations(c.Query("active") == "true")
+		if err != nil {
+			utils.Error("Failed to get locations: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get locations"})
+		}
+
+		return c.JSON(locations)
+	})
+
+	// Get location by ID
+	app.Get("/locations/:id", func(c *fiber.Ctx) error {
+		location, err := findLocation(c)
+		if location == nil {
+			return err
+		}
+
+		return c.JSON(location)
+	})
+
+	// Update location
+	app.Put("/locations/:id", func(c *fiber.Ctx) error {
+		// 1. Check if location exists
+		location, err := findLocation(c)
+		if location == nil {
+			return err
+		}
+
+		// 2. Parse input
+		var input validator.LocationInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for location"})
+		}
+
+		// 3. Update fields if provided
+		if strings.TrimSpace(input.Name) != "" {
+			location.Name = strings.TrimSpace(input.Name)
+		}
+		if strings.TrimSpace(input.Street) != "" {
+			location.Street = strings.TrimSpace(input.Street)
+		}
+		if strings.TrimSpace(input.City) != "" {
+			location.City = strings.TrimSpace(input.City)
+		}
+		if strings.TrimSpace(input.PostalCode) != "" {
+			location.PostalCode = strings.TrimSpace(input.PostalCode)
+		}
+		if input.Country != "" {
+			country, err := validator.ValidateCountryCode(input.Country)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			location.Country = country
+		}
+		if strings.TrimSpace(input.Phone) != "" {
+			location.Phone = strings.TrimSpace(input.Phone)
+		}
+		if input.TimeZone != "" {
+			timeZone, err := validator.ValidateTimeZone(input.TimeZone)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			location.TimeZone = timeZone
+		}
+		if input.IsActive != nil {
+			location.IsActive = *input.IsActive
+		}
+
+		// 4. Check for duplicate names
+		if err := service.CheckForDuplicateLocation(location); err != nil {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 5. Save to database
+		if err := repository.UpdateLocation(location); err != nil {
+			utils.Error("Failed to update location: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update location"})
+		}
+
+		return c.JSON(location)
+	})
+
+	// Delete location (including its opening hours); locations still referenced by schedules or open shifts are kept
+	app.Delete("/locations/:id", func(c *fiber.Ctx) error {
+		location, err := findLocation(c)
+		if location == nil {
+			return err
+		}
+
+		inUse, err := repository.CheckLocationInUse(location.ID)
+		if err != nil {
+			utils.Error("Failed to check location usage: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+		if inUse {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "location is still used by schedules or open shifts, deactivate it instead"})
+		}
+
+		if err := repository.DeleteLocation(location.ID); err != nil {
+			utils.Error("Failed to delete location: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete location"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+
+	// Get opening hours of a location
+	app.Get("/locations/:id/opening-hours", func(c *fiber.Ctx) error {
+		locationID, err := validator.ValidateLocationExists(c.Params("id"))
+		if err != nil {
+			return respondWithLocationError(c, err)
+		}
+
+		openingHours, err := repository.GetLocationOpeningHours(locationID)
+		if err != nil {
+			utils.Error("Failed to get location opening hours: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get opening hours"})
+		}
+
+		return c.JSON(openingHours)
+	})
+
+	// Add opening hours to a location
+	app.Post("/locations/:id/opening-hours", func(c *fiber.Ctx) error {
+		// 1. Validate location ID and existence
+		locationID, err := validator.ValidateLocationExists(c.Params("id"))
+		if err != nil {
+			return respondWithLocationError(c, err)
+		}
+
+		// 2. Parse input
+		var input validator.LocationOpeningHourInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for opening hours"})
+		}
+
+		// 3. Validate required fields and day of week
+		if err := validator.ValidateLocationOpeningHourRequiredFields(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 4. Validate and normalize time formats
+		startTime, endTime, err := validator.ValidateAndNormalizeTimes(input.StartTime, input.EndTime)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 5. Build model and check for overlaps
+		openingHour := service.BuildLocationOpeningHourModel(locationID, *input.DayOfWeek, startTime, endTime)
+		if err := service.CheckForOverlappingLocationOpeningHour(openingHour, nil); err != nil {
+			if _, ok := err.(*service.OverlappingLocationOpeningHourError); ok {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "error checking for overlapping opening hours"})
+		}
+
+		// 6. Save to database
+		if err := repository.CreateLocationOpeningHour(openingHour); err != nil {
+			utils.Error("Failed to create location opening hours: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create opening hours"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(openingHour)
+	})
+
+	// Delete opening hours of a location
+	app.Delete("/locations/:id/opening-hours/:hourId", func(c *fiber.Ctx) error {
+		locationID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid location ID format"})
+		}
+		openingHourID, err := uuid.Parse(c.Params("hourId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid opening hours ID format"})
+		}
+
+		openingHour, err := repository.GetLocationOpeningHourByID(openingHourID)
+		if err != nil || openingHour.LocationID != locationID {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "opening hours not found"})
+		}
+
+		if err := repository.DeleteLocationOpeningHour(openingHourID); err != nil {
+			utils.Error("Failed to delete location opening hours: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete opening hours"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+
+	// Get who works at a location per day and the opening hours nobody covers
+	app.Get("/locations/:id/coverage", func(c *fiber.Ctx) error {
+		// 1. Check if location exists
+		location, err := findLocation(c)
+		if location == nil {
+			return err
+		}
+
+		// 2. Parse period
+		startDate, endDate, err := validator.ValidateCoveragePeriod(c.Query("start_date"), c.Query("end_date"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Build coverage report
+		availabilityService := service.NewAvailabilityService()
+		coverage, err := availabilityService.GetLocationCoverage(*location, startDate, endDate)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+
+		return c.JSON(coverage)
+	})
+}
+
+// findLocation loads the location of the :id route parameter, writing the error response when it fails
+func findLocation(c *fiber.Ctx) (*model.Location, error) {
+	locationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid location ID format"})
+	}
+
+	location, err := repository.GetLocationByID(locationID)
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "location not found"})
+	}
+	return &location, nil
+}
+
+// respondWithLocationError writes the response for location lookup and validation errors
+func respondWithLocationError(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "location not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case "internal server error":
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+}
+
+// respondWithLocationConflict writes the response for schedules overlapping a shift at another location
+func respondWithLocationConflict(c *fiber.Ctx, err error) error {
+	if conflict, ok := err.(*service.LocationConflictError); ok {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":                   err.Error(),
+			"conflicting_schedule_id": conflict.ScheduleID,
+			"conflicting_location_id": conflict.LocationID,
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+}
@@ -0,0 +1,289 @@
+package handler
+
+import (
+	"services/shared/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupOpenShiftRoutes configures the routes for posting and claiming open shifts.
+func SetupOpenShiftRoutes(app *fiber.App) {
+	// Post a new open shift
+	app.Post("/open-shifts", func(c *fiber.Ctx) error {
+		// 1. The posting manager is recorded on the shift
+		userID := currentUserID(c)
+		if userID == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authenticated user required"})
+		}
+
+		// 2. Parse input
+		var input validator.OpenShiftInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for open shift"})
+		}
+
+		// 3. Validate required fields and values
+		if err := validator.ValidateOpenShiftRequiredFields(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if input.Headcount == 0 {
+			input.Headcount = 1
+		}
+		if input.ClaimMode == "" {
+			input.ClaimMode = model.ClaimModeApproval
+		}
+		if err := validator.ValidateOpenShiftValues(input.Headcount, input.ClaimMode); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 4. Parse date and times
+		shiftDate, err := validator.ValidateAndParseShiftDate(input.ShiftDate)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if shiftDate.Before(time.Now().UTC().Truncate(24 * time.Hour)) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "shift_date cannot be in the past"})
+		}
+		startTime, endTime, err := validator.ValidateAndNormalizeTimes(input.StartTime, input.EndTime)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 5. Resolve the location the shift is worked at
+		locationID, err := validator.ValidateWorkLocation(input.LocationID)
+		if err != nil {
+			return respondWithLocationError(c, err)
+		}
+
+		// 6. Build and save open shift
+		openShift := service.BuildOpenShiftModel(shiftDate, startTime, endTime, strings.TrimSpace(input.Role), input.Headcount, input.ClaimMode, input.Notes, userID, locationID)
+		if err := repository.CreateOpenShift(openShift); err != nil {
+			utils.Error("Failed to create open shift: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create open shift"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(openShift)
+	})
+
+	// Get open shifts with optional filtering by status, date, role and location
+	app.Get("/open-shifts", func(c *fiber.Ctx) error {
+		status := c.Query("status")
+		if err := validator.ValidateOpenShiftStatus(status); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		var date *time.Time
+		if dateStr := c.Query("date"); dateStr != "" {
+			parsedDate, err := time.Parse("2006-01-02", dateStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date format, use YYYY-MM-DD"})
+			}
+			date = &parsedDate
+		}
+
+		locationID, err := validator.ValidateLocationFilter(c.Query("location_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		openShifts, err := repository.GetFilteredOpenShifts(status, date, c.Query("role"), locationID)
+		if err != nil {
+			utils.Error("Failed to get open shifts: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get open shifts"})
+		}
+		if openShifts == nil {
+			openShifts = []model.OpenShift{}
+		}
+
+		return c.JSON(openShifts)
+	})
+
+	// Get open shift by ID, including its claims
+	app.Get("/open-shifts/:id", func(c *fiber.Ctx) error {
+		openShift, err := findOpenShift(c)
+		if openShift == nil {
+			return err
+		}
+		return c.JSON(openShift)
+	})
+
+	// List employees who can still claim the open shift
+	app.Get("/open-shifts/:id/eligible-employees", func(c *fiber.Ctx) error {
+		openShift, err := findOpenShift(c)
+		if openShift == nil {
+			return err
+		}
+
+		skill, minLevel, err := validator.ValidateSkillFilter(c.Query("skill"), c.Query("min_level"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		eligible, err := service.GetEligibleEmployees(*openShift)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get eligible employees"})
+		}
+
+		// Optionally narrow down to employees with a skill
+		if skill != "" {
+			skilled, err := repository.GetEmployeesWithSkill(skill, minLevel, openShift.ShiftDate)
+			if err != nil {
+				utils.Error("Failed to get employees with skill: " + err.Error())
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get eligible employees"})
+			}
+			hasSkill := make(map[uuid.UUID]bool, len(skilled))
+			for _, employee := range skilled {
+				hasSkill[employee.ID] = true
+			}
+			filtered := []model.EligibleEmployee{}
+			for _, employee := range eligible {
+				if hasSkill[employee.EmployeeID] {
+					filtered = append(filtered, employee)
+				}
+			}
+			eligible = filtered
+		}
+
+		return c.JSON(eligible)
+	})
+
+	// Claim an open shift
+	app.Post("/open-shifts/:id/claims", func(c *fiber.Ctx) error {
+		// 1. Find open shift
+		openShift, err := findOpenShift(c)
+		if openShift == nil {
+			return err
+		}
+
+		// 2. Parse claiming employee
+		var input validator.OpenShiftClaimInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for open shift claim"})
+		}
+		if input.EmployeeID == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "employee_id is required to claim an open shift"})
+		}
+		employeeID, err := uuid.Parse(input.EmployeeID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+		}
+		if ok, err := checkActingEmployee(c, employeeID); !ok {
+			return err
+		}
+
+		// 3. Claim the shift
+		claim, err := service.ClaimOpenShift(*openShift, employeeID)
+		if err != nil {
+			return respondWithOpenShiftError(c, err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(claim)
+	})
+
+	// Approve a pending claim, creating the employee's schedule
+	app.Post("/open-shifts/:id/claims/:claimId/approve", func(c *fiber.Ctx) error {
+		return decideOpenShiftClaim(c, true)
+	})
+
+	// Reject a pending claim
+	app.Post("/open-shifts/:id/claims/:claimId/reject", func(c *fiber.Ctx) error {
+		return decideOpenShiftClaim(c, false)
+	})
+
+	// Cancel an open shift, removing the schedules created for it
+	app.Post("/open-shifts/:id/cancel", func(c *fiber.Ctx) error {
+		userID := currentUserID(c)
+		if userID == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authenticated user required"})
+		}
+
+		openShift, err := findOpenShift(c)
+		if openShift == nil {
+			return err
+		}
+
+		cancelled, err := service.CancelOpenShift(*openShift, userID)
+		if err != nil {
+			return respondWithOpenShiftError(c, err)
+		}
+
+		return c.JSON(cancelled)
+	})
+}
+
+// findOpenShift parses the open shift ID route parameter and loads the shift.
+// When the shift cannot be loaded it writes the error response and returns a nil shift.
+func findOpenShift(c *fiber.Ctx) (*model.OpenShift, error) {
+	openShiftID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid open shift ID format"})
+	}
+
+	openShift, err := repository.GetOpenShiftByID(openShiftID)
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "open shift not found"})
+	}
+	return &openShift, nil
+}
+
+// decideOpenShiftClaim approves or rejects a pending claim on behalf of the current user
+func decideOpenShiftClaim(c *fiber.Ctx, approve bool) error {
+	// 1. The acting user is recorded on every decision
+	userID := currentUserID(c)
+	if userID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authenticated user required"})
+	}
+
+	// 2. Find open shift and claim
+	openShift, err := findOpenShift(c)
+	if openShift == nil {
+		return err
+	}
+	claimID, err := uuid.Parse(c.Params("claimId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid claim ID format"})
+	}
+	claim, err := repository.GetOpenShiftClaimByID(openShift.ID, claimID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "claim not found"})
+	}
+
+	// 3. Managers can only decide on claims of the employees they manage
+	if err := checkManagerScope(c, false, claim.EmployeeID); err != nil {
+		return respondWithScopeError(c, err)
+	}
+
+	// 4. Apply the decision
+	var updated *model.OpenShiftClaim
+	if approve {
+		updated, err = service.ApproveOpenShiftClaim(*openShift, claim, userID)
+	} else {
+		updated, err = service.RejectOpenShiftClaim(claim, userID)
+	}
+	if err != nil {
+		return respondWithOpenShiftError(c, err)
+	}
+
+	return c.JSON(updated)
+}
+
+// respondWithOpenShiftError maps open shift service errors to HTTP responses
+func respondWithOpenShiftError(c *fiber.Ctx, err error) error {
+	if _, ok := err.(*service.OpenShiftClaimError); ok {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	}
+	if _, ok := err.(*service.OpenShiftUnavailableError); ok {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err.Error() == "employee not found" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/storage"
+)
+
+// SetupPhotoRoutes configures the routes for uploading and serving employee photos.
+func SetupPhotoRoutes(app *fiber.App, store storage.BlobStore) {
+	// Upload a new photo for an employee (multipart form field "photo")
+	app.Post("/employees/:id/photo", func(c *fiber.Ctx) error {
+		// 1. Find employee
+		employeeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID"})
+		}
+		employee, err := repository.GetEmployeeByID(employeeID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "employee not found"})
+		}
+
+		// 2. Read the uploaded file, one byte past the limit so oversized files are detected
+		fileHeader, err := c.FormFile("photo")
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "photo file is required"})
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "photo file could not be read"})
+		}
+		defer file.Close()
+		data, err := io.ReadAll(io.LimitReader(file, int64(service.PhotoMaxBytes())+1))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "photo file could not be read"})
+		}
+
+		// 3. Process and store
+		photo, err := service.UploadEmployeePhoto(c.UserContext(), store, employee, data, currentUserID(c))
+		if err != nil {
+			if _, ok := err.(*service.PhotoError); ok {
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to upload photo"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+			"photo":   photo,
+			"picture": service.EmployeePhotoURL(*photo),
+		})
+	})
+
+	// Serve the photo of an employee in full (default) or thumbnail size
+	app.Get("/employees/:id/photo", func(c *fiber.Ctx) error {
+		photo, err := findEmployeePhoto(c)
+		if photo == nil {
+			return err
+		}
+
+		size := c.Query("size", model.PhotoSizeFull)
+		if size != model.PhotoSizeFull && size != model.PhotoSizeThumbnail {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid size, must be one of: full, thumb"})
+		}
+
+		data, contentType, err := service.GetEmployeePhotoData(c.UserContext(), store, *photo, size)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to read photo"})
+		}
+
+		// Blobs never change; a new upload gets a new photo ID and URL
+		c.Set(fiber.HeaderContentType, contentType)
+		c.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+		c.Set(fiber.HeaderETag, `"`+photo.ID.String()+"-"+size+`"`)
+		return c.Send(data)
+	})
+
+	// Remove the photo of an employee
+	app.Delete("/employees/:id/photo", func(c *fiber.Ctx) error {
+		photo, err := findEmployeePhoto(c)
+		if photo == nil {
+			return err
+		}
+
+		if err := service.DeleteEmployeePhoto(c.UserContext(), store, *photo); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete photo"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+}
+
+// findEmployeePhoto parses the employee ID route parameter and loads their photo.
+// When there is no photo it writes the error response and returns a nil photo.
+func findEmployeePhoto(c *fiber.Ctx) (*model.EmployeePhoto, error) {
+	employeeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID"})
+	}
+
+	photo, err := repository.GetEmployeePhoto(employeeID)
+	if err != nil {
+		return nil, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get photo"})
+	}
+	if photo == nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "photo not found"})
+	}
+	return photo, nil
+}
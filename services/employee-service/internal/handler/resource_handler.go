@@ -0,0 +1,464 @@
+package handler
+
+import (
+	"services/shared/utils"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupResourceRoutes configures the routes for resource (chairs, rooms, equipment) management.
+func SetupResourceRoutes(app *fiber.App) {
+	// Create a new resource
+	app.Post("/resources", func(c *fiber.Ctx) error {
+		// 1. Parse input
+		var input validator.ResourceInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for resource"})
+		}
+
+		// 2. Validate required fields
+		if err := validator.ValidateResourceRequiredFields(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Validate capacity
+		capacity, err := validator.ValidateResourceCapacity(input.Capacity)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 4. Build resource model
+		resource := &model.Resource{
+			Name:     strings.TrimSpace(input.Name),
+			Type:     strings.ToLower(strings.TrimSpace(input.Type)),
+			Capacity: capacity,
+			IsActive: input.IsActive == nil || *input.IsActive,
+		}
+
+		// 5. Check for duplicate names
+		if err := service.CheckForDuplicateResource(resource); err != nil {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 6. Save to database
+		if err := repository.CreateResource(resource); err != nil {
+			utils.Error("Failed to create resource: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create resource"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(resource)
+	})
+
+	// Get resources with optional filtering
+	app.Get("/resources", func(c *fiber.Ctx) error {
+		resources, err := repository.GetFilteredResources(strings.ToLower(c.Query("type")), c.Query("active") == "true")
+		if err != nil {
+			utils.Error("Failed to get resources: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get resources"})
+		}
+
+		return c.JSON(resources)
+	})
+
+	// Get resource by ID
+	app.Get("/resources/:id", func(c *fiber.Ctx) error {
+		resourceID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid resource ID format"})
+		}
+
+		resource, err := repository.GetResourceByID(resourceID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "resource not found"})
+		}
+
+		return c.JSON(resource)
+	})
+
+	// Update existing resource
+	app.Put("/resources/:id", func(c *fiber.Ctx) error {
+		// 1. Parse and validate resource ID
+		resourceID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid resource ID format"})
+		}
+
+		// 2. Check if resource exists
+		existingResource, err := repository.GetResourceByID(resourceID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "resource not found"})
+		}
+
+		// 3. Parse input
+		var input validator.ResourceInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for resource"})
+		}
+
+		// 4. Update fields if provided
+		if strings.TrimSpace(input.Name) != "" {
+			existingResource.Name = strings.TrimSpace(input.Name)
+		}
+		if strings.TrimSpace(input.Type) != "" {
+			existingResource.Type = strings.ToLower(strings.TrimSpace(input.Type))
+		}
+		if input.Capacity != nil {
+			capacity, err := validator.ValidateResourceCapacity(input.Capacity)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			existingResource.Capacity = capacity
+		}
+		if input.IsActive != nil {
+			existingResource.IsActive = *input.IsActive
+		}
+
+		// 5. Check for duplicate names
+		if err := service.CheckForDuplicateResource(&existingResource); err != nil {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 6. Save to database
+		if err := repository.UpdateResource(&existingResource); err != nil {
+			utils.Error("Failed to update resource: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update resource"})
+		}
+
+		return c.JSON(existingResource)
+	})
+
+	// Delete resource (including its opening hours and blocks)
+	app.Delete("/resources/:id", func(c *fiber.Ctx) error {
+		resourceID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid resource ID format"})
+		}
+
+		if _, err := repository.GetResourceByID(resourceID); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "resource not found"})
+		}
+
+		if err := repository.DeleteResource(resourceID); err != nil {
+			utils.Error("Failed to delete resource: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete resource"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+
+	// Get opening hours of a resource
+	app.Get("/resources/:id/opening-hours", func(c *fiber.Ctx) error {
+		resourceID, err := validator.ValidateResourceExists(c.Params("id"))
+		if err != nil {
+			if err.Error() == "resource not found" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		openingHours, err := repository.GetResourceOpeningHours(resourceID)
+		if err != nil {
+			utils.Error("Failed to get resource opening hours: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get opening hours"})
+		}
+
+		return c.JSON(openingHours)
+	})
+
+	// Add opening hours to a resource
+	app.Post("/resources/:id/opening-hours", func(c *fiber.Ctx) error {
+		// 1. Validate resource ID and existence
+		resourceID, err := validator.ValidateResourceExists(c.Params("id"))
+		if err != nil {
+			if err.Error() == "resource not found" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 2. Parse input
+		var input validator.ResourceOpeningHourInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for opening hours"})
+		}
+
+		// 3. Validate required fields and day of week
+		if err := validator.ValidateResourceOpeningHourRequiredFields(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 4. Validate and normalize time formats
+		startTime, endTime, err := validator.ValidateAndNormalizeTimes(input.StartTime, input.EndTime)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 5. Build model and check for overlaps
+		openingHour := service.BuildResourceOpeningHourModel(resourceID, *input.DayOfWeek, startTime, endTime)
+		if err := service.CheckForOverlappingResourceOpeningHour(openingHour, nil); err != nil {
+			if _, ok := err.(*service.OverlappingResourceOpeningHourError); ok {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "error checking for overlapping opening hours"})
+		}
+
+		// 6. Save to database
+		if err := repository.CreateResourceOpeningHour(openingHour); err != nil {
+			utils.Error("Failed to create resource opening hours: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create opening hours"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(openingHour)
+	})
+
+	// Delete opening hours of a resource
+	app.Delete("/resources/:id/opening-hours/:hourId", func(c *fiber.Ctx) error {
+		resourceID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid resource ID format"})
+		}
+		openingHourID, err := uuid.Parse(c.Params("hourId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid opening hours ID format"})
+		}
+
+		openingHour, err := repository.GetResourceOpeningHourByID(openingHourID)
+		if err != nil || openingHour.ResourceID != resourceID {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "opening hours not found"})
+		}
+
+		if err := repository.DeleteResourceOpeningHour(openingHourID); err != nil {
+			utils.Error("Failed to delete resource opening hours: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete opening hours"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+
+	// POST /resources/availability - Get resource availability for a specific date
+	app.Post("/resources/availability", getResourceAvailability)
+
+	// Create a new resource block
+	app.Post("/resource-blocks", func(c *fiber.Ctx) error {
+		// 1. Parse input
+		var input validator.ResourceBlockInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for resource block"})
+		}
+
+		// 2. Validate required fields
+		if err := validator.ValidateResourceBlockRequiredFields(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if err := validator.ValidateResourceBlockUnits(input.Units); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Validate resource ID and existence
+		resourceID, err := validator.ValidateResourceExists(input.ResourceID)
+		if err != nil {
+			if err.Error() == "resource not found" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 4. Validate and parse date-times
+		startDateTime, endDateTime, err := validator.ValidateAndParseResourceBlockDateTimes(input.StartDateTime, input.EndDateTime)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 5. Build model and check for overlaps
+		block := service.BuildResourceBlockModel(resourceID, startDateTime, endDateTime, input.Units, input.Reason)
+		if err := service.CheckForOverlappingResourceBlock(block, nil); err != nil {
+			if _, ok := err.(*service.OverlappingResourceBlockError); ok {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+			}
+			if _, ok := err.(*service.ResourceBlockUnitsError); ok {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "error checking for overlapping resource block"})
+		}
+
+		// 6. Save to database
+		if err := repository.CreateResourceBlock(block); err != nil {
+			utils.Error("Failed to create resource block: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create resource block"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(block)
+	})
+
+	// Get resource blocks with optional filtering
+	app.Get("/resource-blocks", func(c *fiber.Ctx) error {
+		// Parse resource ID if provided
+		var resourceID *uuid.UUID
+		if resourceIDStr := c.Query("resource_id"); resourceIDStr != "" {
+			parsedID, err := uuid.Parse(resourceIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid resource ID format"})
+			}
+			resourceID = &parsedID
+		}
+
+		// Parse start date if provided
+		var startDate *time.Time
+		if startDateStr := c.Query("start_date"); startDateStr != "" {
+			parsedDate, err := time.Parse("2006-01-02", startDateStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid start_date format, use YYYY-MM-DD"})
+			}
+			startDate = &parsedDate
+		}
+
+		// Parse end date if provided (inclusive)
+		var endDate *time.Time
+		if endDateStr := c.Query("end_date"); endDateStr != "" {
+			parsedDate, err := time.Parse("2006-01-02", endDateStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid end_date format, use YYYY-MM-DD"})
+			}
+			parsedDate = time.Date(parsedDate.Year(), parsedDate.Month(), parsedDate.Day(), 23, 59, 59, 999999999, parsedDate.Location())
+			endDate = &parsedDate
+		}
+
+		blocks, err := repository.GetFilteredResourceBlocks(resourceID, startDate, endDate)
+		if err != nil {
+			utils.Error("Failed to get resource blocks: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get resource blocks"})
+		}
+
+		return c.JSON(blocks)
+	})
+
+	// Update existing resource block
+	app.Put("/resource-blocks/:id", func(c *fiber.Ctx) error {
+		// 1. Parse and validate block ID
+		blockID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid resource block ID format"})
+		}
+
+		// 2. Check if block exists
+		existingBlock, err := repository.GetResourceBlockByID(blockID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "resource block not found"})
+		}
+
+		// 3. Parse and validate input
+		var input validator.ResourceBlockInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for resource block"})
+		}
+		if err := validator.ValidateResourceBlockRequiredFields(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if err := validator.ValidateResourceBlockUnits(input.Units); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 4. Validate resource ID and existence
+		resourceID, err := validator.ValidateResourceExists(input.ResourceID)
+		if err != nil {
+			if err.Error() == "resource not found" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 5. Validate and parse date-times
+		startDateTime, endDateTime, err := validator.ValidateAndParseResourceBlockDateTimes(input.StartDateTime, input.EndDateTime)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 6. Update block object and check for overlaps (excluding current one)
+		existingBlock.ResourceID = resourceID
+		existingBlock.StartDateTime = startDateTime
+		existingBlock.EndDateTime = endDateTime
+		existingBlock.Units = input.Units
+		existingBlock.Reason = input.Reason
+
+		if err := service.CheckForOverlappingResourceBlock(&existingBlock, &blockID); err != nil {
+			if _, ok := err.(*service.OverlappingResourceBlockError); ok {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+			}
+			if _, ok := err.(*service.ResourceBlockUnitsError); ok {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "error checking for overlapping resource block"})
+		}
+
+		// 7. Save to database
+		if err := repository.UpdateResourceBlock(&existingBlock); err != nil {
+			utils.Error("Failed to update resource block: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update resource block"})
+		}
+
+		return c.JSON(existingBlock)
+	})
+
+	// Delete resource block
+	app.Delete("/resource-blocks/:id", func(c *fiber.Ctx) error {
+		blockID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid resource block ID format"})
+		}
+
+		if _, err := repository.GetResourceBlockByID(blockID); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "resource block not found"})
+		}
+
+		if err := repository.DeleteResourceBlock(blockID); err != nil {
+			utils.Error("Failed to delete resource block: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete resource block"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+}
+
+// getResourceAvailability handles the POST /resources/availability endpoint
+// Returns the opening hours, blocks and free slots of a resource on a specific date
+func getResourceAvailability(c *fiber.Ctx) error {
+	// Step 1: Parse request body
+	var req model.ResourceAvailabilityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if req.Date == "" || req.ResourceID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "date and resource_id are required"})
+	}
+
+	// Step 2: Validate and parse resource ID and date
+	resourceID, err := uuid.Parse(req.ResourceID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid resource ID format"})
+	}
+	date, err := validator.ValidateAndParseAvailabilityDate(req.Date)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := validator.ValidateAvailabilityDateRange(date); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// Step 3: Calculate availability
+	availability, err := service.NewResourceService().GetResourceAvailability(resourceID, date)
+	if err != nil {
+		if err.Error() == "resource not found" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Resource not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Internal server error"})
+	}
+
+	return c.JSON(availability)
+}
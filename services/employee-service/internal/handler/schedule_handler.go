@@ -54,8 +54,14 @@ func SetupScheduleRoutes(app *fiber.App) {
 			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
 		}
 
-		// 7. Create schedule object - now using time.Time for start and end times
-		schedule := service.BuildScheduleModel(employeeID, dayOfWeek, startTime, endTime, validFrom, validUntil, input.Notes)
+		// 7. Resolve the location the schedule is worked at
+		locationID, err := validator.ValidateWorkLocation(input.LocationID)
+		if err != nil {
+			return respondWithLocationError(c, err)
+		}
+
+		// 7b. Create schedule object - now using time.Time for start and end times
+		schedule := service.BuildScheduleModel(employeeID, dayOfWeek, startTime, endTime, validFrom, validUntil, input.Notes, locationID)
 
 		// 8. Check for duplicate schedules
 		if err := service.CheckForDuplicateSchedule(schedule, nil); err != nil {
@@ -65,11 +71,23 @@ func SetupScheduleRoutes(app *fiber.App) {
 			return c.Status(500).JSON(fiber.Map{"error": "internal server error"})
 		}
 
-		// 9. Save to database
+		// 8b. Never schedule the employee at two locations at the same time
+		if err := service.CheckScheduleLocationConflict(schedule, nil); err != nil {
+			return respondWithLocationConflict(c, err)
+		}
+
+		// 9. Check employment period and certifications required by the employee's role
+		warnings, err := service.CheckScheduleEmployee(employeeID, validFrom, validUntil)
+		if err != nil {
+			return respondWithScheduleCheckError(c, err)
+		}
+
+		// 10. Save to database
 		if err := repository.CreateSchedule(schedule); err != nil {
 			utils.Error("Failed to create schedule: " + err.Error())
 			return c.Status(500).JSON(fiber.Map{"error": "failed to create schedule"})
 		}
+		schedule.Warnings = warnings
 
 		return c.Status(201).JSON(schedule)
 	})
@@ -105,8 +123,14 @@ func SetupScheduleRoutes(app *fiber.App) {
 			dayOfWeek = &parsedDay
 		}
 		
+		// Parse location ID if provided
+		locationID, err := validator.ValidateLocationFilter(c.Query("location_id"))
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+
 		// Get schedules based on filters
-		schedules, err = repository.GetFilteredSchedules(employeeID, dayOfWeek, validUntilFlag == "all")
+		schedules, err = repository.GetFilteredSchedules(employeeID, dayOfWeek, locationID, validUntilFlag == "all")
 		if err != nil {
 			utils.Error("Failed to get schedules: " + err.Error())
 			return c.Status(500).JSON(fiber.Map{"error": "failed to get schedules"})
@@ -175,6 +199,13 @@ func SetupScheduleRoutes(app *fiber.App) {
 		existingSchedule.ValidFrom = validFrom
 		existingSchedule.ValidUntil = validUntil
 		existingSchedule.Notes = input.Notes
+		if input.LocationID != "" || existingSchedule.LocationID == nil {
+			locationID, err := validator.ValidateWorkLocation(input.LocationID)
+			if err != nil {
+				return respondWithLocationError(c, err)
+			}
+			existingSchedule.LocationID = locationID
+		}
 
 		// 10. Check for duplicate schedules (excluding current one)
 		if err := service.CheckForDuplicateSchedule(&existingSchedule, &scheduleID); err != nil {
@@ -184,11 +215,23 @@ func SetupScheduleRoutes(app *fiber.App) {
 			return c.Status(500).JSON(fiber.Map{"error": "internal server error"})
 		}
 
-		// 11. Save to database
+		// 10b. Never schedule the employee at two locations at the same time
+		if err := service.CheckScheduleLocationConflict(&existingSchedule, &scheduleID); err != nil {
+			return respondWithLocationConflict(c, err)
+		}
+
+		// 11. Check employment period and certifications required by the employee's role
+		warnings, err := service.CheckScheduleEmployee(employeeID, validFrom, validUntil)
+		if err != nil {
+			return respondWithScheduleCheckError(c, err)
+		}
+
+		// 12. Save to database
 		if err := repository.UpdateSchedule(&existingSchedule); err != nil {
 			utils.Error("Failed to update schedule: " + err.Error())
 			return c.Status(500).JSON(fiber.Map{"error": "failed to update schedule"})
 		}
+		existingSchedule.Warnings = warnings
 
 		return c.JSON(existingSchedule)
 	})
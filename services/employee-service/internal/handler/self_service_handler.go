@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupSelfServiceRoutes configures the routes linking auth users to employees and the /me routes
+// through which a signed-in employee reads their own data. The /me routes never take an employee ID;
+// the employee is always resolved from the authenticated user.
+func SetupSelfServiceRoutes(app *fiber.App) {
+	// Link an employee to an auth user
+	app.Put("/employees/:id/user", func(c *fiber.Ctx) error {
+		// 1. Find employee
+		employeeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID"})
+		}
+		employee, err := repository.GetEmployeeByID(employeeID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "employee not found"})
+		}
+
+		// 2. Parse user ID
+		var input validator.UserLinkInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for user link"})
+		}
+		userID, err := validator.ValidateUserID(input.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. A user can only be linked to one employee
+		linked, err := repository.GetEmployeeByUserID(userID)
+		if err == nil && linked.ID != employee.ID {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "user is already linked to another employee"})
+		}
+
+		// 4. Save link
+		if err := repository.SetEmployeeUserID(&employee, &userID); err != nil {
+			utils.Error("Failed to link employee to user: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to link user"})
+		}
+
+		return c.JSON(employee)
+	})
+
+	// Unlink an employee from their auth user
+	app.Delete("/employees/:id/user", func(c *fiber.Ctx) error {
+		employeeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID"})
+		}
+		employee, err := repository.GetEmployeeByID(employeeID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "employee not found"})
+		}
+
+		if err := repository.SetEmployeeUserID(&employee, nil); err != nil {
+			utils.Error("Failed to unlink employee from user: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to unlink user"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+
+	// Get the employee record of the caller
+	app.Get("/me", func(c *fiber.Ctx) error {
+		employee, err := currentEmployee(c)
+		if employee == nil {
+			return err
+		}
+		if employee.CustomFields, err = service.GetEmployeeCustomFields(employee.ID); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+		return c.JSON(employee)
+	})
+
+	// Get the schedules of the caller; validuntil=all includes expired schedules
+	app.Get("/me/schedule", func(c *fiber.Ctx) error {
+		employee, err := currentEmployee(c)
+		if employee == nil {
+			return err
+		}
+
+		schedules, err := repository.GetFilteredSchedules(&employee.ID, nil, nil, c.Query("validuntil") == "all")
+		if err != nil {
+			utils.Error("Failed to get schedules: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get schedules"})
+		}
+		if schedules == nil {
+			schedules = []model.Schedule{}
+		}
+
+		return c.JSON(schedules)
+	})
+
+	// Get the availability of the caller on a date (default today)
+	app.Get("/me/availability", func(c *fiber.Ctx) error {
+		employee, err := currentEmployee(c)
+		if employee == nil {
+			return err
+		}
+
+		dateStr := c.Query("date")
+		if dateStr == "" {
+			dateStr = time.Now().UTC().Format("2006-01-02")
+		}
+		date, err := validator.ValidateAndParseAvailabilityDate(dateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if err := validator.ValidateAvailabilityDateRange(date); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		availability, err := service.NewAvailabilityService().GetEmployeeAvailability(employee.ID, date)
+		if err != nil {
+			if err.Error() == "no schedule found for employee on this date" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No schedule found for employee on this date"})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Internal server error"})
+		}
+
+		return c.JSON(availability)
+	})
+
+	// Get the one-time blocks of the caller, optionally within start_date and end_date
+	app.Get("/me/blocks", func(c *fiber.Ctx) error {
+		employee, err := currentEmployee(c)
+		if employee == nil {
+			return err
+		}
+
+		var startDate, endDate *time.Time
+		if startDateStr := c.Query("start_date"); startDateStr != "" {
+			parsedDate, err := time.Parse("2006-01-02", startDateStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid start_date format, use YYYY-MM-DD"})
+			}
+			startDate = &parsedDate
+		}
+		if endDateStr := c.Query("end_date"); endDateStr != "" {
+			parsedDate, err := time.Parse("2006-01-02", endDateStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid end_date format, use YYYY-MM-DD"})
+			}
+			// Inclusive end date
+			parsedDate = parsedDate.Add(24*time.Hour - time.Nanosecond)
+			endDate = &parsedDate
+		}
+
+		blocks, err := repository.GetFilteredOnetimeBlocks(&employee.ID, startDate, endDate)
+		if err != nil {
+			utils.Error("Failed to get one-time blocks: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get one-time blocks"})
+		}
+		if blocks == nil {
+			blocks = []model.OnetimeBlock{}
+		}
+
+		return c.JSON(blocks)
+	})
+}
+
+// currentEmployee resolves the employee linked to the authenticated user.
+// When there is none it writes the error response and returns a nil employee.
+func currentEmployee(c *fiber.Ctx) (*model.Employee, error) {
+	userIDStr := currentUserID(c)
+	if userIDStr == "" {
+		return nil, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authenticated user required"})
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authenticated user required"})
+	}
+
+	employee, err := repository.GetEmployeeByUserID(userID)
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "no employee is linked to this user"})
+	}
+	return &employee, nil
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"services/shared/utils"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupShiftSwapRoutes configures the routes for the shift swap workflow (offer, accept, approve).
+func SetupShiftSwapRoutes(app *fiber.App) {
+	// Offer a dated shift for swapping
+	app.Post("/shift-swaps", func(c *fiber.Ctx) error {
+		// 1. Parse input
+		var input validator.ShiftSwapOfferInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for shift swap"})
+		}
+
+		// 2. Validate required fields
+		if err := validator.ValidateShiftSwapOfferRequiredFields(input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		employeeID, err := uuid.Parse(input.EmployeeID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+		}
+		if ok, err := checkActingEmployee(c, employeeID); !ok {
+			return err
+		}
+		shiftDate, err := validator.ValidateAndParseShiftDate(input.ShiftDate)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Resolve the shift and build the offer
+		swap, err := service.BuildShiftSwapOffer(employeeID, shiftDate, strings.TrimSpace(input.Note), currentUserID(c))
+		if err != nil {
+			return respondWithShiftSwapError(c, err)
+		}
+
+		// 4. Save to database
+		if err := repository.CreateShiftSwap(swap); err != nil {
+			utils.Error("Failed to create shift swap: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create shift swap"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(swap)
+	})
+
+	// Get shift swaps with optional filtering by employee and status
+	app.Get("/shift-swaps", func(c *fiber.Ctx) error {
+		var employeeID *uuid.UUID
+		if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+			parsedID, err := uuid.Parse(employeeIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+			}
+			employeeID = &parsedID
+		}
+
+		managedIDs, ok, err := managedEmployeeScope(c)
+		if !ok {
+			return err
+		}
+
+		status := c.Query("status")
+		if err := validator.ValidateShiftSwapStatus(status); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		swaps, err := repository.GetFilteredShiftSwaps(employeeID, managedIDs, status)
+		if err != nil {
+			utils.Error("Failed to get shift swaps: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get shift swaps"})
+		}
+		if swaps == nil {
+			swaps = []model.ShiftSwap{}
+		}
+
+		return c.JSON(swaps)
+	})
+
+	// Get shift swap by ID
+	app.Get("/shift-swaps/:id", func(c *fiber.Ctx) error {
+		swapID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid shift swap ID format"})
+		}
+
+		swap, err := repository.GetShiftSwapByID(swapID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "shift swap not found"})
+		}
+		involved := []uuid.UUID{swap.OfferingEmployeeID}
+		if swap.AcceptingEmployeeID != nil {
+			involved = append(involved, *swap.AcceptingEmployeeID)
+		}
+		if ok, err := checkManagedRecord(c, involved...); !ok {
+			return err
+		}
+
+		return c.JSON(swap)
+	})
+
+	// Accept an offered shift
+	app.Post("/shift-swaps/:id/accept", func(c *fiber.Ctx) error {
+		// 1. Parse and validate swap ID
+		swapID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid shift swap ID format"})
+		}
+		swap, err := repository.GetShiftSwapByID(swapID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "shift swap not found"})
+		}
+
+		// 2. Parse accepting employee
+		var input validator.ShiftSwapAcceptInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for shift swap"})
+		}
+		if input.EmployeeID == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "employee_id is required to accept a shift swap"})
+		}
+		employeeID, err := uuid.Parse(input.EmployeeID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+		}
+		if ok, err := checkActingEmployee(c, employeeID); !ok {
+			return err
+		}
+
+		// 3. Accept after checking the employee can work the shift
+		updated, err := service.AcceptShiftSwap(swap, employeeID)
+		if err != nil {
+			return respondWithShiftSwapError(c, err)
+		}
+
+		return c.JSON(updated)
+	})
+
+	// Approve an accepted swap, creating the one-time schedule and block
+	app.Post("/shift-swaps/:id/approve", func(c *fiber.Ctx) error {
+		return decideShiftSwap(c, service.ApproveShiftSwap, false)
+	})
+
+	// Reject an open swap
+	app.Post("/shift-swaps/:id/reject", func(c *fiber.Ctx) error {
+		return decideShiftSwap(c, service.RejectShiftSwap, false)
+	})
+
+	// Cancel an open swap
+	app.Post("/shift-swaps/:id/cancel", func(c *fiber.Ctx) error {
+		return decideShiftSwap(c, service.CancelShiftSwap, true)
+	})
+}
+
+// decideShiftSwap runs a manager decision on a shift swap on behalf of the current user.
+// allowSelf lets the offering employee withdraw their own offer.
+func decideShiftSwap(c *fiber.Ctx, decide func(model.ShiftSwap, string, string) (*model.ShiftSwap, error), allowSelf bool) error {
+	// 1. The acting user is recorded on every decision
+	userID := currentUserID(c)
+	if userID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authenticated user required"})
+	}
+
+	// 2. Parse and validate swap ID
+	swapID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid shift swap ID format"})
+	}
+	swap, err := repository.GetShiftSwapByID(swapID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "shift swap not found"})
+	}
+
+	// 3. Managers can only decide on swaps between employees they manage
+	involved := []uuid.UUID{swap.OfferingEmployeeID}
+	if !allowSelf && swap.AcceptingEmployeeID != nil {
+		involved = append(involved, *swap.AcceptingEmployeeID)
+	}
+	if err := checkManagerScope(c, allowSelf, involved...); err != nil {
+		return respondWithScopeError(c, err)
+	}
+
+	// 4. Parse optional decision note
+	note, err := parseDecisionNote(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for shift swap decision"})
+	}
+
+	// 5. Apply the decision
+	updated, err := decide(swap, userID, note)
+	if err != nil {
+		return respondWithShiftSwapError(c, err)
+	}
+
+	return c.JSON(updated)
+}
+
+// respondWithShiftSwapError maps shift swap service errors to HTTP responses
+func respondWithShiftSwapError(c *fiber.Ctx, err error) error {
+	if _, ok := err.(*service.ShiftSwapCheckError); ok {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	}
+	if _, ok := err.(*service.ShiftSwapStatusError); ok {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err.Error() == "employee not found" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update shift swap"})
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupSkillRoutes configures the routes for employee skills, role requirements and certification alerts.
+func SetupSkillRoutes(app *fiber.App) {
+	// Get the skills of an employee
+	app.Get("/employees/:id/skills", func(c *fiber.Ctx) error {
+		employeeID, err := validator.ValidateEmployeeExists(c.Params("id"))
+		if err != nil {
+			return respondWithEmployeeLookupError(c, err)
+		}
+
+		skills, err := repository.GetEmployeeSkills(employeeID)
+		if err != nil {
+			utils.Error("Failed to get employee skills: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get skills"})
+		}
+
+		return c.JSON(skills)
+	})
+
+	// Add a skill or certification to an employee
+	app.Post("/employees/:id/skills", func(c *fiber.Ctx) error {
+		// 1. Validate employee
+		employeeID, err := validator.ValidateEmployeeExists(c.Params("id"))
+		if err != nil {
+			return respondWithEmployeeLookupError(c, err)
+		}
+
+		// 2. Parse and validate input
+		var input validator.EmployeeSkillInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for skill"})
+		}
+		skill := &model.EmployeeSkill{EmployeeID: employeeID}
+		if err := applyEmployeeSkillInput(skill, input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Check for duplicates
+		duplicate, err := repository.CheckDuplicateEmployeeSkill(employeeID, skill.Name, nil)
+		if err != nil {
+			utils.Error("Failed to check for duplicate skills: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create skill"})
+		}
+		if duplicate {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": (&service.DuplicateSkillError{Name: skill.Name}).Error()})
+		}
+
+		// 4. Save to database
+		if err := repository.CreateEmployeeSkill(skill); err != nil {
+			utils.Error("Failed to create skill: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create skill"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(skill)
+	})
+
+	// Update a skill of an employee
+	app.Put("/employees/:id/skills/:skillId", func(c *fiber.Ctx) error {
+		// 1. Find skill
+		skill, err := findEmployeeSkill(c)
+		if skill == nil {
+			return err
+		}
+
+		// 2. Parse and validate input
+		var input validator.EmployeeSkillInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for skill"})
+		}
+		if err := applyEmployeeSkillInput(skill, input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Check for duplicates when renamed
+		duplicate, err := repository.CheckDuplicateEmployeeSkill(skill.EmployeeID, skill.Name, &skill.ID)
+		if err != nil {
+			utils.Error("Failed to check for duplicate skills: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update skill"})
+		}
+		if duplicate {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": (&service.DuplicateSkillError{Name: skill.Name}).Error()})
+		}
+
+		// 4. Save to database
+		if err := repository.UpdateEmployeeSkill(skill); err != nil {
+			utils.Error("Failed to update skill: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update skill"})
+		}
+
+		return c.JSON(skill)
+	})
+
+	// Remove a skill from an employee
+	app.Delete("/employees/:id/skills/:skillId", func(c *fiber.Ctx) error {
+		skill, err := findEmployeeSkill(c)
+		if skill == nil {
+			return err
+		}
+
+		if err := repository.DeleteEmployeeSkill(skill.ID); err != nil {
+			utils.Error("Failed to delete skill: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete skill"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+
+	// Check an employee against the requirements of their role
+	app.Get("/employees/:id/role-check", func(c *fiber.Ctx) error {
+		employeeID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+		}
+		employee, err := repository.GetEmployeeByID(employeeID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "employee not found"})
+		}
+
+		policy := service.LoadCertificationPolicy()
+		problems, err := service.CheckRoleRequirements(employee, time.Now().UTC().Truncate(24*time.Hour), policy)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check role requirements"})
+		}
+		if problems == nil {
+			problems = []string{}
+		}
+
+		return c.JSON(fiber.Map{
+			"employee_id": employee.ID,
+			"role":        employee.Role,
+			"enforcement": policy.Enforcement,
+			"problems":    problems,
+		})
+	})
+
+	// Add a skill requirement to a role
+	app.Post("/role-requirements", func(c *fiber.Ctx) error {
+		var input validator.RoleRequirementInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for role requirement"})
+		}
+
+		role := strings.TrimSpace(input.Role)
+		skillName := validator.NormalizeSkillName(input.SkillName)
+		if role == "" || skillName == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "role and skill_name are required for role requirement"})
+		}
+		minLevel, err := validator.ValidateSkillLevel(input.MinLevel)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		duplicate, err := repository.CheckDuplicateRoleRequirement(role, skillName)
+		if err != nil {
+			utils.Error("Failed to check for duplicate role requirements: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create role requirement"})
+		}
+		if duplicate {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "role already requires this skill"})
+		}
+
+		requirement := &model.RoleRequirement{Role: role, SkillName: skillName, MinLevel: minLevel}
+		if err := repository.CreateRoleRequirement(requirement); err != nil {
+			utils.Error("Failed to create role requirement: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create role requirement"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(requirement)
+	})
+
+	// Get role requirements, optionally for one role
+	app.Get("/role-requirements", func(c *fiber.Ctx) error {
+		requirements, err := repository.GetRoleRequirements(strings.TrimSpace(c.Query("role")))
+		if err != nil {
+			utils.Error("Failed to get role requirements: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get role requirements"})
+		}
+		return c.JSON(requirements)
+	})
+
+	// Delete role requirement
+	app.Delete("/role-requirements/:id", func(c *fiber.Ctx) error {
+		requirementID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid role requirement ID format"})
+		}
+
+		if _, err := repository.GetRoleRequirementByID(requirementID); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "role requirement not found"})
+		}
+
+		if err := repository.DeleteRoleRequirement(requirementID); err != nil {
+			utils.Error("Failed to delete role requirement: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete role requirement"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+
+	// Get certifications that have expired or expire soon
+	app.Get("/certifications/expiring", func(c *fiber.Ctx) error {
+		withinDays, err := validator.ValidateWithinDays(c.Query("within_days"), service.LoadCertificationPolicy().WarningDays)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		alerts, err := service.GetCertificationAlerts(withinDays)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get expiring certifications"})
+		}
+
+		return c.JSON(alerts)
+	})
+}
+
+// applyEmployeeSkillInput validates the input and copies it onto the skill
+func applyEmployeeSkillInput(skill *model.EmployeeSkill, input validator.EmployeeSkillInput) error {
+	name := validator.NormalizeSkillName(input.Name)
+	if name == "" {
+		return fmt.Errorf("name is required for skill")
+	}
+	level, err := validator.ValidateSkillLevel(input.Level)
+	if err != nil {
+		return err
+	}
+	issuedOn, expiresOn, err := validator.ValidateAndParseSkillDates(input)
+	if err != nil {
+		return err
+	}
+
+	skill.Name = name
+	skill.Level = level
+	skill.IsCertification = input.IsCertification
+	skill.IssuedOn = issuedOn
+	skill.ExpiresOn = expiresOn
+	skill.Notes = input.Notes
+	return nil
+}
+
+// findEmployeeSkill parses the employee and skill ID route parameters and loads the skill.
+// When the skill cannot be loaded it writes the error response and returns a nil skill.
+func findEmployeeSkill(c *fiber.Ctx) (*model.EmployeeSkill, error) {
+	employeeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+	}
+	skillID, err := uuid.Parse(c.Params("skillId"))
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid skill ID format"})
+	}
+
+	skill, err := repository.GetEmployeeSkillByID(employeeID, skillID)
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "skill not found"})
+	}
+	return &skill, nil
+}
+
+// respondWithEmployeeLookupError maps employee validation errors to HTTP responses
+func respondWithEmployeeLookupError(c *fiber.Ctx, err error) error {
+	if err.Error() == "employee not found" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+}
+
+// respondWithScheduleCheckError maps employment and certification check errors to HTTP responses
+func respondWithScheduleCheckError(c *fiber.Ctx, err error) error {
+	if _, ok := err.(*service.CertificationError); ok {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	}
+	if _, ok := err.(*service.EmploymentError); ok {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err.Error() == "employee not found" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+}
@@ -0,0 +1,390 @@
+package handler
+
+import (
+	"strings"
+
+	"services/shared/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupTeamRoutes configures the routes for teams, managers and reporting lines.
+func SetupTeamRoutes(app *fiber.App) {
+	// Create a new team
+	app.Post("/teams", func(c *fiber.Ctx) error {
+		// 1. Parse input
+		var input validator.TeamInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for team"})
+		}
+
+		// 2. Build team model
+		team := model.Team{}
+		if err := applyTeamInput(&team, input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Check for duplicates and the hierarchy
+		if duplicate, err := repository.CheckDuplicateTeam(team.Name, nil); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		} else if duplicate {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "a team with this name already exists"})
+		}
+		if err := service.CheckTeamHierarchy(team); err != nil {
+			return respondWithHierarchyError(c, err)
+		}
+
+		// 4. Save to database
+		if err := repository.CreateTeam(&team); err != nil {
+			utils.Error("Failed to create team: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create team"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(team)
+	})
+
+	// Get all teams
+	app.Get("/teams", func(c *fiber.Ctx) error {
+		teams, err := repository.GetAllTeams()
+		if err != nil {
+			utils.Error("Failed to get teams: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get teams"})
+		}
+
+		return c.JSON(teams)
+	})
+
+	// Get team by ID
+	app.Get("/teams/:id", func(c *fiber.Ctx) error {
+		team, err := findTeam(c)
+		if team == nil {
+			return err
+		}
+
+		return c.JSON(team)
+	})
+
+	// Update team
+	app.Put("/teams/:id", func(c *fiber.Ctx) error {
+		// 1. Find team
+		team, err := findTeam(c)
+		if team == nil {
+			return err
+		}
+
+		// 2. Parse input
+		var input validator.TeamInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for team"})
+		}
+		if strings.TrimSpace(input.Name) == "" {
+			input.Name = team.Name
+		}
+		if err := applyTeamInput(team, input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Check for duplicates and the hierarchy
+		if duplicate, err := repository.CheckDuplicateTeam(team.Name, &team.ID); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		} else if duplicate {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "a team with this name already exists"})
+		}
+		if err := service.CheckTeamHierarchy(*team); err != nil {
+			return respondWithHierarchyError(c, err)
+		}
+
+		// 4. Save changes
+		if err := repository.UpdateTeam(team); err != nil {
+			utils.Error("Failed to update team: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update team"})
+		}
+
+		return c.JSON(team)
+	})
+
+	// Delete team, moving its sub-teams and members up to its parent
+	app.Delete("/teams/:id", func(c *fiber.Ctx) error {
+		team, err := findTeam(c)
+		if team == nil {
+			return err
+		}
+
+		if err := repository.DeleteTeam(*team); err != nil {
+			utils.Error("Failed to delete team: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete team"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+
+	// Get the members of a team, including the members of its sub-teams with ?include_subteams=true
+	app.Get("/teams/:id/members", func(c *fiber.Ctx) error {
+		team, err := findTeam(c)
+		if team == nil {
+			return err
+		}
+
+		members, err := service.GetTeamMembers(*team, c.QueryBool("include_subteams"))
+		if err != nil {
+			utils.Error("Failed to get team members: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get team members"})
+		}
+
+		return c.JSON(members)
+	})
+
+	// Move an employee into a team
+	app.Put("/employees/:id/team", func(c *fiber.Ctx) error {
+		// 1. Find employee
+		employee, err := findHierarchyEmployee(c)
+		if employee == nil {
+			return err
+		}
+
+		// 2. Parse team ID
+		var input validator.TeamAssignmentInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for team assignment"})
+		}
+		teamID, err := validator.ValidateOptionalID(input.TeamID, "team ID")
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Save team
+		updated, err := service.AssignTeam(*employee, teamID)
+		if err != nil {
+			return respondWithHierarchyError(c, err)
+		}
+
+		return c.JSON(updated)
+	})
+
+	// Set the manager of an employee
+	app.Put("/employees/:id/manager", func(c *fiber.Ctx) error {
+		// 1. Find employee
+		employee, err := findHierarchyEmployee(c)
+		if employee == nil {
+			return err
+		}
+
+		// 2. Parse manager ID
+		var input validator.ManagerAssignmentInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for manager assignment"})
+		}
+		managerID, err := validator.ValidateOptionalID(input.ManagerID, "manager ID")
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Save manager
+		updated, err := service.AssignManager(*employee, managerID)
+		if err != nil {
+			return respondWithHierarchyError(c, err)
+		}
+
+		return c.JSON(updated)
+	})
+
+	// Get the reports of a manager, including indirect reports with ?indirect=true
+	app.Get("/employees/:id/reports", func(c *fiber.Ctx) error {
+		employee, err := findHierarchyEmployee(c)
+		if employee == nil {
+			return err
+		}
+
+		reports, err := service.GetReports(employee.ID, c.QueryBool("indirect"))
+		if err != nil {
+			utils.Error("Failed to get reports: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get reports"})
+		}
+
+		return c.JSON(reports)
+	})
+
+	// Get the reports of the caller, including indirect reports with ?indirect=true
+	app.Get("/me/reports", func(c *fiber.Ctx) error {
+		employee, err := currentEmployee(c)
+		if employee == nil {
+			return err
+		}
+
+		reports, err := service.GetReports(employee.ID, c.QueryBool("indirect"))
+		if err != nil {
+			utils.Error("Failed to get reports: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get reports"})
+		}
+
+		return c.JSON(reports)
+	})
+}
+
+// applyTeamInput validates a team input and copies it onto the team
+func applyTeamInput(team *model.Team, input validator.TeamInput) error {
+	name, err := validator.ValidateTeamName(input.Name)
+	if err != nil {
+		return err
+	}
+	team.Name = name
+	if input.Description != nil {
+		team.Description = strings.TrimSpace(*input.Description)
+	}
+	if input.ParentID != nil {
+		parentID, err := validator.ValidateOptionalID(*input.ParentID, "parent team ID")
+		if err != nil {
+			return err
+		}
+		team.ParentID = parentID
+	}
+	if input.LeadID != nil {
+		leadID, err := validator.ValidateOptionalID(*input.LeadID, "lead ID")
+		if err != nil {
+			return err
+		}
+		team.LeadID = leadID
+	}
+	return nil
+}
+
+// findTeam parses the team ID route parameter and loads the team.
+// When the team cannot be loaded it writes the error response and returns a nil team.
+func findTeam(c *fiber.Ctx) (*model.Team, error) {
+	teamID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid team ID format"})
+	}
+
+	team, err := repository.GetTeamByID(teamID)
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "team not found"})
+	}
+	return &team, nil
+}
+
+// findHierarchyEmployee parses the employee ID route parameter and loads the employee.
+// When the employee cannot be loaded it writes the error response and returns a nil employee.
+func findHierarchyEmployee(c *fiber.Ctx) (*model.Employee, error) {
+	employeeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID"})
+	}
+
+	employee, err := repository.GetEmployeeByID(employeeID)
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "employee not found"})
+	}
+	return &employee, nil
+}
+
+// managedEmployeeScope returns the IDs of the employees a list is restricted to, or nil when it is not.
+// Managers only see themselves and the employees they manage. The managed_by filter ("me" or an
+// employee ID) narrows a list down to the employees that manager manages. When the scope cannot be
+// resolved it writes the error response and returns ok false.
+func managedEmployeeScope(c *fiber.Ctx) (ids []uuid.UUID, ok bool, err error) {
+	given, managerID, err := validator.ValidateManagedBy(c.Query("managed_by"))
+	if err != nil {
+		return nil, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	isManager := currentUserRole(c) == roleManager
+	var caller *model.Employee
+	if isManager || given && managerID == nil {
+		caller, err = currentEmployee(c)
+		if caller == nil {
+			return nil, false, err
+		}
+	}
+
+	if given {
+		if managerID == nil {
+			managerID = &caller.ID
+		}
+		ids, err = repository.GetManagedEmployeeIDs(*managerID)
+		if err != nil {
+			utils.Error("Failed to get managed employees: " + err.Error())
+			return nil, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+	}
+	if !isManager {
+		return ids, true, nil
+	}
+
+	own, err := repository.GetManagedEmployeeIDs(caller.ID)
+	if err != nil {
+		utils.Error("Failed to get managed employees: " + err.Error())
+		return nil, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+	}
+	own = append(own, caller.ID)
+	if ids == nil {
+		return own, true, nil
+	}
+	return intersectIDs(ids, own), true, nil
+}
+
+// checkManagedRecord restricts reading a single record to what managedEmployeeScope lets a list show:
+// managers only see records of themselves and the employees they manage, other roles are not
+// restricted. The record is visible when any of employeeIDs is in scope. When it is not, it writes
+// the error response and returns false.
+func checkManagedRecord(c *fiber.Ctx, employeeIDs ...uuid.UUID) (bool, error) {
+	if currentUserRole(c) != roleManager {
+		return true, nil
+	}
+	var err error
+	for _, employeeID := range employeeIDs {
+		if err = checkManagerScope(c, true, employeeID); err == nil {
+			return true, nil
+		}
+	}
+	return false, respondWithScopeError(c, err)
+}
+
+// intersectIDs returns the IDs of a that are also in b
+func intersectIDs(a, b []uuid.UUID) []uuid.UUID {
+	inB := make(map[uuid.UUID]bool, len(b))
+	for _, id := range b {
+		inB[id] = true
+	}
+	both := []uuid.UUID{}
+	for _, id := range a {
+		if inB[id] {
+			both = append(both, id)
+		}
+	}
+	return both
+}
+
+// checkManagerScope checks that the caller may act on the given employees, see service.CheckManagerScope.
+// Owners are not limited by the hierarchy.
+func checkManagerScope(c *fiber.Ctx, allowSelf bool, employeeIDs ...uuid.UUID) error {
+	if currentUserRole(c) == roleOwner {
+		return nil
+	}
+	return service.CheckManagerScope(currentUserID(c), allowSelf, employeeIDs...)
+}
+
+// respondWithHierarchyError maps team and reporting line service errors to HTTP responses
+func respondWithHierarchyError(c *fiber.Ctx, err error) error {
+	if _, ok := err.(*service.HierarchyError); ok {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	}
+	switch err.Error() {
+	case "team not found", "parent team not found", "manager not found", "lead not found":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+}
+
+// respondWithScopeError maps a failed manager scope check to an HTTP response
+func respondWithScopeError(c *fiber.Ctx, err error) error {
+	if _, ok := err.(*service.ScopeError); ok {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"strings"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupTimeEntryRoutes configures the routes for the time clock and the schedule variance report.
+func SetupTimeEntryRoutes(app *fiber.App) {
+	// Clock in
+	app.Post("/time-entries/clock-in", func(c *fiber.Ctx) error {
+		employeeID, at, input, problem := parsePunch(c)
+		if problem != "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": problem})
+		}
+		if ok, err := checkActingEmployee(c, employeeID); !ok {
+			return err
+		}
+
+		entry, err := service.ClockIn(employeeID, at, strings.TrimSpace(input.Notes), currentUserID(c))
+		if err != nil {
+			return respondWithTimeClockError(c, err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(entry)
+	})
+
+	// Clock out
+	app.Post("/time-entries/clock-out", func(c *fiber.Ctx) error {
+		return punch(c, service.ClockOut)
+	})
+
+	// Start a break
+	app.Post("/time-entries/break-start", func(c *fiber.Ctx) error {
+		return punch(c, service.StartBreak)
+	})
+
+	// End a break
+	app.Post("/time-entries/break-end", func(c *fiber.Ctx) error {
+		return punch(c, service.EndBreak)
+	})
+
+	// Get time entries clocked in during a period, optionally for one employee
+	app.Get("/time-entries", func(c *fiber.Ctx) error {
+		var employeeID *uuid.UUID
+		if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+			parsedID, err := uuid.Parse(employeeIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+			}
+			employeeID = &parsedID
+		}
+
+		startDateStr := c.Query("start_date")
+		if startDateStr == "" {
+			startDateStr = time.Now().UTC().Format("2006-01-02")
+		}
+		startDate, endDate, err := validator.ValidateAndParseReportPeriod(startDateStr, c.Query("end_date"), 93)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		entries, err := repository.GetTimeEntriesForPeriod(employeeID, startDate, endDate.AddDate(0, 0, 1))
+		if err != nil {
+			utils.Error("Failed to get time entries: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get time entries"})
+		}
+
+		return c.JSON(entries)
+	})
+
+	// Compare time entries with the schedule and breaks of an employee
+	app.Get("/time-entries/variance", func(c *fiber.Ctx) error {
+		// 1. Parse employee ID
+		employeeIDStr := c.Query("employee_id")
+		if employeeIDStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "employee_id is required"})
+		}
+		employeeID, err := uuid.Parse(employeeIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+		}
+
+		// 2. Parse period and tolerance
+		startDate, endDate, err := validator.ValidateAndParseReportPeriod(c.Query("start_date"), c.Query("end_date"), 31)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		graceMinutes, err := validator.ValidateGraceMinutes(c.Query("grace_minutes"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Build the report
+		report, err := service.NewAvailabilityService().GetVarianceReport(employeeID, startDate, endDate, graceMinutes)
+		if err != nil {
+			if err.Error() == "employee not found" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to build variance report"})
+		}
+
+		return c.JSON(report)
+	})
+
+	// Record a missed time entry for an employee (managers only)
+	app.Post("/time-entries", func(c *fiber.Ctx) error {
+		if !isManagerRole(c) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "only managers can record time entries"})
+		}
+
+		var input validator.TimeEntryCorrectionInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for time entry"})
+		}
+		employeeID, err := uuid.Parse(input.EmployeeID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+		}
+		if ok, err := checkActingEmployee(c, employeeID); !ok {
+			return err
+		}
+		if input.ClockIn == "" || input.ClockOut == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "clock_in and clock_out are required"})
+		}
+		reason := strings.TrimSpace(input.Reason)
+		if reason == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "reason is required"})
+		}
+		clockIn, clockOut, err := validator.ValidateAndParseCorrectionTimes(input.ClockIn, input.ClockOut)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		entry, err := service.RecordTimeEntry(employeeID, *clockIn, *clockOut, strings.TrimSpace(input.Notes), reason, currentUserID(c))
+		if err != nil {
+			return respondWithTimeClockError(c, err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(entry)
+	})
+
+	// Correct the clock-in or clock-out time of an entry (managers only)
+	app.Put("/time-entries/:id", func(c *fiber.Ctx) error {
+		if !isManagerRole(c) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "only managers can correct time entries"})
+		}
+
+		entryID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid time entry ID format"})
+		}
+		existing, err := repository.GetTimeEntryByID(entryID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "time entry not found"})
+		}
+		if ok, err := checkActingEmployee(c, existing.EmployeeID); !ok {
+			return err
+		}
+
+		var input validator.TimeEntryCorrectionInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for time entry"})
+		}
+		if input.ClockIn == "" && input.ClockOut == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "clock_in or clock_out is required"})
+		}
+		reason := strings.TrimSpace(input.Reason)
+		if reason == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "reason is required"})
+		}
+		clockIn, clockOut, err := validator.ValidateAndParseCorrectionTimes(input.ClockIn, input.ClockOut)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		var notes *string
+		if input.Notes != "" {
+			trimmed := strings.TrimSpace(input.Notes)
+			notes = &trimmed
+		}
+
+		entry, err := service.CorrectTimeEntry(entryID, clockIn, clockOut, notes, reason, currentUserID(c))
+		if err != nil {
+			if err.Error() == "time entry not found" {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+			}
+			return respondWithTimeClockError(c, err)
+		}
+
+		return c.JSON(entry)
+	})
+
+	// Get time entry by ID
+	app.Get("/time-entries/:id", func(c *fiber.Ctx) error {
+		entryID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid time entry ID format"})
+		}
+
+		entry, err := repository.GetTimeEntryByID(entryID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "time entry not found"})
+		}
+
+		return c.JSON(entry)
+	})
+}
+
+// punch handles a clock-out or break punch for an employee
+func punch(c *fiber.Ctx, apply func(uuid.UUID, time.Time) (*model.TimeEntry, error)) error {
+	employeeID, at, _, problem := parsePunch(c)
+	if problem != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": problem})
+	}
+	if ok, err := checkActingEmployee(c, employeeID); !ok {
+		return err
+	}
+
+	entry, err := apply(employeeID, at)
+	if err != nil {
+		return respondWithTimeClockError(c, err)
+	}
+
+	return c.JSON(entry)
+}
+
+// parsePunch parses the employee and time of a punch.
+// It returns a message describing the problem when the input is invalid.
+func parsePunch(c *fiber.Ctx) (uuid.UUID, time.Time, validator.TimeClockInput, string) {
+	var input validator.TimeClockInput
+	if err := c.BodyParser(&input); err != nil {
+		return uuid.Nil, time.Time{}, input, "invalid input for time clock"
+	}
+	if input.EmployeeID == "" {
+		return uuid.Nil, time.Time{}, input, "employee_id is required"
+	}
+	employeeID, err := uuid.Parse(input.EmployeeID)
+	if err != nil {
+		return uuid.Nil, time.Time{}, input, "invalid employee ID format"
+	}
+	at, err := validator.ValidateAndParsePunchTime(input.Time)
+	if err != nil {
+		return uuid.Nil, time.Time{}, input, err.Error()
+	}
+	return employeeID, at, input, ""
+}
+
+// respondWithTimeClockError maps time clock service errors to HTTP responses
+func respondWithTimeClockError(c *fiber.Ctx, err error) error {
+	if _, ok := err.(*service.TimeClockError); ok {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err.Error() == "employee not found" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// SetupTimesheetRoutes configures the routes for holidays, weekly timesheets and the payroll export.
+func SetupTimesheetRoutes(app *fiber.App) {
+	// Create a new holiday
+	app.Post("/holidays", func(c *fiber.Ctx) error {
+		var input validator.HolidayInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for holiday"})
+		}
+
+		date, err := validator.ValidateHolidayInput(input)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		duplicate, err := repository.CheckDuplicateHoliday(date)
+		if err != nil {
+			utils.Error("Failed to check for duplicate holidays: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create holiday"})
+		}
+		if duplicate {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "a holiday already exists on this date"})
+		}
+
+		holiday := &model.Holiday{Date: date, Name: strings.TrimSpace(input.Name)}
+		if err := repository.CreateHoliday(holiday); err != nil {
+			utils.Error("Failed to create holiday: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create holiday"})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(holiday)
+	})
+
+	// Get holidays, optionally for one year
+	app.Get("/holidays", func(c *fiber.Ctx) error {
+		year, err := validator.ValidateYear(c.Query("year"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		var start, end time.Time
+		if year != nil {
+			start = time.Date(*year, 1, 1, 0, 0, 0, 0, time.UTC)
+			end = start.AddDate(1, 0, 0)
+		}
+
+		holidays, err := repository.GetHolidays(start, end)
+		if err != nil {
+			utils.Error("Failed to get holidays: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get holidays"})
+		}
+
+		return c.JSON(holidays)
+	})
+
+	// Delete holiday
+	app.Delete("/holidays/:id", func(c *fiber.Ctx) error {
+		holidayID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid holiday ID format"})
+		}
+
+		if _, err := repository.GetHolidayByID(holidayID); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "holiday not found"})
+		}
+
+		if err := repository.DeleteHoliday(holidayID); err != nil {
+			utils.Error("Failed to delete holiday: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete holiday"})
+		}
+
+		return c.Status(204).Send(nil)
+	})
+
+	// Get the timesheet of an employee for a week; approved weeks return the stored snapshot
+	app.Get("/timesheets", func(c *fiber.Ctx) error {
+		// 1. Parse employee ID
+		employeeIDStr := c.Query("employee_id")
+		if employeeIDStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "employee_id is required"})
+		}
+		employeeID, err := uuid.Parse(employeeIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+		}
+
+		// 2. Parse week and source
+		weekStart, err := validator.ValidateAndParseWeekStart(c.Query("week_start"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		source, err := validator.ValidateTimesheetSource(c.Query("source"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Managers only see their own timesheets and those of the employees they manage
+		if currentUserRole(c) == roleManager {
+			if err := checkManagerScope(c, true, employeeID); err != nil {
+				return respondWithScopeError(c, err)
+			}
+		}
+
+		// 4. Get the timesheet
+		timesheet, err := service.NewAvailabilityService().GetTimesheet(employeeID, weekStart, source)
+		if err != nil {
+			return respondWithTimesheetError(c, err)
+		}
+
+		return c.JSON(timesheet)
+	})
+
+	// Approve the timesheet of an employee for a week, locking the week
+	app.Post("/timesheets/approve", func(c *fiber.Ctx) error {
+		// 1. The approving manager is recorded on the timesheet
+		userID := currentUserID(c)
+		if userID == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authenticated user required"})
+		}
+
+		// 2. Parse input
+		var input validator.TimesheetApprovalInput
+		if err := c.BodyParser(&input); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input for timesheet approval"})
+		}
+		if input.EmployeeID == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "employee_id is required"})
+		}
+		employeeID, err := uuid.Parse(input.EmployeeID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employee ID format"})
+		}
+		weekStart, err := validator.ValidateAndParseWeekStart(input.WeekStart)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		source, err := validator.ValidateTimesheetSource(input.Source)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// 3. Managers can only approve timesheets of the employees they manage
+		if err := checkManagerScope(c, false, employeeID); err != nil {
+			return respondWithScopeError(c, err)
+		}
+
+		// 4. Approve
+		timesheet, err := service.NewAvailabilityService().ApproveTimesheet(employeeID, weekStart, source, userID)
+		if err != nil {
+			return respondWithTimesheetError(c, err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(timesheet)
+	})
+
+	// Export the approved timesheets of a week for payroll
+	app.Get("/timesheets/export", func(c *fiber.Ctx) error {
+		weekStart, err := validator.ValidateAndParseWeekStart(c.Query("week_start"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		format, err := validator.ValidateExportFormat(c.Query("format"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		export, err := service.BuildPayrollExport(weekStart)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to build payroll export"})
+		}
+
+		if format == "json" {
+			return c.JSON(export)
+		}
+
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		if err := writer.WriteAll(service.PayrollExportCSV(export)); err != nil {
+			utils.Error("Failed to write payroll CSV: " + err.Error())
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to build payroll export"})
+		}
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="payroll-%s.csv"`, export.WeekStart))
+		return c.Send(buf.Bytes())
+	})
+
+	// Reopen an approved timesheet, unlocking its week
+	app.Post("/timesheets/:id/reopen", func(c *fiber.Ctx) error {
+		userID := currentUserID(c)
+		if userID == "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authenticated user required"})
+		}
+
+		timesheetID, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid timesheet ID format"})
+		}
+		timesheet, err := repository.GetTimesheetByID(timesheetID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "timesheet not found"})
+		}
+		if err := checkManagerScope(c, false, timesheet.EmployeeID); err != nil {
+			return respondWithScopeError(c, err)
+		}
+
+		if err := service.ReopenTimesheet(timesheet); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to reopen timesheet"})
+		}
+		utils.Info(fmt.Sprintf("Timesheet %s of employee %s reopened by %s", timesheet.ID, timesheet.EmployeeID, userID))
+
+		return c.Status(204).Send(nil)
+	})
+}
+
+// respondWithTimesheetError maps timesheet service errors to HTTP responses
+func respondWithTimesheetError(c *fiber.Ctx, err error) error {
+	if _, ok := err.(*service.TimesheetStatusError); ok {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if _, ok := err.(*service.TimesheetApprovalError); ok {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err.Error() == "employee not found" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+}
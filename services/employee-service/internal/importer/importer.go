@@ -0,0 +1,397 @@
+// Package importer creates employees in bulk from CSV and XLSX files.
+// It is shared by the import endpoint and the import command.
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/mail"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/service"
+	"github.com/salobook/services/employee-service/internal/validator"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	MaxFileBytes = 5 << 20 // Largest accepted import file
+	MaxRows      = 1000    // Largest number of employees per import
+)
+
+// Column names of an import file. Headers are matched case-insensitively with spaces treated as underscores.
+// Custom fields use a cf.<key> column and weekly schedules one column per weekday holding HH:MM-HH:MM.
+const (
+	columnFirstName    = "first_name"
+	columnLastName     = "last_name"
+	columnEmail        = "email"
+	columnRole         = "role"
+	columnIsActive     = "is_active" // Defaults to true, new staff are usually hired to work
+	columnPicture      = "picture"
+	columnLocation     = "location"            // Location name or ID of the weekly schedule
+	columnScheduleFrom = "schedule_valid_from" // First day of the weekly schedule, defaults to today
+	customFieldPrefix  = "cf."
+)
+
+// weekdayColumns maps the accepted weekday column names to their day of week
+var weekdayColumns = map[string]int{
+	"sunday": 0, "sun": 0,
+	"monday": 1, "mon": 1,
+	"tuesday": 2, "tue": 2,
+	"wednesday": 3, "wed": 3,
+	"thursday": 4, "thu": 4,
+	"friday": 5, "fri": 5,
+	"saturday": 6, "sat": 6,
+}
+
+// FileError represents an import file that cannot be read or has an unusable header.
+type FileError struct {
+	Message string
+}
+
+func (e *FileError) Error() string {
+	return e.Message
+}
+
+// ReadFile returns the rows of a CSV or XLSX import file, chosen by the file name extension.
+// Only the first sheet of a workbook is read.
+func ReadFile(filename string, data []byte) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, &FileError{Message: "invalid CSV file: " + err.Error()}
+		}
+		return rows, nil
+	case ".xlsx":
+		workbook, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, &FileError{Message: "invalid XLSX file"}
+		}
+		defer workbook.Close()
+		sheets := workbook.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, &FileError{Message: "XLSX file has no sheets"}
+		}
+		rows, err := workbook.GetRows(sheets[0])
+		if err != nil {
+			return nil, &FileError{Message: "invalid XLSX file"}
+		}
+		return rows, nil
+	}
+	return nil, &FileError{Message: "unsupported file type, must be one of: .csv, .xlsx"}
+}
+
+// ReadAll reads an import file up to MaxFileBytes
+func ReadAll(filename string, r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxFileBytes+1))
+	if err != nil {
+		return nil, &FileError{Message: "import file could not be read"}
+	}
+	if len(data) > MaxFileBytes {
+		return nil, &FileError{Message: fmt.Sprintf("import file cannot be larger than %d MB", MaxFileBytes>>20)}
+	}
+	return ReadFile(filename, data)
+}
+
+// header holds the position of every known column, -1 when absent
+type header struct {
+	columns      map[string]int
+	customFields map[string]int // By custom field key
+	weekdays     map[int]int    // By day of week
+}
+
+// parseHeader maps the header row to columns and rejects unknown and repeated columns
+func parseHeader(row []string) (*header, error) {
+	h := &header{columns: map[string]int{}, customFields: map[string]int{}, weekdays: map[int]int{}}
+	known := map[string]bool{
+		columnFirstName: true, columnLastName: true, columnEmail: true, columnRole: true,
+		columnIsActive: true, columnPicture: true, columnLocation: true, columnScheduleFrom: true,
+	}
+
+	for i, cell := range row {
+		name := strings.ToLower(strings.TrimSpace(cell))
+		name = strings.Join(strings.Fields(name), "_")
+		if name == "" {
+			continue
+		}
+
+		var seen bool
+		switch {
+		case strings.HasPrefix(name, customFieldPrefix):
+			key := strings.TrimPrefix(name, customFieldPrefix)
+			_, seen = h.customFields[key]
+			h.customFields[key] = i
+		case known[name]:
+			_, seen = h.columns[name]
+			h.columns[name] = i
+		default:
+			day, ok := weekdayColumns[name]
+			if !ok {
+				return nil, &FileError{Message: "unknown column: " + cell}
+			}
+			_, seen = h.weekdays[day]
+			h.weekdays[day] = i
+		}
+		if seen {
+			return nil, &FileError{Message: "duplicate column: " + cell}
+		}
+	}
+
+	for _, required := range []string{columnFirstName, columnLastName, columnEmail} {
+		if _, ok := h.columns[required]; !ok {
+			return nil, &FileError{Message: "missing required column: " + required}
+		}
+	}
+	return h, nil
+}
+
+// value returns the trimmed cell of a column, "" when the column or cell is missing
+func (h *header) value(row []string, column string) string {
+	index, ok := h.columns[column]
+	if !ok {
+		return ""
+	}
+	return cell(row, index)
+}
+
+// cell returns the trimmed cell at index, "" when the row is shorter
+func cell(row []string, index int) string {
+	if index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+// Import validates every data row of an import file and, unless it is a dry run, creates the valid
+// rows in one transaction. Invalid rows are reported and skipped.
+func Import(rows [][]string, dryRun bool) (*model.EmployeeImportReport, error) {
+	if len(rows) == 0 {
+		return nil, &FileError{Message: "import file is empty"}
+	}
+	h, err := parseHeader(rows[0])
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.EmployeeImportReport{DryRun: dryRun, Rows: []model.EmployeeImportRow{}}
+	var imports []model.EmployeeImport
+	var importRows []int
+	emails := make(map[string]int)
+	for i, row := range rows[1:] {
+		if isBlank(row) {
+			continue
+		}
+		report.TotalRows++
+		if report.TotalRows > MaxRows {
+			return nil, &FileError{Message: fmt.Sprintf("import file cannot have more than %d rows", MaxRows)}
+		}
+
+		result := model.EmployeeImportRow{Row: i + 2, Email: h.value(row, columnEmail)}
+		item, warnings, errs := validateRow(h, row)
+		if firstRow, ok := emails[strings.ToLower(result.Email)]; ok && result.Email != "" {
+			errs = append(errs, fmt.Sprintf("email is repeated from row %d", firstRow))
+		} else if result.Email != "" {
+			emails[strings.ToLower(result.Email)] = result.Row
+		}
+
+		result.Warnings = warnings
+		if len(errs) > 0 {
+			result.Errors = errs
+			report.InvalidRows++
+		} else {
+			result.Valid = true
+			result.Schedules = len(item.Schedules)
+			report.ValidRows++
+			imports = append(imports, *item)
+			importRows = append(importRows, len(report.Rows))
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	if dryRun || len(imports) == 0 {
+		return report, nil
+	}
+
+	if err := repository.ImportEmployees(imports); err != nil {
+		utils.Error("Failed to import employees: " + err.Error())
+		return nil, fmt.Errorf("failed to import employees")
+	}
+	for i, item := range imports {
+		employeeID := item.Employee.ID
+		report.Rows[importRows[i]].EmployeeID = &employeeID
+		report.ImportedEmployees++
+		report.ImportedSchedules += len(item.Schedules)
+	}
+	utils.Info(fmt.Sprintf("Imported %d employees with %d schedules", report.ImportedEmployees, report.ImportedSchedules))
+	return report, nil
+}
+
+// validateRow builds the employee, custom field values and schedules of a row and collects every problem
+func validateRow(h *header, row []string) (*model.EmployeeImport, []string, []string) {
+	var errs []string
+
+	// 1. Employee fields
+	employee := &model.Employee{
+		FirstName: h.value(row, columnFirstName),
+		LastName:  h.value(row, columnLastName),
+		Email:     h.value(row, columnEmail),
+		Role:      h.value(row, columnRole),
+		Picture:   h.value(row, columnPicture),
+		IsActive:  true,
+	}
+	if employee.FirstName == "" || employee.LastName == "" || employee.Email == "" {
+		errs = append(errs, "first_name, last_name, and email are required")
+	}
+	if len(employee.FirstName) > 40 || len(employee.LastName) > 40 {
+		errs = append(errs, "first_name and last_name cannot be longer than 40 characters")
+	}
+	if employee.Email != "" {
+		if _, err := mail.ParseAddress(employee.Email); err != nil || len(employee.Email) > 100 {
+			errs = append(errs, "invalid email format")
+		} else if existing, err := repository.GetEmployeeByEmail(employee.Email); err == nil {
+			if existing.DeletedAt.Valid {
+				errs = append(errs, "email belongs to a former employee, restore them instead")
+			} else {
+				errs = append(errs, "employee with this email already exists")
+			}
+		}
+	}
+	if isActive := h.value(row, columnIsActive); isActive != "" {
+		parsed, err := parseBool(isActive)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		employee.IsActive = parsed
+	}
+
+	// 2. Custom fields; required ones must be filled in
+	customFields := make(map[string]interface{}, len(h.customFields))
+	for key, index := range h.customFields {
+		if value := cell(row, index); value != "" {
+			customFields[key] = value
+		}
+	}
+	customFieldValues, _, err := service.BuildEmployeeCustomFieldValues(nil, customFields)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	// 3. Weekly schedule
+	schedules, warnings, scheduleErrs := validateSchedule(h, row, *employee)
+	errs = append(errs, scheduleErrs...)
+
+	return &model.EmployeeImport{
+		Employee:          employee,
+		CustomFieldValues: customFieldValues,
+		Schedules:         schedules,
+	}, warnings, errs
+}
+
+// validateSchedule builds the recurring schedules of the weekday columns of a row
+func validateSchedule(h *header, row []string, employee model.Employee) ([]model.Schedule, []string, []string) {
+	type shift struct {
+		day   int
+		value string
+	}
+	var shifts []shift
+	for day := 0; day < 7; day++ {
+		if index, ok := h.weekdays[day]; ok {
+			if value := cell(row, index); value != "" {
+				shifts = append(shifts, shift{day: day, value: value})
+			}
+		}
+	}
+	if len(shifts) == 0 {
+		return nil, nil, nil
+	}
+
+	var errs []string
+
+	// 1. First day and location of the schedule
+	validFromStr := h.value(row, columnScheduleFrom)
+	if validFromStr == "" {
+		validFromStr = time.Now().UTC().Format("2006-01-02")
+	}
+	validFrom, _, _, err := validator.ValidateAndParseDates(validFromStr, "")
+	if err != nil {
+		errs = append(errs, strings.Replace(err.Error(), "valid_from", columnScheduleFrom, 1))
+	}
+	locationID, err := resolveLocation(h.value(row, columnLocation))
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	// 2. One recurring schedule per weekday
+	schedules := make([]model.Schedule, 0, len(shifts))
+	for _, s := range shifts {
+		start, end, ok := strings.Cut(s.value, "-")
+		if !ok {
+			errs = append(errs, fmt.Sprintf("invalid %s shift %q, use HH:MM-HH:MM", time.Weekday(s.day), s.value))
+			continue
+		}
+		startTime, endTime, err := validator.ValidateAndNormalizeTimes(strings.TrimSpace(start), strings.TrimSpace(end))
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid %s shift: %v", time.Weekday(s.day), err))
+			continue
+		}
+		schedules = append(schedules, *service.BuildScheduleModel(uuid.Nil, s.day, startTime, endTime, validFrom, nil, "Imported", locationID))
+	}
+	if len(errs) > 0 {
+		return nil, nil, errs
+	}
+
+	// 3. Certifications required by the role
+	warnings, err := service.CheckSchedulingCertifications(employee, validFrom)
+	if err != nil {
+		return nil, nil, []string{err.Error()}
+	}
+	return schedules, warnings, nil
+}
+
+// resolveLocation resolves the location column, which holds a location name or ID
+func resolveLocation(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return validator.ValidateWorkLocation("")
+	}
+	if _, err := uuid.Parse(value); err == nil {
+		return validator.ValidateWorkLocation(value)
+	}
+	location, err := repository.GetLocationByName(value)
+	if err != nil {
+		return nil, fmt.Errorf("location not found: %s", value)
+	}
+	return validator.ValidateWorkLocation(location.ID.String())
+}
+
+// parseBool parses the common spellings of yes and no used in spreadsheets
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1":
+		return true, nil
+	case "false", "no", "n", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid is_active %q, must be true or false", value)
+}
+
+// isBlank reports whether every cell of a row is empty
+func isBlank(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -10,21 +10,28 @@ import (
 type AvailabilityRequest struct {
 	Date       string `json:"date" validate:"required"`       // ISO 8601 date format
 	EmployeeID string `json:"employee_id" validate:"required"` // UUID string
+	ResourceIDs []string `json:"resource_ids"`                  // Optional resources that must also be free (UUID strings)
+	LocationID string `json:"location_id"`                         // Optional location the employee must be scheduled at (UUID string)
 }
 
 // AvailabilityResponse represents the response structure for availability endpoint
 type AvailabilityResponse struct {
 	Date         time.Time                `json:"date"`
 	EmployeeID   uuid.UUID                `json:"employee_id"`
-	Schedule     *AvailabilitySchedule    `json:"schedule"`
+	LocationID   *uuid.UUID               `json:"location_id"`
+	Schedule     *AvailabilitySchedule    `json:"schedule"` // From the start of the first shift to the end of the last
+	Shifts       []AvailabilitySchedule   `json:"shifts"`   // One per schedule, several when the day is split between locations
 	OneTimeBlocks []AvailabilityBlock     `json:"onetimeblocks"`
 	Breaks       []AvailabilityBreak      `json:"breaks"`
+	Resources    []ResourceAvailabilityResponse `json:"resources,omitempty"`
+	FreeSlots    []AvailabilitySlot       `json:"free_slots"` // Time the employee (and every requested resource) is free
 }
 
 // AvailabilitySchedule represents the schedule information in availability response
 type AvailabilitySchedule struct {
-	StartTime time.Time `json:"start_time"` // Full ISO datetime
-	EndTime   time.Time `json:"end_time"`   // Full ISO datetime
+	StartTime  time.Time  `json:"start_time"`            // Full ISO datetime
+	EndTime    time.Time  `json:"end_time"`              // Full ISO datetime
+	LocationID *uuid.UUID `json:"location_id,omitempty"` // Set for the shifts of an availability response
 }
 
 // AvailabilityBlock represents a one-time block in availability response
@@ -32,6 +39,7 @@ type AvailabilityBlock struct {
 	StartTime time.Time `json:"start_time"` // Full ISO datetime
 	EndTime   time.Time `json:"end_time"`   // Full ISO datetime
 	Reason    string    `json:"reason"`
+	Units     int       `json:"units,omitempty"` // Units taken, set for resource blocks
 }
 
 // AvailabilityBreak represents a break in availability response
@@ -41,6 +49,12 @@ type AvailabilityBreak struct {
 	Reason    string    `json:"reason"`
 }
 
+// AvailabilitySlot represents a free time window in availability response
+type AvailabilitySlot struct {
+	StartTime time.Time `json:"start_time"` // Full ISO datetime
+	EndTime   time.Time `json:"end_time"`   // Full ISO datetime
+}
+
 // TimeRange represents a simple time range for internal calculations
 type TimeRange struct {
 	Start time.Time
@@ -74,4 +88,52 @@ func (tr TimeRange) GetIntersection(other TimeRange) *TimeRange {
 // IsValid checks if the time range is valid (start before end)
 func (tr TimeRange) IsValid() bool {
 	return tr.Start.Before(tr.End)
-} 
\ No newline at end of file
+}
+
+// ChainAvailabilityRequest represents the request structure for the chained availability search.
+// Legs are searched in order and each leg starts right after the previous one (plus its gap).
+type ChainAvailabilityRequest struct {
+	Date        string            `json:"date" validate:"required"` // ISO 8601 date format
+	Legs        []ChainLegRequest `json:"legs" validate:"required"`
+	StepMinutes int               `json:"step_minutes"` // Granularity of candidate start times (default 15)
+	MaxResults  int               `json:"max_results"`  // Maximum number of sequences returned (default 20)
+	LocationID  string            `json:"location_id"`  // Optional location every leg must be served at
+}
+
+// ChainLegRequest represents a single leg (service) of a chained availability search
+type ChainLegRequest struct {
+	DurationMinutes int      `json:"duration_minutes" validate:"required"`
+	GapAfterMinutes int      `json:"gap_after_minutes"` // Processing gap before the next leg may start
+	EmployeeIDs     []string `json:"employee_ids"`      // Preferred employees, tried before everybody else
+	ResourceIDs     []string `json:"resource_ids"`      // Resources that must be free during the leg
+}
+
+// ChainAvailabilityResponse represents the response structure for the chained availability search
+type ChainAvailabilityResponse struct {
+	Date      time.Time       `json:"date"`
+	Sequences []ChainSequence `json:"sequences"`
+}
+
+// ChainSequence represents one possible sequence of consecutive legs
+type ChainSequence struct {
+	StartTime time.Time  `json:"start_time"`
+	EndTime   time.Time  `json:"end_time"`
+	Legs      []ChainLeg `json:"legs"`
+}
+
+// ChainLeg represents a single leg of a chained sequence assigned to an employee
+type ChainLeg struct {
+	LegIndex   int       `json:"leg_index"`
+	EmployeeID uuid.UUID `json:"employee_id"`
+	Preferred  bool      `json:"preferred"` // Whether the employee is one of the leg's preferred employees
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+}
+
+// ChainLegQuery represents a validated leg of a chained availability search for internal calculations
+type ChainLegQuery struct {
+	Duration    time.Duration
+	GapAfter    time.Duration
+	EmployeeIDs []uuid.UUID // Preferred employees, tried before everybody else
+	ResourceIDs []uuid.UUID
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Custom field types
+const (
+	CustomFieldTypeText   = "text"
+	CustomFieldTypeNumber = "number"
+	CustomFieldTypeDate   = "date"
+	CustomFieldTypeEnum   = "enum"
+)
+
+// CustomFieldDefinition represents an extra attribute the business keeps on its employees,
+// such as an emergency contact or uniform size. Values are validated against its type and rules.
+type CustomFieldDefinition struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Key       string    `json:"key" gorm:"type:varchar(64);uniqueIndex;not null"` // Lowercase identifier used in values and filters
+	Label     string    `json:"label" gorm:"type:varchar(100);not null"`
+	Type      string    `json:"type" gorm:"type:varchar(10);not null"` // text, number, date, enum
+	Required  bool      `json:"required" gorm:"not null;default:false"`
+	MaxLength *int      `json:"max_length" gorm:"type:integer"` // Text only
+	Pattern   string    `json:"pattern" gorm:"type:text"`       // Text only, regular expression the whole value must match
+	MinValue  *float64  `json:"min_value"`                      // Number only
+	MaxValue  *float64  `json:"max_value"`                      // Number only
+	Options   []string  `json:"options" gorm:"-"`               // Enum only, allowed values in display order
+	Position  int       `json:"position" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// CustomFieldOption is an allowed value of an enum custom field
+type CustomFieldOption struct {
+	FieldID  uuid.UUID `json:"field_id" gorm:"type:uuid;primaryKey"`
+	Value    string    `json:"value" gorm:"type:varchar(100);primaryKey"`
+	Position int       `json:"position" gorm:"not null;default:0"`
+}
+
+// EmployeeCustomFieldValue holds the value of a custom field for an employee.
+// Values are stored normalized as text: numbers without trailing zeros and dates as YYYY-MM-DD.
+type EmployeeCustomFieldValue struct {
+	EmployeeID uuid.UUID `json:"employee_id" gorm:"type:uuid;primaryKey"`
+	FieldID    uuid.UUID `json:"field_id" gorm:"type:uuid;primaryKey;index"`
+	Value      string    `json:"value" gorm:"type:text;not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// CustomFieldFilter restricts the employee directory to employees whose value of a field matches
+type CustomFieldFilter struct {
+	FieldID uuid.UUID
+	Value   string // Normalized value, compared case-insensitively
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Customer represents a client of the business.
+// Duplicates are not deleted when merged; they point at the surviving record via MergedIntoID.
+type Customer struct {
+	ID                    uuid.UUID   `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	FirstName             string      `json:"first_name" gorm:"type:varchar(40);not null;index"`
+	LastName              string      `json:"last_name" gorm:"type:varchar(40);not null;index"`
+	Email                 string      `json:"email" gorm:"type:varchar(100);index"`
+	Phone                 string      `json:"phone" gorm:"type:varchar(20);index"` // Normalized: digits with optional leading +
+	MarketingEmailConsent bool        `json:"marketing_email_consent" gorm:"default:false"`
+	MarketingSMSConsent   bool        `json:"marketing_sms_consent" gorm:"default:false"`
+	ConsentUpdatedAt      *time.Time  `json:"consent_updated_at"`
+	Notes                 string      `json:"notes" gorm:"type:text"`
+	MergedIntoID          *uuid.UUID  `json:"merged_into_id,omitempty" gorm:"type:uuid;index"`
+	PreferredEmployeeIDs  []uuid.UUID `json:"preferred_employee_ids" gorm:"-"`
+	CreatedAt             time.Time   `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt             time.Time   `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// CustomerPreferredEmployee links a customer to an employee they prefer to be served by
+type CustomerPreferredEmployee struct {
+	CustomerID uuid.UUID `json:"customer_id" gorm:"type:uuid;primaryKey"`
+	EmployeeID uuid.UUID `json:"employee_id" gorm:"type:uuid;primaryKey;index"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// CustomerPage represents a paginated list of customers
+type CustomerPage struct {
+	Data     []Customer `json:"data"`
+	Total    int64      `json:"total"`
+	Page     int        `json:"page"`
+	PageSize int        `json:"page_size"`
+}
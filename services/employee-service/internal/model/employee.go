@@ -4,16 +4,57 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Employee struct {
     ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-    FirstName   string    `json:"first_name" gorm:"type:varchar(40);not null"`
-    LastName    string    `json:"last_name" gorm:"type:varchar(40);not null"`
+    FirstName   string    `json:"first_name" gorm:"type:varchar(40);not null;index:idx_employee_name,priority:2"`
+    LastName    string    `json:"last_name" gorm:"type:varchar(40);not null;index:idx_employee_name,priority:1"`
     Email       string    `json:"email" gorm:"type:varchar(100);uniqueIndex;not null"`
     Picture     string    `json:"picture" gorm:"type:text"`
-    Role        string    `json:"role" gorm:"type:varchar(100)"`
-    IsActive    bool      `json:"is_active" gorm:"default:false"`
-    CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+    Role        string    `json:"role" gorm:"type:varchar(100);index"`
+    IsActive    bool      `json:"is_active" gorm:"default:false;index"`
+    TerminationDate *time.Time `json:"termination_date" gorm:"type:date;index"` // Last working day, nil while employed
+    UserID      *uuid.UUID `json:"user_id" gorm:"type:uuid;uniqueIndex"` // Auth service user signing in as this employee
+    TeamID      *uuid.UUID `json:"team_id" gorm:"type:uuid;index"`
+    ManagerID   *uuid.UUID `json:"manager_id" gorm:"type:uuid;index"` // Employee this employee reports to
+    LocationID  *uuid.UUID `json:"location_id" gorm:"type:uuid;index"` // Home location
+    PayGrade    string    `json:"pay_grade" gorm:"type:varchar(50)"`
+    CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime;index"`
     UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-}
\ No newline at end of file
+    DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+    CustomFields map[string]interface{} `json:"custom_fields,omitempty" gorm:"-"` // Custom field values by key, only loaded for single employees
+}
+
+// IsEmployedOn reports whether the employee has not left before the given date
+func (e Employee) IsEmployedOn(date time.Time) bool {
+    return e.TerminationDate == nil || !e.TerminationDate.Before(date)
+}
+
+// EmployeeFilter holds the search, filter, sort and pagination options of the employee directory
+type EmployeeFilter struct {
+    Search        string     // Matched against first name, last name, full name and email
+    Role          string
+    IsActive      *bool
+    Skill         string     // Normalized skill name, empty for any
+    MinLevel      int
+    IncludeFormer bool       // Include terminated and soft-deleted employees
+    EmployeeIDs   []uuid.UUID // Restricts the results to these employees when not nil
+    LocationID    *uuid.UUID  // Employees based at the location or with a current or future schedule there
+    CustomFields  []CustomFieldFilter // Every filter must match
+    AsOf          time.Time  // Date used for terminations and certification expiry
+    Historic      bool       // Match role, active status and home location against the employee history on AsOf
+    Sort          string     // Column to sort by
+    Descending    bool
+    Page          int
+    PageSize      int
+}
+
+// EmployeePage represents a paginated list of employees
+type EmployeePage struct {
+    Data     []Employee `json:"data"`
+    Total    int64      `json:"total"`
+    Page     int        `json:"page"`
+    PageSize int        `json:"page_size"`
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmployeeHistory is one effective-dated version of the employee attributes that change over time:
+// role, active status, home location and pay grade. The versions of an employee follow each other
+// without gaps or overlaps; the current version has no ValidUntil. Employees created before history
+// was kept have no versions until their first change.
+type EmployeeHistory struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EmployeeID uuid.UUID  `json:"employee_id" gorm:"type:uuid;not null;index:idx_employee_history,priority:1"`
+	ValidFrom  time.Time  `json:"valid_from" gorm:"type:date;not null;index:idx_employee_history,priority:2"`
+	ValidUntil *time.Time `json:"valid_until" gorm:"type:date"` // Last day of the version, nil for the current one
+	Role       string     `json:"role" gorm:"type:varchar(100)"`
+	IsActive   bool       `json:"is_active"`
+	LocationID *uuid.UUID `json:"location_id" gorm:"type:uuid"`
+	PayGrade   string     `json:"pay_grade" gorm:"type:varchar(50)"`
+	ChangedBy  string     `json:"changed_by" gorm:"type:varchar(64)"` // Auth user ID that made the change
+	Reason     string     `json:"reason" gorm:"type:text"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// NewEmployeeHistory returns a version holding the current attributes of an employee
+func NewEmployeeHistory(employee Employee, validFrom time.Time) EmployeeHistory {
+	return EmployeeHistory{
+		EmployeeID: employee.ID,
+		ValidFrom:  validFrom,
+		Role:       employee.Role,
+		IsActive:   employee.IsActive,
+		LocationID: employee.LocationID,
+		PayGrade:   employee.PayGrade,
+	}
+}
+
+// Covers reports whether the version is valid on a date
+func (h EmployeeHistory) Covers(date time.Time) bool {
+	return !h.ValidFrom.After(date) && (h.ValidUntil == nil || !h.ValidUntil.Before(date))
+}
+
+// HistoryChanged reports whether any attribute kept in the employee history differs between two records
+func HistoryChanged(previous Employee, updated Employee) bool {
+	sameLocation := (previous.LocationID == nil && updated.LocationID == nil) ||
+		(previous.LocationID != nil && updated.LocationID != nil && *previous.LocationID == *updated.LocationID)
+	return previous.Role != updated.Role || previous.IsActive != updated.IsActive ||
+		previous.PayGrade != updated.PayGrade || !sameLocation
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// EmployeeImport is a validated import row: a new employee with its custom field values and initial weekly schedule
+type EmployeeImport struct {
+	Employee          *Employee
+	CustomFieldValues []EmployeeCustomFieldValue
+	Schedules         []Schedule
+}
+
+// EmployeeImportReport describes the outcome of a bulk employee import or its dry run
+type EmployeeImportReport struct {
+	DryRun            bool                `json:"dry_run"`
+	TotalRows         int                 `json:"total_rows"`
+	ValidRows         int                 `json:"valid_rows"`
+	InvalidRows       int                 `json:"invalid_rows"`
+	ImportedEmployees int                 `json:"imported_employees"` // Zero for dry runs
+	ImportedSchedules int                 `json:"imported_schedules"`
+	Rows              []EmployeeImportRow `json:"rows"`
+}
+
+// EmployeeImportRow describes the validation result of one row of an import file
+type EmployeeImportRow struct {
+	Row        int        `json:"row"` // Row number in the file, the header is row 1
+	Email      string     `json:"email"`
+	Valid      bool       `json:"valid"`
+	Errors     []string   `json:"errors,omitempty"`
+	Warnings   []string   `json:"warnings,omitempty"`
+	Schedules  int        `json:"schedules"`             // Weekly schedule rows the employee gets
+	EmployeeID *uuid.UUID `json:"employee_id,omitempty"` // Set once imported
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Photo sizes served for employee photos
+const (
+	PhotoSizeFull      = "full"  // Fits within 1024x1024
+	PhotoSizeThumbnail = "thumb" // 256x256 centre crop
+)
+
+// EmployeePhoto represents the managed photo of an employee. Uploads are re-encoded as JPEG,
+// which strips EXIF and other metadata, and stored in blob storage in two sizes.
+type EmployeePhoto struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EmployeeID    uuid.UUID `json:"employee_id" gorm:"type:uuid;not null;uniqueIndex"`
+	FullKey       string    `json:"-" gorm:"type:varchar(255);not null"`
+	ThumbnailKey  string    `json:"-" gorm:"type:varchar(255);not null"`
+	ContentType   string    `json:"content_type" gorm:"type:varchar(50);not null"`
+	Width         int       `json:"width" gorm:"not null"`
+	Height        int       `json:"height" gorm:"not null"`
+	OriginalBytes int       `json:"original_bytes" gorm:"not null"` // Size of the uploaded file
+	UploadedBy    string    `json:"uploaded_by" gorm:"type:varchar(64)"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
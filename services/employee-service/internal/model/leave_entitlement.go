@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Accrual methods for leave entitlements
+const (
+	AccrualUpfront = "upfront" // Whole allowance is available from the start of the year
+	AccrualMonthly = "monthly" // One twelfth of the allowance is added at the start of each month
+)
+
+// LeaveEntitlement represents how much leave of one type an employee gets in a calendar year.
+// Amounts are in hours, so part-time schedules are handled naturally.
+type LeaveEntitlement struct {
+	ID                uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EmployeeID        uuid.UUID `json:"employee_id" gorm:"type:uuid;not null;uniqueIndex:idx_leave_entitlement_period"`
+	LeaveType         string    `json:"leave_type" gorm:"type:varchar(20);not null;uniqueIndex:idx_leave_entitlement_period"` // vacation, sick, training
+	Year              int       `json:"year" gorm:"not null;uniqueIndex:idx_leave_entitlement_period"`
+	AllowanceHours    float64   `json:"allowance_hours" gorm:"not null"`                                   // Yearly allowance
+	AccrualMethod     string    `json:"accrual_method" gorm:"type:varchar(20);not null;default:'upfront'"` // upfront, monthly
+	CarryOverCapHours float64   `json:"carry_over_cap_hours" gorm:"not null;default:0"`                    // Maximum unused hours carried into the next year
+	CreatedAt         time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// LeaveBalance represents the computed leave balance of an employee for one leave type and year.
+type LeaveBalance struct {
+	EmployeeID        uuid.UUID `json:"employee_id"`
+	LeaveType         string    `json:"leave_type"`
+	Year              int       `json:"year"`
+	AsOf              time.Time `json:"as_of"`
+	AllowanceHours    float64   `json:"allowance_hours"`
+	AccruedHours      float64   `json:"accrued_hours"`       // Part of the allowance earned as of AsOf
+	CarriedOverHours  float64   `json:"carried_over_hours"`  // Unused hours brought in from the previous year, after the cap
+	UsedHours         float64   `json:"used_hours"`          // Scheduled hours covered by approved leave in the year
+	PendingHours      float64   `json:"pending_hours"`       // Scheduled hours covered by pending leave in the year
+	AvailableHours    float64   `json:"available_hours"`     // Accrued + carried over - used
+	UsedScheduledDays int       `json:"used_scheduled_days"` // Scheduled days touched by approved leave
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Leave types
+const (
+	LeaveTypeVacation = "vacation"
+	LeaveTypeSick     = "sick"
+	LeaveTypeTraining = "training"
+)
+
+// Leave request statuses
+const (
+	LeaveStatusPending   = "pending"
+	LeaveStatusApproved  = "approved"
+	LeaveStatusRejected  = "rejected"
+	LeaveStatusCancelled = "cancelled"
+)
+
+// LeaveRequest represents a request for time off that goes through manager approval.
+// Approving a request creates the OnetimeBlock that makes the employee unavailable.
+type LeaveRequest struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EmployeeID     uuid.UUID  `json:"employee_id" gorm:"type:uuid;not null;index"`
+	Type           string     `json:"type" gorm:"type:varchar(20);not null"`                           // vacation, sick, training
+	Status         string     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"` // pending, approved, rejected, cancelled
+	StartDateTime  time.Time  `json:"start_date_time" gorm:"type:timestamp with time zone;not null"`
+	EndDateTime    time.Time  `json:"end_date_time" gorm:"type:timestamp with time zone;not null"`
+	Reason         string     `json:"reason" gorm:"type:text"`
+	RequestedBy    string     `json:"requested_by" gorm:"type:varchar(64)"` // Auth user ID that created the request
+	DecidedBy      string     `json:"decided_by" gorm:"type:varchar(64)"`   // Auth user ID that approved, rejected or cancelled it
+	DecidedAt      *time.Time `json:"decided_at"`
+	DecisionNote   string     `json:"decision_note" gorm:"type:text"`
+	OnetimeBlockID *uuid.UUID `json:"onetime_block_id" gorm:"type:uuid"` // Set while the request is approved
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// LeaveConflict describes something that clashes with a leave request
+type LeaveConflict struct {
+	Type        string    `json:"type"` // onetime_block, leave_request
+	ReferenceID uuid.UUID `json:"reference_id"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	Description string    `json:"description"`
+}
+
+// LeaveRequestDetail represents a leave request together with its current conflicts
+type LeaveRequestDetail struct {
+	LeaveRequest
+	Conflicts []LeaveConflict `json:"conflicts"`
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Location represents a salon where employees work.
+// Opening hours are times of day in UTC, like every schedule time. Only UTC is accepted as time zone
+// until schedule times are converted per location.
+type Location struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name       string    `json:"name" gorm:"type:varchar(100);not null;uniqueIndex"`
+	Street     string    `json:"street" gorm:"type:varchar(200)"`
+	City       string    `json:"city" gorm:"type:varchar(100)"`
+	PostalCode string    `json:"postal_code" gorm:"type:varchar(20)"`
+	Country    string    `json:"country" gorm:"type:varchar(2)"` // ISO 3166-1 alpha-2 code
+	Phone      string    `json:"phone" gorm:"type:varchar(20)"`
+	TimeZone   string    `json:"time_zone" gorm:"type:varchar(64);not null;default:'UTC'"` // IANA time zone, always UTC for now
+	IsActive   bool      `json:"is_active" gorm:"default:true"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// LocationOpeningHour represents the weekly opening hours of a location.
+// A location without opening hours on a day of week is closed on that day.
+type LocationOpeningHour struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	LocationID uuid.UUID `json:"location_id" gorm:"type:uuid;not null;index"`
+	DayOfWeek  int       `json:"day_of_week" gorm:"type:smallint;not null;check:day_of_week >= 0 AND day_of_week <= 6"` // 0-6 (Sun-Sat)
+	StartTime  time.Time `json:"start_time" gorm:"type:time without time zone;not null"`                                // HH:MM:SS format
+	EndTime    time.Time `json:"end_time" gorm:"type:time without time zone;not null"`                                  // HH:MM:SS format
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// LocationCoverage represents who works at a location over a period and when it is open without staff
+type LocationCoverage struct {
+	LocationID uuid.UUID             `json:"location_id"`
+	TimeZone   string                `json:"time_zone"`
+	StartDate  string                `json:"start_date"`
+	EndDate    string                `json:"end_date"`
+	Days       []LocationCoverageDay `json:"days"`
+}
+
+// LocationCoverageDay represents the coverage of a location on a single date
+type LocationCoverageDay struct {
+	Date           string                 `json:"date"`
+	OpeningHours   []AvailabilitySchedule `json:"opening_hours"` // Empty when the location is closed
+	Shifts         []LocationShift        `json:"shifts"`
+	UncoveredSlots []AvailabilitySlot     `json:"uncovered_slots"` // Opening hours when nobody is free to serve customers
+}
+
+// LocationShift represents an employee working at a location on a date
+type LocationShift struct {
+	EmployeeID uuid.UUID          `json:"employee_id"`
+	FirstName  string             `json:"first_name"`
+	LastName   string             `json:"last_name"`
+	Role       string             `json:"role"`
+	StartTime  time.Time          `json:"start_time"`
+	EndTime    time.Time          `json:"end_time"`
+	FreeSlots  []AvailabilitySlot `json:"free_slots"` // The shift minus blocks, leave and breaks
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Open shift statuses
+const (
+	OpenShiftStatusOpen      = "open"
+	OpenShiftStatusFilled    = "filled"
+	OpenShiftStatusCancelled = "cancelled"
+)
+
+// Open shift claim modes
+const (
+	ClaimModeFirstCome = "first_come" // Claims by eligible employees are approved immediately while places remain
+	ClaimModeApproval  = "approval"   // Claims wait for a manager to approve them
+)
+
+// Open shift claim statuses
+const (
+	ClaimStatusPending   = "pending"
+	ClaimStatusApproved  = "approved"
+	ClaimStatusRejected  = "rejected"
+	ClaimStatusCancelled = "cancelled" // The open shift was cancelled
+)
+
+// OpenShift represents an unassigned shift posted by a manager for eligible employees to claim.
+// Every approved claim creates a one-time schedule for the claiming employee.
+type OpenShift struct {
+	ID            uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ShiftDate     time.Time        `json:"shift_date" gorm:"type:date;not null;index"`
+	StartDateTime time.Time        `json:"start_date_time" gorm:"type:timestamp with time zone;not null"`
+	EndDateTime   time.Time        `json:"end_date_time" gorm:"type:timestamp with time zone;not null"` // Next day for shifts crossing midnight
+	Role          string           `json:"role" gorm:"type:varchar(100)"`                               // Required employee role, empty for any role
+	LocationID    *uuid.UUID       `json:"location_id" gorm:"type:uuid;index"`                          // Location of the schedules created for claims
+	Headcount     int              `json:"headcount" gorm:"not null"`
+	ClaimMode     string           `json:"claim_mode" gorm:"type:varchar(20);not null"`                  // first_come, approval
+	Status        string           `json:"status" gorm:"type:varchar(20);not null;default:'open';index"` // open, filled, cancelled
+	Notes         string           `json:"notes" gorm:"type:text"`
+	PostedBy      string           `json:"posted_by" gorm:"type:varchar(64)"` // Auth user ID of the manager who posted it
+	Claims        []OpenShiftClaim `json:"claims,omitempty" gorm:"-"`
+	CreatedAt     time.Time        `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time        `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// OpenShiftClaim represents an employee claiming a place on an open shift
+type OpenShiftClaim struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	OpenShiftID uuid.UUID  `json:"open_shift_id" gorm:"type:uuid;not null;uniqueIndex:idx_open_shift_claim"`
+	EmployeeID  uuid.UUID  `json:"employee_id" gorm:"type:uuid;not null;uniqueIndex:idx_open_shift_claim"`
+	Status      string     `json:"status" gorm:"type:varchar(20);not null;default:'pending'"` // pending, approved, rejected, cancelled
+	ScheduleID  *uuid.UUID `json:"schedule_id" gorm:"type:uuid"`                              // One-time schedule created on approval
+	DecidedBy   string     `json:"decided_by" gorm:"type:varchar(64)"`
+	DecidedAt   *time.Time `json:"decided_at"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// EligibleEmployee represents an employee who can claim an open shift
+type EligibleEmployee struct {
+	EmployeeID uuid.UUID `json:"employee_id"`
+	FirstName  string    `json:"first_name"`
+	LastName   string    `json:"last_name"`
+	Role       string    `json:"role"`
+}
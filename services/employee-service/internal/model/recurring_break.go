@@ -15,6 +15,7 @@ type RecurringBreak struct {
 	StartTime   time.Time  `json:"start_time" gorm:"type:time without time zone;not null"`                                  // HH:MM:SS format
 	EndTime     time.Time  `json:"end_time" gorm:"type:time without time zone;not null"`                                    // HH:MM:SS format
 	Reason      string     `json:"reason" gorm:"type:text;not null"`                                                         // Mandatory reason for the break
+	ValidUntil  *time.Time `json:"valid_until,omitempty" gorm:"type:date"`                                                   // Last day of the employee's employment, set when they are terminated
 	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 } 
\ No newline at end of file
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Resource represents a bookable physical resource such as a chair, a room,
+// a wash basin or a piece of equipment.
+type Resource struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name      string    `json:"name" gorm:"type:varchar(100);not null;uniqueIndex"`
+	Type      string    `json:"type" gorm:"type:varchar(50);not null;index"`                          // chair, room, equipment, ...
+	Capacity  int       `json:"capacity" gorm:"type:smallint;not null;default:1;check:capacity >= 1"` // How many services can use it at the same time
+	IsActive  bool      `json:"is_active" gorm:"default:true"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ResourceOpeningHour represents the weekly opening hours of a resource.
+// A resource without opening hours on a day of week is closed on that day.
+type ResourceOpeningHour struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ResourceID uuid.UUID `json:"resource_id" gorm:"type:uuid;not null;index"`
+	DayOfWeek  int       `json:"day_of_week" gorm:"type:smallint;not null;check:day_of_week >= 0 AND day_of_week <= 6"` // 0-6 (Sun-Sat)
+	StartTime  time.Time `json:"start_time" gorm:"type:time without time zone;not null"`                                // HH:MM:SS format
+	EndTime    time.Time `json:"end_time" gorm:"type:time without time zone;not null"`                                  // HH:MM:SS format
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ResourceBlock represents a specific, non-recurring block of time when a
+// resource is unavailable (maintenance, repairs, private events...). A block can
+// take only some units of a resource with a capacity above 1, for example one of
+// three wash basins; blocks may overlap as long as together they stay within capacity.
+type ResourceBlock struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ResourceID    uuid.UUID `json:"resource_id" gorm:"type:uuid;not null;index"`
+	StartDateTime time.Time `json:"start_date_time" gorm:"type:timestamp with time zone;not null"`
+	EndDateTime   time.Time `json:"end_date_time" gorm:"type:timestamp with time zone;not null"`
+	Units         *int      `json:"units" gorm:"type:smallint;check:units >= 1"` // Units of capacity taken, nil takes the whole resource
+	Reason        string    `json:"reason" gorm:"type:text;not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ResourceAvailabilityRequest represents the request structure for the resource availability endpoint
+type ResourceAvailabilityRequest struct {
+	Date       string `json:"date" validate:"required"`        // ISO 8601 date format
+	ResourceID string `json:"resource_id" validate:"required"` // UUID string
+}
+
+// ResourceAvailabilityResponse represents the availability of a single resource on a date
+type ResourceAvailabilityResponse struct {
+	Date          time.Time              `json:"date"`
+	ResourceID    uuid.UUID              `json:"resource_id"`
+	Capacity      int                    `json:"capacity"`
+	OpeningHours  []AvailabilitySchedule `json:"opening_hours"` // Empty when the resource is closed on that date
+	Blocks        []AvailabilityBlock    `json:"blocks"`
+	FreeSlots     []AvailabilitySlot     `json:"free_slots"`     // Times with at least one free unit
+	CapacitySlots []ResourceCapacitySlot `json:"capacity_slots"` // Opening hours split by the number of free units
+}
+
+// ResourceCapacitySlot is a period in which the same number of units of a resource is free
+type ResourceCapacitySlot struct {
+	StartTime time.Time `json:"start_time"` // Full ISO datetime
+	EndTime   time.Time `json:"end_time"`   // Full ISO datetime
+	Available int       `json:"available"`
+}
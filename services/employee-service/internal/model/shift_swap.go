@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Shift swap statuses
+const (
+	SwapStatusOffered   = "offered"
+	SwapStatusAccepted  = "accepted"
+	SwapStatusApproved  = "approved"
+	SwapStatusRejected  = "rejected"
+	SwapStatusCancelled = "cancelled"
+)
+
+// ShiftSwap represents an employee handing a dated shift over to a colleague.
+// The colleague accepts the offer and a manager approves it. Approval gives the accepting
+// employee a one-time schedule for the shift and blocks the shift for the offering employee.
+type ShiftSwap struct {
+	ID                  uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	OfferingEmployeeID  uuid.UUID  `json:"offering_employee_id" gorm:"type:uuid;not null;index"`
+	AcceptingEmployeeID *uuid.UUID `json:"accepting_employee_id" gorm:"type:uuid;index"`
+	ShiftDate           time.Time  `json:"shift_date" gorm:"type:date;not null"`
+	SourceScheduleID    uuid.UUID  `json:"source_schedule_id" gorm:"type:uuid;not null"`                    // Schedule the shift was resolved from when offered
+	StartDateTime       time.Time  `json:"start_date_time" gorm:"type:timestamp with time zone;not null"`   // Shift start on ShiftDate
+	EndDateTime         time.Time  `json:"end_date_time" gorm:"type:timestamp with time zone;not null"`     // Shift end, next day for shifts crossing midnight
+	Status              string     `json:"status" gorm:"type:varchar(20);not null;default:'offered';index"` // offered, accepted, approved, rejected, cancelled
+	Note                string     `json:"note" gorm:"type:text"`
+	OfferedBy           string     `json:"offered_by" gorm:"type:varchar(64)"` // Auth user ID that created the offer
+	DecidedBy           string     `json:"decided_by" gorm:"type:varchar(64)"` // Auth user ID that approved, rejected or cancelled it
+	DecidedAt           *time.Time `json:"decided_at"`
+	DecisionNote        string     `json:"decision_note" gorm:"type:text"`
+	CreatedScheduleID   *uuid.UUID `json:"created_schedule_id" gorm:"type:uuid"` // One-time schedule of the accepting employee
+	CreatedBlockID      *uuid.UUID `json:"created_block_id" gorm:"type:uuid"`    // One-time block of the offering employee
+	CreatedAt           time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
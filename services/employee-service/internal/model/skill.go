@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Certification enforcement modes
+const (
+	CertificationEnforcementWarn  = "warn"  // Scheduling succeeds and the problems are returned as warnings
+	CertificationEnforcementBlock = "block" // Scheduling is refused while a required certification is missing or expiring
+)
+
+// Skill levels
+const (
+	SkillLevelMin = 1 // Beginner
+	SkillLevelMax = 5 // Expert
+)
+
+// EmployeeSkill represents a skill or certification held by an employee.
+// Names are stored lowercase so filtering and role requirements match regardless of spelling.
+type EmployeeSkill struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EmployeeID      uuid.UUID  `json:"employee_id" gorm:"type:uuid;not null;uniqueIndex:idx_employee_skill"`
+	Name            string     `json:"name" gorm:"type:varchar(100);not null;uniqueIndex:idx_employee_skill;index"`
+	Level           int        `json:"level" gorm:"type:smallint;not null;check:level >= 1 AND level <= 5"`
+	IsCertification bool       `json:"is_certification" gorm:"not null;default:false"`
+	IssuedOn        *time.Time `json:"issued_on" gorm:"type:date"`
+	ExpiresOn       *time.Time `json:"expires_on" gorm:"type:date;index"` // Nil for skills and certifications that do not expire
+	Notes           string     `json:"notes" gorm:"type:text"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// RoleRequirement represents a skill or certification an employee needs to be scheduled in a role
+type RoleRequirement struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Role      string    `json:"role" gorm:"type:varchar(100);not null;uniqueIndex:idx_role_requirement"`
+	SkillName string    `json:"skill_name" gorm:"type:varchar(100);not null;uniqueIndex:idx_role_requirement"`
+	MinLevel  int       `json:"min_level" gorm:"type:smallint;not null;default:1"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// CertificationAlert describes a certification that has expired or expires within the warning window
+type CertificationAlert struct {
+	EmployeeID uuid.UUID `json:"employee_id"`
+	FirstName  string    `json:"first_name"`
+	LastName   string    `json:"last_name"`
+	Role       string    `json:"role"`
+	SkillName  string    `json:"skill_name"`
+	ExpiresOn  time.Time `json:"expires_on"`
+	DaysLeft   int       `json:"days_left"` // Negative when already expired
+	Required   bool      `json:"required"`  // Whether the employee's role requires the certification
+}
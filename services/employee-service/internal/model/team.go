@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Team represents a group of employees. Teams can be nested through ParentID and are led by an employee.
+// The lead manages every member of the team and of its sub-teams.
+type Team struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name        string     `json:"name" gorm:"type:varchar(100);not null;uniqueIndex"`
+	Description string     `json:"description" gorm:"type:text"`
+	ParentID    *uuid.UUID `json:"parent_id" gorm:"type:uuid;index"` // Nil for top-level teams
+	LeadID      *uuid.UUID `json:"lead_id" gorm:"type:uuid;index"`   // Employee leading the team
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// EmployeeReport represents an employee reporting to a manager and how many levels below the manager they are
+type EmployeeReport struct {
+	Employee
+	Depth int `json:"depth"` // 1 for direct reports
+}
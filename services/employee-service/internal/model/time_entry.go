@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimeEntry represents one stretch of actual work recorded with the time clock.
+// An entry is open while ClockOut is nil; an employee can have at most one open entry.
+// Punches are only accepted close to the current time; entries for other times are
+// recorded or corrected by managers, which is kept in the Corrected* fields.
+type TimeEntry struct {
+	ID               uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EmployeeID       uuid.UUID        `json:"employee_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_open_time_entry,where:clock_out IS NULL"`
+	ClockIn          time.Time        `json:"clock_in" gorm:"type:timestamp with time zone;not null;index"`
+	ClockOut         *time.Time       `json:"clock_out" gorm:"type:timestamp with time zone"`
+	Notes            string           `json:"notes" gorm:"type:text"`
+	RecordedBy       string           `json:"recorded_by" gorm:"type:varchar(64)"`  // Auth user ID that clocked in
+	CorrectedBy      string           `json:"corrected_by" gorm:"type:varchar(64)"` // Auth user ID of the manager who last corrected the entry
+	CorrectedAt      *time.Time       `json:"corrected_at" gorm:"type:timestamp with time zone"`
+	CorrectionReason string           `json:"correction_reason" gorm:"type:text"`
+	Breaks           []TimeEntryBreak `json:"breaks" gorm:"-"`
+	CreatedAt        time.Time        `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time        `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TimeEntryBreak represents a break taken during a time entry. A break is open while EndTime is nil.
+type TimeEntryBreak struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TimeEntryID uuid.UUID  `json:"time_entry_id" gorm:"type:uuid;not null;index"`
+	StartTime   time.Time  `json:"start_time" gorm:"type:timestamp with time zone;not null"`
+	EndTime     *time.Time `json:"end_time" gorm:"type:timestamp with time zone"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Variance flag types
+const (
+	VarianceLateArrival    = "late_arrival"
+	VarianceEarlyDeparture = "early_departure"
+	VarianceMissedBreak    = "missed_break"
+	VarianceUnscheduled    = "unscheduled_work"
+	VarianceNoShow         = "no_show"
+)
+
+// VarianceFlag describes one difference between actual and scheduled work
+type VarianceFlag struct {
+	Type    string `json:"type"`
+	Minutes int    `json:"minutes"`
+	Message string `json:"message"`
+}
+
+// VarianceDay compares the time entries of one day with the schedule and breaks of that day
+type VarianceDay struct {
+	Date             time.Time             `json:"date"`
+	Schedule         *AvailabilitySchedule `json:"schedule"` // Nil when the employee is not scheduled
+	Breaks           []AvailabilityBreak   `json:"breaks"`
+	Entries          []TimeEntry           `json:"entries"`
+	ScheduledMinutes int                   `json:"scheduled_minutes"` // Free time of the day, i.e. schedule minus blocks and breaks
+	WorkedMinutes    int                   `json:"worked_minutes"`    // Clocked time minus recorded breaks
+	VarianceMinutes  int                   `json:"variance_minutes"`  // Worked minus scheduled
+	Flags            []VarianceFlag        `json:"flags"`
+}
+
+// VarianceReport represents the schedule variance of an employee over a period
+type VarianceReport struct {
+	EmployeeID       uuid.UUID     `json:"employee_id"`
+	StartDate        time.Time     `json:"start_date"`
+	EndDate          time.Time     `json:"end_date"`
+	GraceMinutes     int           `json:"grace_minutes"`
+	Days             []VarianceDay `json:"days"`
+	ScheduledMinutes int           `json:"scheduled_minutes"`
+	WorkedMinutes    int           `json:"worked_minutes"`
+	VarianceMinutes  int           `json:"variance_minutes"`
+}
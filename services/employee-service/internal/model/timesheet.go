@@ -0,0 +1,90 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Timesheet statuses
+const (
+	TimesheetStatusOpen     = "open"     // Computed live from time entries or schedules
+	TimesheetStatusApproved = "approved" // Totals are frozen and the week is locked
+)
+
+// Timesheet sources
+const (
+	TimesheetSourceClocked   = "clocked"   // Hours come from time clock entries
+	TimesheetSourceScheduled = "scheduled" // Hours come from the resolved schedule (free slots)
+)
+
+// Holiday represents a public holiday; hours worked on it are reported separately on timesheets
+type Holiday struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Date      time.Time `json:"date" gorm:"type:date;not null;uniqueIndex"`
+	Name      string    `json:"name" gorm:"type:varchar(100);not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Timesheet represents the hours of an employee for one week (Monday to Sunday).
+// Open timesheets are computed on request; approving one stores its totals and locks the week.
+// Night and holiday minutes are premiums counted on top of the regular/overtime split.
+type Timesheet struct {
+	ID              uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EmployeeID      uuid.UUID      `json:"employee_id" gorm:"type:uuid;not null;uniqueIndex:idx_timesheet_week"`
+	WeekStart       time.Time      `json:"week_start" gorm:"type:date;not null;uniqueIndex:idx_timesheet_week"`
+	Status          string         `json:"status" gorm:"type:varchar(20);not null;default:'approved'"`
+	Source          string         `json:"source" gorm:"type:varchar(20);not null"` // clocked, scheduled
+	TotalMinutes    int            `json:"total_minutes" gorm:"not null"`
+	RegularMinutes  int            `json:"regular_minutes" gorm:"not null"`
+	OvertimeMinutes int            `json:"overtime_minutes" gorm:"not null"`
+	NightMinutes    int            `json:"night_minutes" gorm:"not null"`
+	HolidayMinutes  int            `json:"holiday_minutes" gorm:"not null"`
+	ApprovedBy      string         `json:"approved_by" gorm:"type:varchar(64)"`
+	ApprovedAt      *time.Time     `json:"approved_at"`
+	Days            []TimesheetDay `json:"days" gorm:"-"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TimesheetDay represents the hours of one day of a timesheet
+type TimesheetDay struct {
+	ID              uuid.UUID `json:"-" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TimesheetID     uuid.UUID `json:"-" gorm:"type:uuid;not null;index"`
+	Date            time.Time `json:"date" gorm:"type:date;not null"`
+	TotalMinutes    int       `json:"total_minutes" gorm:"not null"`
+	RegularMinutes  int       `json:"regular_minutes" gorm:"not null"`
+	OvertimeMinutes int       `json:"overtime_minutes" gorm:"not null"`
+	NightMinutes    int       `json:"night_minutes" gorm:"not null"`
+	HolidayMinutes  int       `json:"holiday_minutes" gorm:"not null"`
+}
+
+// PayrollExport is the versioned export format for the payroll provider.
+// Field names and ordering are part of the contract; add fields rather than changing existing ones.
+type PayrollExport struct {
+	FormatVersion string              `json:"format_version"`
+	WeekStart     string              `json:"week_start"` // YYYY-MM-DD
+	WeekEnd       string              `json:"week_end"`   // YYYY-MM-DD, inclusive
+	GeneratedAt   time.Time           `json:"generated_at"`
+	Timesheets    []PayrollExportLine `json:"timesheets"`
+}
+
+// PayrollExportLine represents one approved timesheet in the payroll export. Hours have two decimals.
+type PayrollExportLine struct {
+	EmployeeID    uuid.UUID `json:"employee_id"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	WeekStart     string    `json:"week_start"`
+	Source        string    `json:"source"`
+	RegularHours  float64   `json:"regular_hours"`
+	OvertimeHours float64   `json:"overtime_hours"`
+	NightHours    float64   `json:"night_hours"`
+	HolidayHours  float64   `json:"holiday_hours"`
+	TotalHours    float64   `json:"total_hours"`
+	ApprovedBy    string    `json:"approved_by"`
+	ApprovedAt    time.Time `json:"approved_at"`
+	Role          string    `json:"role"`      // As of the week start
+	PayGrade      string    `json:"pay_grade"` // As of the week start
+}
@@ -21,6 +21,7 @@ func GetEmployeeScheduleForDate(employeeID uuid.UUID, date time.Time) (*model.Sc
 	type ScheduleDTO struct {
 		ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
 		EmployeeID  uuid.UUID  `gorm:"type:uuid;not null"`
+		LocationID  *uuid.UUID `gorm:"type:uuid"`
 		DayOfWeek   int        `gorm:"type:smallint;not null"`
 		StartTime   string     `gorm:"type:time without time zone;not null"`
 		EndTime     string     `gorm:"type:time without time zone;not null"`
@@ -72,6 +73,7 @@ func GetEmployeeScheduleForDate(employeeID uuid.UUID, date time.Time) (*model.Sc
 	schedule := &model.Schedule{
 		ID:         selectedDTO.ID,
 		EmployeeID: selectedDTO.EmployeeID,
+		LocationID: selectedDTO.LocationID,
 		DayOfWeek:  selectedDTO.DayOfWeek,
 		StartTime:  startTime,
 		EndTime:    endTime,
@@ -117,6 +119,7 @@ func GetEmployeeRecurringBreaksForDay(employeeID uuid.UUID, dayOfWeek int) ([]mo
 		StartTime   string     `gorm:"type:time without time zone;not null"`
 		EndTime     string     `gorm:"type:time without time zone;not null"`
 		Reason      string     `gorm:"type:text;not null"`
+		ValidUntil  *time.Time `gorm:"type:date"`
 		CreatedAt   time.Time
 		UpdatedAt   time.Time
 	}
@@ -152,6 +155,7 @@ func GetEmployeeRecurringBreaksForDay(employeeID uuid.UUID, dayOfWeek int) ([]mo
 			StartTime:  startTime,
 			EndTime:    endTime,
 			Reason:     dto.Reason,
+			ValidUntil: dto.ValidUntil,
 			CreatedAt:  dto.CreatedAt,
 			UpdatedAt:  dto.UpdatedAt,
 		}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"services/shared/db"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateCustomFieldDefinition creates a custom field definition together with its enum options
+func CreateCustomFieldDefinition(field *model.CustomFieldDefinition) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Create(field).Error; err != nil {
+		return err
+	}
+	if err := createCustomFieldOptions(tx, field); err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// GetAllCustomFieldDefinitions returns all custom field definitions in display order
+func GetAllCustomFieldDefinitions() ([]model.CustomFieldDefinition, error) {
+	fields := []model.CustomFieldDefinition{}
+	if err := db.DB.Order("position ASC, key ASC").Find(&fields).Error; err != nil {
+		return nil, err
+	}
+
+	var options []model.CustomFieldOption
+	if err := db.DB.Order("position ASC").Find(&options).Error; err != nil {
+		return nil, err
+	}
+	byField := make(map[uuid.UUID][]string)
+	for _, option := range options {
+		byField[option.FieldID] = append(byField[option.FieldID], option.Value)
+	}
+	for i := range fields {
+		if fields[i].Type == model.CustomFieldTypeEnum {
+			fields[i].Options = byField[fields[i].ID]
+		}
+	}
+
+	return fields, nil
+}
+
+// GetCustomFieldDefinitionByID returns a custom field definition by ID
+func GetCustomFieldDefinitionByID(id uuid.UUID) (model.CustomFieldDefinition, error) {
+	var field model.CustomFieldDefinition
+	if err := db.DB.Where("id = ?", id).First(&field).Error; err != nil {
+		return field, err
+	}
+	return field, loadCustomFieldOptions(&field)
+}
+
+// GetCustomFieldDefinitionByKey returns a custom field definition by key
+func GetCustomFieldDefinitionByKey(key string) (model.CustomFieldDefinition, error) {
+	var field model.CustomFieldDefinition
+	if err := db.DB.Where("key = ?", key).First(&field).Error; err != nil {
+		return field, err
+	}
+	return field, loadCustomFieldOptions(&field)
+}
+
+// UpdateCustomFieldDefinition saves a custom field definition and replaces its enum options
+func UpdateCustomFieldDefinition(field *model.CustomFieldDefinition) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Save(field).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("field_id = ?", field.ID).Delete(&model.CustomFieldOption{}).Error; err != nil {
+		return err
+	}
+	if err := createCustomFieldOptions(tx, field); err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// DeleteCustomFieldDefinition deletes a custom field definition with its options and the values of all employees
+func DeleteCustomFieldDefinition(id uuid.UUID) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Where("field_id = ?", id).Delete(&model.EmployeeCustomFieldValue{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("field_id = ?", id).Delete(&model.CustomFieldOption{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(&model.CustomFieldDefinition{}, "id = ?", id).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// CountCustomFieldValues counts the employees that have one of the given values for a field
+func CountCustomFieldValues(fieldID uuid.UUID, values []string) (int64, error) {
+	var count int64
+	err := db.DB.Model(&model.EmployeeCustomFieldValue{}).
+		Where("field_id = ? AND value IN ?", fieldID, values).
+		Count(&count).Error
+	return count, err
+}
+
+// CountEmployeesMissingCustomField counts the current employees without a value for a field
+func CountEmployeesMissingCustomField(fieldID uuid.UUID) (int64, error) {
+	var count int64
+	err := db.DB.Model(&model.Employee{}).
+		Where("id NOT IN (?)", db.DB.Model(&model.EmployeeCustomFieldValue{}).Select("employee_id").Where("field_id = ?", fieldID)).
+		Count(&count).Error
+	return count, err
+}
+
+// GetEmployeeCustomFieldValues returns the custom field values of an employee
+func GetEmployeeCustomFieldValues(employeeID uuid.UUID) ([]model.EmployeeCustomFieldValue, error) {
+	values := []model.EmployeeCustomFieldValue{}
+	err := db.DB.Where("employee_id = ?", employeeID).Find(&values).Error
+	return values, err
+}
+
+// SaveEmployeeCustomFieldValues sets and removes custom field values of an employee in one transaction
+func SaveEmployeeCustomFieldValues(employeeID uuid.UUID, values []model.EmployeeCustomFieldValue, removeFieldIDs []uuid.UUID) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := saveEmployeeCustomFieldValues(tx, employeeID, values, removeFieldIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// CreateEmployeeWithCustomFields creates an employee together with its custom field values
+func CreateEmployeeWithCustomFields(employee *model.Employee, values []model.EmployeeCustomFieldValue) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Create(employee).Error; err != nil {
+		return err
+	}
+	if err := createEmployeeHistoryBaseline(tx, employee); err != nil {
+		return err
+	}
+	if err := saveEmployeeCustomFieldValues(tx, employee.ID, values, nil); err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// saveEmployeeCustomFieldValues upserts and removes custom field values within a transaction
+func saveEmployeeCustomFieldValues(tx *gorm.DB, employeeID uuid.UUID, values []model.EmployeeCustomFieldValue, removeFieldIDs []uuid.UUID) error {
+	if len(removeFieldIDs) > 0 {
+		if err := tx.Where("employee_id = ? AND field_id IN ?", employeeID, removeFieldIDs).
+			Delete(&model.EmployeeCustomFieldValue{}).Error; err != nil {
+			return err
+		}
+	}
+	for _, value := range values {
+		value.EmployeeID = employeeID
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "employee_id"}, {Name: "field_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+		}).Create(&value).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createCustomFieldOptions stores the enum options of a field in display order
+func createCustomFieldOptions(tx *gorm.DB, field *model.CustomFieldDefinition) error {
+	for i, value := range field.Options {
+		option := model.CustomFieldOption{FieldID: field.ID, Value: value, Position: i}
+		if err := tx.Create(&option).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadCustomFieldOptions loads the enum options of a field
+func loadCustomFieldOptions(field *model.CustomFieldDefinition) error {
+	if field.Type != model.CustomFieldTypeEnum {
+		return nil
+	}
+	field.Options = []string{}
+	return db.DB.Model(&model.CustomFieldOption{}).
+		Where("field_id = ?", field.ID).
+		Order("position ASC").
+		Pluck("value", &field.Options).Error
+}
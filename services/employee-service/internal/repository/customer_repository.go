@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"errors"
+	"services/shared/db"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCustomerHasMergedRecords is returned when deleting a customer other customers were merged into
+var ErrCustomerHasMergedRecords = errors.New("customer has merged records")
+
+// CreateCustomer creates a new customer in the database
+func CreateCustomer(customer *model.Customer) error {
+	if customer.ID == uuid.Nil {
+		customer.ID = uuid.New()
+	}
+	return db.DB.Create(customer).Error
+}
+
+// GetCustomerByID returns a customer by ID, including preferred employees
+func GetCustomerByID(id uuid.UUID) (model.Customer, error) {
+	var customer model.Customer
+	if err := db.DB.Where("id = ?", id).First(&customer).Error; err != nil {
+		return customer, err
+	}
+
+	preferred, err := GetCustomerPreferredEmployeeIDs(id)
+	if err != nil {
+		return customer, err
+	}
+	customer.PreferredEmployeeIDs = preferred
+
+	return customer, nil
+}
+
+// SearchCustomers returns a page of customers (excluding merged duplicates) matching the search term.
+// The term is matched against the full name, which covers first and last name, email and phone.
+func SearchCustomers(term string, phoneTerm string, page int, pageSize int) ([]model.Customer, int64, error) {
+	query := db.DB.Model(&model.Customer{}).Where("merged_into_id IS NULL")
+
+	if term != "" {
+		like := containsPattern(strings.ToLower(term))
+		conditions := `LOWER(first_name || ' ' || last_name) LIKE ? ESCAPE '\' OR LOWER(email) LIKE ? ESCAPE '\'`
+		args := []interface{}{like, like}
+		if phoneTerm != "" {
+			conditions += ` OR phone LIKE ? ESCAPE '\'`
+			args = append(args, containsPattern(phoneTerm))
+		}
+		query = query.Where("("+conditions+")", args...)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var customers []model.Customer
+	err := query.Order("last_name ASC, first_name ASC, id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&customers).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Load preferred employees for the whole page at once
+	customerIDs := make([]uuid.UUID, len(customers))
+	for i, customer := range customers {
+		customerIDs[i] = customer.ID
+	}
+	var links []model.CustomerPreferredEmployee
+	if len(customerIDs) > 0 {
+		if err := db.DB.Where("customer_id IN ?", customerIDs).Order("created_at ASC").Find(&links).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+	preferred := make(map[uuid.UUID][]uuid.UUID)
+	for _, link := range links {
+		preferred[link.CustomerID] = append(preferred[link.CustomerID], link.EmployeeID)
+	}
+	for i := range customers {
+		customers[i].PreferredEmployeeIDs = preferred[customers[i].ID]
+		if customers[i].PreferredEmployeeIDs == nil {
+			customers[i].PreferredEmployeeIDs = []uuid.UUID{}
+		}
+	}
+
+	return customers, total, nil
+}
+
+// FindDuplicateCustomers returns other active customers sharing the email or phone of the given customer
+func FindDuplicateCustomers(customer model.Customer) ([]model.Customer, error) {
+	var duplicates []model.Customer
+	if customer.Email == "" && customer.Phone == "" {
+		return duplicates, nil
+	}
+
+	query := db.DB.Where("id != ? AND merged_into_id IS NULL", customer.ID)
+	switch {
+	case customer.Email != "" && customer.Phone != "":
+		query = query.Where("LOWER(email) = LOWER(?) OR phone = ?", customer.Email, customer.Phone)
+	case customer.Email != "":
+		query = query.Where("LOWER(email) = LOWER(?)", customer.Email)
+	default:
+		query = query.Where("phone = ?", customer.Phone)
+	}
+
+	err := query.Order("created_at ASC").Find(&duplicates).Error
+	return duplicates, err
+}
+
+// UpdateCustomer updates a customer in the database
+func UpdateCustomer(customer *model.Customer) error {
+	return db.DB.Save(customer).Error
+}
+
+// DeleteCustomer deletes a customer and its preferred employee links. Customers that other
+// customers were merged into are not deleted, as the merged records would point at nothing;
+// ErrCustomerHasMergedRecords is returned instead. The customer row is locked so a merge into
+// it cannot happen at the same time.
+func DeleteCustomer(id uuid.UUID) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	var customer model.Customer
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&customer).Error; err != nil {
+		return err
+	}
+	var merged int64
+	if err := tx.Model(&model.Customer{}).Where("merged_into_id = ?", id).Count(&merged).Error; err != nil {
+		return err
+	}
+	if merged > 0 {
+		return ErrCustomerHasMergedRecords
+	}
+
+	if err := tx.Where("customer_id = ?", id).Delete(&model.CustomerPreferredEmployee{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(&model.Customer{}, id).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// GetCustomerPreferredEmployeeIDs returns the IDs of the employees a customer prefers
+func GetCustomerPreferredEmployeeIDs(customerID uuid.UUID) ([]uuid.UUID, error) {
+	employeeIDs := []uuid.UUID{}
+	err := db.DB.Model(&model.CustomerPreferredEmployee{}).
+		Where("customer_id = ?", customerID).
+		Order("created_at ASC").
+		Pluck("employee_id", &employeeIDs).Error
+	return employeeIDs, err
+}
+
+// ReplaceCustomerPreferredEmployees replaces the preferred employees of a customer
+func ReplaceCustomerPreferredEmployees(customerID uuid.UUID, employeeIDs []uuid.UUID) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Where("customer_id = ?", customerID).Delete(&model.CustomerPreferredEmployee{}).Error; err != nil {
+		return err
+	}
+	for _, employeeID := range employeeIDs {
+		link := model.CustomerPreferredEmployee{CustomerID: customerID, EmployeeID: employeeID}
+		if err := tx.Create(&link).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// MergeCustomers saves the surviving customer, moves the preferred employees of every duplicate
+// onto it and marks the duplicates as merged, all in one transaction
+func MergeCustomers(primary *model.Customer, duplicateIDs []uuid.UUID) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Save(primary).Error; err != nil {
+		return err
+	}
+
+	var links []model.CustomerPreferredEmployee
+	if err := tx.Where("customer_id IN ?", duplicateIDs).Find(&links).Error; err != nil {
+		return err
+	}
+	for _, link := range links {
+		moved := model.CustomerPreferredEmployee{CustomerID: primary.ID, EmployeeID: link.EmployeeID}
+		if err := tx.Where(moved).FirstOrCreate(&moved).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Where("customer_id IN ?", duplicateIDs).Delete(&model.CustomerPreferredEmployee{}).Error; err != nil {
+		return err
+	}
+
+	// Re-point anything previously merged into one of the duplicates
+	if err := tx.Model(&model.Customer{}).Where("merged_into_id IN ?", duplicateIDs).
+		Update("merged_into_id", primary.ID).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&model.Customer{}).Where("id IN ?", duplicateIDs).
+		Update("merged_into_id", primary.ID).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"services/shared/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// GetEmployeeHistory returns the versions of an employee, oldest first
+func GetEmployeeHistory(employeeID uuid.UUID) ([]model.EmployeeHistory, error) {
+	versions := []model.EmployeeHistory{}
+	err := db.DB.Where("employee_id = ?", employeeID).Order("valid_from ASC").Find(&versions).Error
+	return versions, err
+}
+
+// GetEmployeeHistories returns the versions of several employees, oldest first
+func GetEmployeeHistories(employeeIDs []uuid.UUID) ([]model.EmployeeHistory, error) {
+	versions := []model.EmployeeHistory{}
+	if len(employeeIDs) == 0 {
+		return versions, nil
+	}
+	err := db.DB.Where("employee_id IN ?", employeeIDs).Order("employee_id ASC, valid_from ASC").Find(&versions).Error
+	return versions, err
+}
+
+// GetCurrentEmployeeHistory returns the current version of an employee
+func GetCurrentEmployeeHistory(employeeID uuid.UUID) (model.EmployeeHistory, error) {
+	var version model.EmployeeHistory
+	err := db.DB.Where("employee_id = ? AND valid_until IS NULL", employeeID).First(&version).Error
+	return version, err
+}
+
+// UpdateEmployeeWithHistory saves an employee and records the new values of its tracked attributes
+// from effectiveFrom. The current version is closed the day before, or overwritten when it starts on
+// the same day. Employees without history first get a version with their previous values from the
+// day they were created.
+func UpdateEmployeeWithHistory(employee *model.Employee, previous model.Employee, effectiveFrom time.Time, changedBy string, reason string) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	// 1. The version the change follows
+	var current model.EmployeeHistory
+	err := tx.Where("employee_id = ? AND valid_until IS NULL", employee.ID).First(&current).Error
+	if err == gorm.ErrRecordNotFound {
+		created := previous.CreatedAt.UTC().Truncate(24 * time.Hour)
+		if created.Before(effectiveFrom) {
+			current = model.NewEmployeeHistory(previous, created)
+			if err := tx.Create(&current).Error; err != nil {
+				return err
+			}
+		}
+	} else if err != nil {
+		return err
+	}
+
+	// 2. Close it or overwrite it
+	version := model.NewEmployeeHistory(*employee, effectiveFrom)
+	version.ChangedBy = changedBy
+	version.Reason = reason
+	if current.ID != uuid.Nil && current.ValidFrom.Equal(effectiveFrom) {
+		if err := tx.Model(&current).Updates(map[string]interface{}{
+			"role":        version.Role,
+			"is_active":   version.IsActive,
+			"location_id": version.LocationID,
+			"pay_grade":   version.PayGrade,
+			"changed_by":  version.ChangedBy,
+			"reason":      version.Reason,
+		}).Error; err != nil {
+			return err
+		}
+	} else {
+		if current.ID != uuid.Nil {
+			dayBefore := effectiveFrom.AddDate(0, 0, -1)
+			if err := tx.Model(&current).Update("valid_until", dayBefore).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(&version).Error; err != nil {
+			return err
+		}
+	}
+
+	// 3. Save the employee
+	if err := tx.Save(employee).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// createEmployeeHistoryBaseline records the first version of a new employee, valid from today
+func createEmployeeHistoryBaseline(tx *gorm.DB, employee *model.Employee) error {
+	version := model.NewEmployeeHistory(*employee, time.Now().UTC().Truncate(24*time.Hour))
+	return tx.Create(&version).Error
+}
+
+// employeeVersionsAsOf selects the employees whose version valid on a date matches the conditions
+func employeeVersionsAsOf(asOf time.Time) *gorm.DB {
+	return db.DB.Model(&model.EmployeeHistory{}).
+		Select("employee_id").
+		Where("valid_from <= ? AND (valid_until IS NULL OR valid_until >= ?)", asOf, asOf)
+}
+
+// employeesWithHistory selects the employees that have any version
+func employeesWithHistory() *gorm.DB {
+	return db.DB.Model(&model.EmployeeHistory{}).Select("employee_id")
+}
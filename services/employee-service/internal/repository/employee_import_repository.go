@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"services/shared/db"
+
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// ImportEmployees creates the employees of an import with their custom field values and schedules
+// in one transaction, so either every row is imported or none is
+func ImportEmployees(imports []model.EmployeeImport) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	for _, item := range imports {
+		if err := tx.Create(item.Employee).Error; err != nil {
+			return err
+		}
+		if err := createEmployeeHistoryBaseline(tx, item.Employee); err != nil {
+			return err
+		}
+		if err := saveEmployeeCustomFieldValues(tx, item.Employee.ID, item.CustomFieldValues, nil); err != nil {
+			return err
+		}
+		for i := range item.Schedules {
+			item.Schedules[i].EmployeeID = item.Employee.ID
+			if err := tx.Create(&item.Schedules[i]).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit().Error
+}
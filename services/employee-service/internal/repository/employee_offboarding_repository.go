@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"services/shared/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// TerminateEmployee records the termination date of an employee and archives everything
+// scheduled after it in one transaction:
+//   - schedules starting after the date are deleted, open-ended or later ending schedules end on it
+//   - recurring breaks end on the date as well
+//   - one-time blocks starting after the date are deleted
+//   - pending and approved leave starting after the date is cancelled
+//   - open swaps and later approved swaps taken over by the employee are cancelled
+//   - claims on later open shifts are cancelled, reopening filled shifts
+//
+// Past schedules, time entries and timesheets are kept for reporting and payroll.
+func TerminateEmployee(employee *model.Employee, terminationDate time.Time, decidedBy string, now time.Time) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	dayAfter := terminationDate.AddDate(0, 0, 1)
+	note := "employee terminated"
+
+	// Schedules
+	if err := tx.Where("employee_id = ? AND valid_from > ?", employee.ID, terminationDate).
+		Delete(&model.Schedule{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&model.Schedule{}).
+		Where("employee_id = ? AND (valid_until IS NULL OR valid_until > ?)", employee.ID, terminationDate).
+		Update("valid_until", terminationDate).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&model.RecurringBreak{}).
+		Where("employee_id = ? AND (valid_until IS NULL OR valid_until > ?)", employee.ID, terminationDate).
+		Update("valid_until", terminationDate).Error; err != nil {
+		return err
+	}
+
+	// Leave, including the blocks of approved requests
+	if err := tx.Model(&model.LeaveRequest{}).
+		Where("employee_id = ? AND status IN ? AND start_date_time >= ?", employee.ID, []string{model.LeaveStatusPending, model.LeaveStatusApproved}, dayAfter).
+		Updates(map[string]interface{}{
+			"status":           model.LeaveStatusCancelled,
+			"decided_by":       decidedBy,
+			"decided_at":       now,
+			"decision_note":    note,
+			"onetime_block_id": nil,
+		}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("employee_id = ? AND start_date_time >= ?", employee.ID, dayAfter).
+		Delete(&model.OnetimeBlock{}).Error; err != nil {
+		return err
+	}
+
+	// Shift swaps
+	if err := cancelShiftSwapsAfterTermination(tx, employee.ID, terminationDate, decidedBy, note, now); err != nil {
+		return err
+	}
+
+	// Open shift claims
+	if err := cancelOpenShiftClaimsAfterTermination(tx, employee.ID, terminationDate, decidedBy, now); err != nil {
+		return err
+	}
+
+	employee.TerminationDate = &terminationDate
+	if err := tx.Model(employee).Update("termination_date", terminationDate).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// cancelShiftSwapsAfterTermination cancels the swaps of a leaving employee after their termination date.
+// When the employee had taken over an approved swap the offering employee gets the shift back.
+func cancelShiftSwapsAfterTermination(tx *gorm.DB, employeeID uuid.UUID, terminationDate time.Time, decidedBy string, note string, now time.Time) error {
+	var approved []model.ShiftSwap
+	if err := tx.Where("accepting_employee_id = ? AND status = ? AND shift_date > ?", employeeID, model.SwapStatusApproved, terminationDate).
+		Find(&approved).Error; err != nil {
+		return err
+	}
+	for _, swap := range approved {
+		if swap.CreatedBlockID != nil {
+			if err := tx.Delete(&model.OnetimeBlock{}, *swap.CreatedBlockID).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Model(&model.ShiftSwap{}).
+		Where("(offering_employee_id = ? OR accepting_employee_id = ?) AND shift_date > ?", employeeID, employeeID, terminationDate).
+		Where("status IN ? OR (status = ? AND accepting_employee_id = ?)",
+			[]string{model.SwapStatusOffered, model.SwapStatusAccepted}, model.SwapStatusApproved, employeeID).
+		Updates(map[string]interface{}{
+			"status":        model.SwapStatusCancelled,
+			"decided_by":    decidedBy,
+			"decided_at":    now,
+			"decision_note": note,
+		}).Error
+}
+
+// cancelOpenShiftClaimsAfterTermination cancels the claims of a leaving employee on open shifts after
+// their termination date. Filled shifts that lose an approved claim are opened again.
+func cancelOpenShiftClaimsAfterTermination(tx *gorm.DB, employeeID uuid.UUID, terminationDate time.Time, decidedBy string, now time.Time) error {
+	laterShifts := tx.Model(&model.OpenShift{}).Select("id").Where("shift_date > ?", terminationDate)
+
+	var approved []model.OpenShiftClaim
+	if err := tx.Where("employee_id = ? AND status = ? AND open_shift_id IN (?)", employeeID, model.ClaimStatusApproved, laterShifts).
+		Find(&approved).Error; err != nil {
+		return err
+	}
+	for _, claim := range approved {
+		if err := tx.Model(&model.OpenShift{}).
+			Where("id = ? AND status = ?", claim.OpenShiftID, model.OpenShiftStatusFilled).
+			Update("status", model.OpenShiftStatusOpen).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(&model.OpenShiftClaim{}).
+		Where("employee_id = ? AND status IN ? AND open_shift_id IN (?)", employeeID, []string{model.ClaimStatusPending, model.ClaimStatusApproved}, laterShifts).
+		Updates(map[string]interface{}{
+			"status":      model.ClaimStatusCancelled,
+			"schedule_id": nil,
+			"decided_by":  decidedBy,
+			"decided_at":  now,
+		}).Error
+}
+
+// GetEmployeeByIDUnscoped returns an employee by ID, including soft-deleted employees
+func GetEmployeeByIDUnscoped(id uuid.UUID) (model.Employee, error) {
+	var employee model.Employee
+	err := db.DB.Unscoped().Where("id = ?", id).First(&employee).Error
+	return employee, err
+}
+
+// RestoreEmployee undoes the soft deletion and termination of an employee.
+// Archived schedules stay closed; the returning employee needs new schedules. Recurring breaks
+// ended by the termination apply again, as they only take effect on scheduled days.
+// What deleting the employee released is not brought back: the auth user has to be linked
+// again, former direct reports keep the manager they moved up to, and teams they led stay
+// without a lead until one is assigned.
+func RestoreEmployee(employee *model.Employee) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if employee.TerminationDate != nil {
+		if err := tx.Model(&model.RecurringBreak{}).
+			Where("employee_id = ? AND valid_until = ?", employee.ID, *employee.TerminationDate).
+			Update("valid_until", nil).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Unscoped().Model(employee).Updates(map[string]interface{}{
+		"deleted_at":       nil,
+		"termination_date": nil,
+	}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	employee.DeletedAt = gorm.DeletedAt{}
+	employee.TerminationDate = nil
+	return nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"services/shared/db"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// GetEmployeePhoto returns the managed photo of an employee, or nil if they have none
+func GetEmployeePhoto(employeeID uuid.UUID) (*model.EmployeePhoto, error) {
+	var photos []model.EmployeePhoto
+	if err := db.DB.Where("employee_id = ?", employeeID).Limit(1).Find(&photos).Error; err != nil {
+		return nil, err
+	}
+	if len(photos) == 0 {
+		return nil, nil
+	}
+	return &photos[0], nil
+}
+
+// ReplaceEmployeePhoto stores a new photo for an employee, removing the previous record,
+// and points the employee's picture at it in one transaction
+func ReplaceEmployeePhoto(photo *model.EmployeePhoto, pictureURL string) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Where("employee_id = ?", photo.EmployeeID).Delete(&model.EmployeePhoto{}).Error; err != nil {
+		return err
+	}
+	if photo.ID == uuid.Nil {
+		photo.ID = uuid.New()
+	}
+	if err := tx.Create(photo).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&model.Employee{}).Where("id = ?", photo.EmployeeID).Update("picture", pictureURL).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// DeleteEmployeePhoto removes the photo record of an employee and clears their picture
+func DeleteEmployeePhoto(employeeID uuid.UUID) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Where("employee_id = ?", employeeID).Delete(&model.EmployeePhoto{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&model.Employee{}).Where("id = ?", employeeID).Update("picture", "").Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"services/shared/db"
+	"strings"
 
 	"github.com/salobook/services/employee-service/internal/model"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // CreateEmployee creates a new employee in the database
@@ -17,11 +19,102 @@ func CreateEmployee(employee *model.Employee) error {
 	return db.DB.Create(employee).Error
 }
 
-// GetAllEmployees returns all employees from the database
-func GetAllEmployees() ([]model.Employee, error) {
-	var employees []model.Employee
-	err := db.DB.Find(&employees).Error
-	return employees, err
+// employeeSortColumns maps the sort options of the employee directory to columns
+var employeeSortColumns = map[string]string{
+	"last_name":  "last_name",
+	"first_name": "first_name",
+	"email":      "email",
+	"role":       "role",
+	"created_at": "created_at",
+}
+
+// SearchEmployees returns a page of employees matching the filter and the total number of matches.
+// Former staff (terminated before filter.AsOf or soft-deleted) are only included when requested.
+func SearchEmployees(filter model.EmployeeFilter) ([]model.Employee, int64, error) {
+	query := db.DB.Model(&model.Employee{})
+	if filter.IncludeFormer {
+		query = query.Unscoped()
+	} else {
+		query = query.Where("termination_date IS NULL OR termination_date >= ?", filter.AsOf)
+	}
+
+	if filter.Search != "" {
+		// The full name covers first and last name on its own, see searchIndexes
+		like := containsPattern(strings.ToLower(filter.Search))
+		query = query.Where(`(LOWER(first_name || ' ' || last_name) LIKE ? ESCAPE '\' OR LOWER(email) LIKE ? ESCAPE '\')`,
+			like, like)
+	}
+	if filter.EmployeeIDs != nil {
+		query = query.Where("id IN ?", filter.EmployeeIDs)
+	}
+	if filter.Historic {
+		// Employees without history keep their current values for the whole time since they were created
+		query = query.Where("(id IN (?) OR (id NOT IN (?) AND created_at < ?))",
+			employeeVersionsAsOf(filter.AsOf), employeesWithHistory(), filter.AsOf.AddDate(0, 0, 1))
+		if filter.Role != "" {
+			query = query.Where("(id IN (?) OR (id NOT IN (?) AND role = ?))",
+				employeeVersionsAsOf(filter.AsOf).Where("role = ?", filter.Role), employeesWithHistory(), filter.Role)
+		}
+		if filter.IsActive != nil {
+			query = query.Where("(id IN (?) OR (id NOT IN (?) AND is_active = ?))",
+				employeeVersionsAsOf(filter.AsOf).Where("is_active = ?", *filter.IsActive), employeesWithHistory(), *filter.IsActive)
+		}
+	} else {
+		if filter.Role != "" {
+			query = query.Where("role = ?", filter.Role)
+		}
+		if filter.IsActive != nil {
+			query = query.Where("is_active = ?", *filter.IsActive)
+		}
+	}
+	if filter.Skill != "" {
+		query = query.Where("id IN (?)", db.DB.Model(&model.EmployeeSkill{}).
+			Select("employee_id").
+			Where("name = ? AND level >= ?", filter.Skill, filter.MinLevel).
+			Where("is_certification = ? OR expires_on IS NULL OR expires_on >= ?", false, filter.AsOf))
+	}
+
+	if filter.LocationID != nil {
+		scheduled := db.DB.Model(&model.Schedule{}).
+			Select("employee_id").
+			Where("location_id = ? AND (valid_until IS NULL OR valid_until >= ?)", *filter.LocationID, filter.AsOf)
+		if filter.Historic {
+			query = query.Where("(id IN (?) OR (id NOT IN (?) AND location_id = ?) OR id IN (?))",
+				employeeVersionsAsOf(filter.AsOf).Where("location_id = ?", *filter.LocationID), employeesWithHistory(), *filter.LocationID, scheduled)
+		} else {
+			query = query.Where("(location_id = ? OR id IN (?))", *filter.LocationID, scheduled)
+		}
+	}
+	for _, customField := range filter.CustomFields {
+		query = query.Where("id IN (?)", db.DB.Model(&model.EmployeeCustomFieldValue{}).
+			Select("employee_id").
+			Where("field_id = ? AND LOWER(value) = LOWER(?)", customField.FieldID, customField.Value))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	sortColumn, ok := employeeSortColumns[filter.Sort]
+	if !ok {
+		sortColumn = "last_name"
+	}
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+	order := sortColumn + " " + direction
+	if sortColumn == "last_name" {
+		order += ", first_name " + direction
+	}
+
+	employees := []model.Employee{}
+	err := query.Order(order + ", id ASC").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&employees).Error
+	return employees, total, err
 }
 
 // GetEmployeeByID returns an employee by ID
@@ -31,10 +124,24 @@ func GetEmployeeByID(id uuid.UUID) (model.Employee, error) {
 	return employee, err
 }
 
-// GetEmployeeByEmail returns an employee by email
+// GetEmployeeByUserID returns the employee linked to an auth user
+func GetEmployeeByUserID(userID uuid.UUID) (model.Employee, error) {
+	var employee model.Employee
+	err := db.DB.Where("user_id = ?", userID).First(&employee).Error
+	return employee, err
+}
+
+// SetEmployeeUserID links an employee to an auth user, or unlinks them when userID is nil
+func SetEmployeeUserID(employee *model.Employee, userID *uuid.UUID) error {
+	employee.UserID = userID
+	return db.DB.Model(employee).Update("user_id", userID).Error
+}
+
+// GetEmployeeByEmail returns an employee by email, including soft-deleted employees
+// because the unique email index covers them too
 func GetEmployeeByEmail(email string) (model.Employee, error) {
 	var employee model.Employee
-	err := db.DB.Where("email = ?", email).First(&employee).Error
+	err := db.DB.Unscoped().Where("email = ?", email).First(&employee).Error
 	return employee, err
 }
 
@@ -43,7 +150,46 @@ func UpdateEmployee(employee *model.Employee) error {
 	return db.DB.Save(employee).Error
 }
 
-// DeleteEmployee soft-deletes an employee
+// DeleteEmployee soft-deletes an employee, unlinks their auth user and removes them from customer preferences.
+// Their recurring breaks end on the termination date, as on termination, so a restore brings them back.
+// Their direct reports move up to their own manager and the teams they led are left without a lead.
+// The employee should be terminated first so nothing stays scheduled after they leave.
 func DeleteEmployee(id uuid.UUID) error {
-	return db.DB.Delete(&model.Employee{}, id).Error
-} 
\ No newline at end of file
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Where("employee_id = ?", id).Delete(&model.CustomerPreferredEmployee{}).Error; err != nil {
+		return err
+	}
+	terminationDate := gorm.Expr("(SELECT termination_date FROM employees WHERE id = ?)", id)
+	if err := tx.Model(&model.RecurringBreak{}).
+		Where("employee_id = ? AND (valid_until IS NULL OR valid_until > ?)", id, terminationDate).
+		Update("valid_until", terminationDate).Error; err != nil {
+		return err
+	}
+	// Release the user link so the account can be linked to another employee record
+	if err := tx.Model(&model.Employee{}).Where("id = ?", id).Update("user_id", nil).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&model.Employee{}).Where("manager_id = ?", id).
+		Update("manager_id", gorm.Expr("(SELECT manager_id FROM employees WHERE id = ?)", id)).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&model.Team{}).Where("lead_id = ?", id).Update("lead_id", nil).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(&model.Employee{}, id).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// GetActiveEmployees returns all active employees who have not left
+func GetActiveEmployees() ([]model.Employee, error) {
+	var employees []model.Employee
+	err := db.DB.Where("is_active = ?", true).
+		Where("termination_date IS NULL OR termination_date >= CURRENT_DATE").
+		Find(&employees).Error
+	return employees, err
+}
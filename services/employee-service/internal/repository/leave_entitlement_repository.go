@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"services/shared/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// CreateLeaveEntitlement creates a new leave entitlement in the database
+func CreateLeaveEntitlement(entitlement *model.LeaveEntitlement) error {
+	if entitlement.ID == uuid.Nil {
+		entitlement.ID = uuid.New()
+	}
+	return db.DB.Create(entitlement).Error
+}
+
+// CheckDuplicateLeaveEntitlement checks if the employee already has an entitlement for the leave type and year
+func CheckDuplicateLeaveEntitlement(employeeID uuid.UUID, leaveType string, year int, excludeID *uuid.UUID) (bool, error) {
+	query := db.DB.Model(&model.LeaveEntitlement{}).
+		Where("employee_id = ? AND leave_type = ? AND year = ?", employeeID, leaveType, year)
+
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// GetLeaveEntitlementByID returns a leave entitlement by ID
+func GetLeaveEntitlementByID(id uuid.UUID) (model.LeaveEntitlement, error) {
+	var entitlement model.LeaveEntitlement
+	err := db.DB.Where("id = ?", id).First(&entitlement).Error
+	return entitlement, err
+}
+
+// GetLeaveEntitlement returns the entitlement of an employee for a leave type and year, or nil if there is none
+func GetLeaveEntitlement(employeeID uuid.UUID, leaveType string, year int) (*model.LeaveEntitlement, error) {
+	var entitlements []model.LeaveEntitlement
+	err := db.DB.Where("employee_id = ? AND leave_type = ? AND year = ?", employeeID, leaveType, year).
+		Limit(1).Find(&entitlements).Error
+	if err != nil || len(entitlements) == 0 {
+		return nil, err
+	}
+	return &entitlements[0], nil
+}
+
+// GetFilteredLeaveEntitlements returns leave entitlements filtered by employee and year when provided
+func GetFilteredLeaveEntitlements(employeeID *uuid.UUID, year *int) ([]model.LeaveEntitlement, error) {
+	var entitlements []model.LeaveEntitlement
+	query := db.DB.Model(&model.LeaveEntitlement{})
+
+	if employeeID != nil {
+		query = query.Where("employee_id = ?", *employeeID)
+	}
+	if year != nil {
+		query = query.Where("year = ?", *year)
+	}
+
+	err := query.Order("year DESC, leave_type ASC").Find(&entitlements).Error
+	return entitlements, err
+}
+
+// UpdateLeaveEntitlement updates an existing leave entitlement
+func UpdateLeaveEntitlement(entitlement *model.LeaveEntitlement) error {
+	return db.DB.Save(entitlement).Error
+}
+
+// DeleteLeaveEntitlement deletes a leave entitlement by ID
+func DeleteLeaveEntitlement(id uuid.UUID) error {
+	return db.DB.Delete(&model.LeaveEntitlement{}, id).Error
+}
+
+// GetLeaveRequestsInPeriod returns leave requests of an employee with the given type and status
+// that overlap with the period [start, end)
+func GetLeaveRequestsInPeriod(employeeID uuid.UUID, leaveType string, status string, start time.Time, end time.Time) ([]model.LeaveRequest, error) {
+	var leaveRequests []model.LeaveRequest
+	err := db.DB.Where("employee_id = ? AND type = ? AND status = ? AND start_date_time < ? AND end_date_time > ?",
+		employeeID, leaveType, status, end, start).
+		Order("start_date_time ASC").
+		Find(&leaveRequests).Error
+	return leaveRequests, err
+}
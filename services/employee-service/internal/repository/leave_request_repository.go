@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"errors"
+	"services/shared/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"gorm.io/gorm/clause"
+)
+
+// ErrLeaveRequestStatusChanged is returned when a leave request changed status while it was being decided
+var ErrLeaveRequestStatusChanged = errors.New("leave request status changed")
+
+// ErrLeaveOverlapsBlock is returned when approved leave would overlap a one-time block of the employee
+var ErrLeaveOverlapsBlock = errors.New("leave overlaps a one-time block")
+
+// CreateLeaveRequest creates a new leave request in the database
+func CreateLeaveRequest(leaveRequest *model.LeaveRequest) error {
+	if leaveRequest.ID == uuid.Nil {
+		leaveRequest.ID = uuid.New()
+	}
+	return db.DB.Create(leaveRequest).Error
+}
+
+// GetLeaveRequestByID returns a leave request by ID
+func GetLeaveRequestByID(id uuid.UUID) (model.LeaveRequest, error) {
+	var leaveRequest model.LeaveRequest
+	err := db.DB.Where("id = ?", id).First(&leaveRequest).Error
+	return leaveRequest, err
+}
+
+// GetFilteredLeaveRequests returns leave requests based on filter criteria
+// If employeeID is provided, filters by employee
+// If employeeIDs is not nil, only returns requests of those employees
+// If status is provided, filters by status
+// If startDate and endDate are provided, returns requests that overlap with that period
+func GetFilteredLeaveRequests(employeeID *uuid.UUID, employeeIDs []uuid.UUID, status string, startDate *time.Time, endDate *time.Time) ([]model.LeaveRequest, error) {
+	var leaveRequests []model.LeaveRequest
+	query := db.DB.Model(&model.LeaveRequest{})
+
+	if employeeID != nil {
+		query = query.Where("employee_id = ?", *employeeID)
+	}
+	if employeeIDs != nil {
+		query = query.Where("employee_id IN ?", employeeIDs)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if startDate != nil {
+		query = query.Where("end_date_time >= ?", *startDate)
+	}
+	if endDate != nil {
+		query = query.Where("start_date_time <= ?", *endDate)
+	}
+
+	err := query.Order("start_date_time ASC").Find(&leaveRequests).Error
+	return leaveRequests, err
+}
+
+// GetOverlappingLeaveRequests returns pending or approved leave requests of an employee
+// that overlap with the given period
+func GetOverlappingLeaveRequests(employeeID uuid.UUID, startDateTime time.Time, endDateTime time.Time, excludeID *uuid.UUID) ([]model.LeaveRequest, error) {
+	var leaveRequests []model.LeaveRequest
+	query := db.DB.Where("employee_id = ? AND status IN ? AND start_date_time < ? AND end_date_time > ?",
+		employeeID, []string{model.LeaveStatusPending, model.LeaveStatusApproved}, endDateTime, startDateTime)
+
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	err := query.Order("start_date_time ASC").Find(&leaveRequests).Error
+	return leaveRequests, err
+}
+
+// GetOverlappingOnetimeBlocks returns the one-time blocks of an employee that overlap with the given period
+func GetOverlappingOnetimeBlocks(employeeID uuid.UUID, startDateTime time.Time, endDateTime time.Time, excludeID *uuid.UUID) ([]model.OnetimeBlock, error) {
+	var blocks []model.OnetimeBlock
+	query := db.DB.Where("employee_id = ? AND start_date_time < ? AND end_date_time > ?", employeeID, endDateTime, startDateTime)
+
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	err := query.Order("start_date_time ASC").Find(&blocks).Error
+	return blocks, err
+}
+
+// ApproveLeaveRequest marks a pending leave request as approved and creates its one-time block
+// in the same transaction. The request row is locked so concurrent decisions cannot both succeed.
+// The employee row is locked as well, so that two overlapping requests of the same employee cannot
+// both be approved; the second sees the block of the first and fails with ErrLeaveOverlapsBlock.
+func ApproveLeaveRequest(leaveRequest *model.LeaveRequest, block *model.OnetimeBlock) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	var current model.LeaveRequest
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", leaveRequest.ID).First(&current).Error; err != nil {
+		return err
+	}
+	if current.Status != model.LeaveStatusPending {
+		return ErrLeaveRequestStatusChanged
+	}
+
+	var employee model.Employee
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", current.EmployeeID).First(&employee).Error; err != nil {
+		return err
+	}
+	var overlapping int64
+	if err := tx.Model(&model.OnetimeBlock{}).
+		Where("employee_id = ? AND start_date_time < ? AND end_date_time > ?", current.EmployeeID, block.EndDateTime, block.StartDateTime).
+		Count(&overlapping).Error; err != nil {
+		return err
+	}
+	if overlapping > 0 {
+		return ErrLeaveOverlapsBlock
+	}
+
+	if block.ID == uuid.Nil {
+		block.ID = uuid.New()
+	}
+	if err := tx.Create(block).Error; err != nil {
+		return err
+	}
+
+	leaveRequest.Status = model.LeaveStatusApproved
+	leaveRequest.OnetimeBlockID = &block.ID
+	if err := tx.Save(leaveRequest).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// DecideLeaveRequest moves a leave request from one of the expected statuses to a new status.
+// When the request had a one-time block (approved leave being cancelled), the block is deleted
+// in the same transaction.
+func DecideLeaveRequest(leaveRequest *model.LeaveRequest, expectedStatuses []string) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	var current model.LeaveRequest
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", leaveRequest.ID).First(&current).Error; err != nil {
+		return err
+	}
+
+	allowed := false
+	for _, status := range expectedStatuses {
+		if current.Status == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return ErrLeaveRequestStatusChanged
+	}
+
+	if current.OnetimeBlockID != nil {
+		if err := tx.Delete(&model.OnetimeBlock{}, *current.OnetimeBlockID).Error; err != nil {
+			return err
+		}
+		leaveRequest.OnetimeBlockID = nil
+	}
+
+	if err := tx.Save(leaveRequest).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
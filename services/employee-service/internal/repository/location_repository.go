@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"services/shared/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// CreateLocation creates a new location in the database
+func CreateLocation(location *model.Location) error {
+	if location.ID == uuid.Nil {
+		location.ID = uuid.New()
+	}
+	return db.DB.Create(location).Error
+}
+
+// GetLocationByID returns a location by ID
+func GetLocationByID(id uuid.UUID) (model.Location, error) {
+	var location model.Location
+	err := db.DB.Where("id = ?", id).First(&location).Error
+	return location, err
+}
+
+// GetLocationByName returns a location by name
+func GetLocationByName(name string) (model.Location, error) {
+	var location model.Location
+	err := db.DB.Where("LOWER(name) = LOWER(?)", name).First(&location).Error
+	return location, err
+}
+
+// GetFilteredLocations returns all locations ordered by name, only active ones if requested
+func GetFilteredLocations(activeOnly bool) ([]model.Location, error) {
+	locations := []model.Location{}
+	query := db.DB.Model(&model.Location{})
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("name ASC").Find(&locations).Error
+	return locations, err
+}
+
+// UpdateLocation updates a location in the database
+func UpdateLocation(location *model.Location) error {
+	return db.DB.Save(location).Error
+}
+
+// CheckLocationInUse checks if any schedule or open shift still refers to a location
+func CheckLocationInUse(id uuid.UUID) (bool, error) {
+	var count int64
+	if err := db.DB.Model(&model.Schedule{}).Where("location_id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+	err := db.DB.Model(&model.OpenShift{}).Where("location_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// DeleteLocation deletes a location and its opening hours
+func DeleteLocation(id uuid.UUID) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Where("location_id = ?", id).Delete(&model.LocationOpeningHour{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(&model.Location{}, id).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// CreateLocationOpeningHour creates a new opening hour entry for a location
+func CreateLocationOpeningHour(openingHour *model.LocationOpeningHour) error {
+	if openingHour.ID == uuid.Nil {
+		openingHour.ID = uuid.New()
+	}
+	return db.DB.Create(openingHour).Error
+}
+
+// CheckOverlappingLocationOpeningHour checks if an opening hour entry overlaps with an existing one
+// of the same location. Hours crossing midnight are compared with the next day's hours as well.
+func CheckOverlappingLocationOpeningHour(
+	locationID uuid.UUID,
+	dayOfWeek int,
+	startTime time.Time,
+	endTime time.Time,
+	excludeID *uuid.UUID,
+) (bool, error) {
+	query := db.DB.Model(&model.LocationOpeningHour{}).
+		Where("location_id = ?", locationID)
+
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	existing, err := findLocationOpeningHours(query)
+	if err != nil {
+		return false, err
+	}
+
+	for _, openingHour := range existing {
+		if weeklyHoursOverlap(openingHour.DayOfWeek, openingHour.StartTime, openingHour.EndTime, dayOfWeek, startTime, endTime) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetLocationOpeningHourByID returns an opening hour entry by ID
+func GetLocationOpeningHourByID(id uuid.UUID) (model.LocationOpeningHour, error) {
+	openingHours, err := findLocationOpeningHours(db.DB.Model(&model.LocationOpeningHour{}).Where("id = ?", id))
+	if err != nil {
+		return model.LocationOpeningHour{}, err
+	}
+	if len(openingHours) == 0 {
+		return model.LocationOpeningHour{}, gorm.ErrRecordNotFound
+	}
+	return openingHours[0], nil
+}
+
+// GetLocationOpeningHours returns all opening hours of a location ordered by day and start time
+func GetLocationOpeningHours(locationID uuid.UUID) ([]model.LocationOpeningHour, error) {
+	return findLocationOpeningHours(db.DB.Model(&model.LocationOpeningHour{}).
+		Where("location_id = ?", locationID).
+		Order("day_of_week ASC, start_time ASC"))
+}
+
+// GetLocationOpeningHoursForDay returns the opening hours of a location on a specific day of week
+func GetLocationOpeningHoursForDay(locationID uuid.UUID, dayOfWeek int) ([]model.LocationOpeningHour, error) {
+	return findLocationOpeningHours(db.DB.Model(&model.LocationOpeningHour{}).
+		Where("location_id = ? AND day_of_week = ?", locationID, dayOfWeek).
+		Order("start_time ASC"))
+}
+
+// DeleteLocationOpeningHour deletes an opening hour entry
+func DeleteLocationOpeningHour(id uuid.UUID) error {
+	return db.DB.Delete(&model.LocationOpeningHour{}, id).Error
+}
+
+// findLocationOpeningHours runs an opening hours query and converts the time columns returned as strings
+func findLocationOpeningHours(query *gorm.DB) ([]model.LocationOpeningHour, error) {
+	type LocationOpeningHourDTO struct {
+		ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+		LocationID uuid.UUID `gorm:"type:uuid;not null"`
+		DayOfWeek  int       `gorm:"type:smallint;not null"`
+		StartTime  string    `gorm:"type:time without time zone;not null"`
+		EndTime    string    `gorm:"type:time without time zone;not null"`
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
+	}
+
+	var dtos []LocationOpeningHourDTO
+	if err := query.Select("*").Find(&dtos).Error; err != nil {
+		return nil, err
+	}
+
+	openingHours := make([]model.LocationOpeningHour, len(dtos))
+	for i, dto := range dtos {
+		startTime, err := time.Parse("15:04:05", dto.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		endTime, err := time.Parse("15:04:05", dto.EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		openingHours[i] = model.LocationOpeningHour{
+			ID:         dto.ID,
+			LocationID: dto.LocationID,
+			DayOfWeek:  dto.DayOfWeek,
+			StartTime:  startTime,
+			EndTime:    endTime,
+			CreatedAt:  dto.CreatedAt,
+			UpdatedAt:  dto.UpdatedAt,
+		}
+	}
+
+	return openingHours, nil
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"errors"
+	"services/shared/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrOpenShiftUnavailable is returned when an open shift is no longer open or has no places left
+var ErrOpenShiftUnavailable = errors.New("open shift is no longer available")
+
+// ErrOpenShiftClaimDecided is returned when a claim was already decided
+var ErrOpenShiftClaimDecided = errors.New("open shift claim was already decided")
+
+// CreateOpenShift creates a new open shift in the database
+func CreateOpenShift(openShift *model.OpenShift) error {
+	if openShift.ID == uuid.Nil {
+		openShift.ID = uuid.New()
+	}
+	return db.DB.Create(openShift).Error
+}
+
+// GetOpenShiftByID returns an open shift by ID, including its claims
+func GetOpenShiftByID(id uuid.UUID) (model.OpenShift, error) {
+	var openShift model.OpenShift
+	if err := db.DB.Where("id = ?", id).First(&openShift).Error; err != nil {
+		return openShift, err
+	}
+
+	claims, err := GetOpenShiftClaims(id)
+	openShift.Claims = claims
+	return openShift, err
+}
+
+// GetFilteredOpenShifts returns open shifts filtered by status, date, role and location when provided
+func GetFilteredOpenShifts(status string, date *time.Time, role string, locationID *uuid.UUID) ([]model.OpenShift, error) {
+	var openShifts []model.OpenShift
+	query := db.DB.Model(&model.OpenShift{})
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if date != nil {
+		query = query.Where("shift_date = ?", *date)
+	}
+	if role != "" {
+		query = query.Where("role = ?", role)
+	}
+	if locationID != nil {
+		query = query.Where("location_id = ?", *locationID)
+	}
+
+	err := query.Order("shift_date ASC, start_date_time ASC").Find(&openShifts).Error
+	return openShifts, err
+}
+
+// GetOpenShiftClaims returns the claims of an open shift in the order they were made
+func GetOpenShiftClaims(openShiftID uuid.UUID) ([]model.OpenShiftClaim, error) {
+	claims := []model.OpenShiftClaim{}
+	err := db.DB.Where("open_shift_id = ?", openShiftID).Order("created_at ASC").Find(&claims).Error
+	return claims, err
+}
+
+// GetOpenShiftClaimByID returns a claim of an open shift by ID
+func GetOpenShiftClaimByID(openShiftID uuid.UUID, claimID uuid.UUID) (model.OpenShiftClaim, error) {
+	var claim model.OpenShiftClaim
+	err := db.DB.Where("id = ? AND open_shift_id = ?", claimID, openShiftID).First(&claim).Error
+	return claim, err
+}
+
+// CheckExistingOpenShiftClaim checks if the employee already claimed the open shift
+func CheckExistingOpenShiftClaim(openShiftID uuid.UUID, employeeID uuid.UUID) (bool, error) {
+	var count int64
+	err := db.DB.Model(&model.OpenShiftClaim{}).
+		Where("open_shift_id = ? AND employee_id = ?", openShiftID, employeeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CreateOpenShiftClaim creates a pending claim on an open shift
+func CreateOpenShiftClaim(claim *model.OpenShiftClaim) error {
+	if claim.ID == uuid.Nil {
+		claim.ID = uuid.New()
+	}
+	return db.DB.Create(claim).Error
+}
+
+// FillOpenShiftClaim approves a claim and creates its schedule in one transaction.
+// The open shift row is locked so concurrent claims cannot exceed the headcount.
+// When newClaim is true the claim is inserted, otherwise the pending claim is updated.
+// Once the last place is filled the shift is marked filled and remaining pending claims are rejected.
+func FillOpenShiftClaim(claim *model.OpenShiftClaim, schedule *model.Schedule, newClaim bool) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	// 1. Lock the open shift and check that places remain
+	var openShift model.OpenShift
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", claim.OpenShiftID).First(&openShift).Error; err != nil {
+		return err
+	}
+	if openShift.Status != model.OpenShiftStatusOpen {
+		return ErrOpenShiftUnavailable
+	}
+	var approved int64
+	if err := tx.Model(&model.OpenShiftClaim{}).
+		Where("open_shift_id = ? AND status = ?", openShift.ID, model.ClaimStatusApproved).
+		Count(&approved).Error; err != nil {
+		return err
+	}
+	if int(approved) >= openShift.Headcount {
+		return ErrOpenShiftUnavailable
+	}
+
+	// 2. Create the schedule
+	if schedule.ID == uuid.Nil {
+		schedule.ID = uuid.New()
+	}
+	if err := tx.Create(schedule).Error; err != nil {
+		return err
+	}
+
+	// 3. Save the approved claim
+	claim.Status = model.ClaimStatusApproved
+	claim.ScheduleID = &schedule.ID
+	if newClaim {
+		if claim.ID == uuid.Nil {
+			claim.ID = uuid.New()
+		}
+		if err := tx.Create(claim).Error; err != nil {
+			return err
+		}
+	} else {
+		result := tx.Model(claim).Where("status = ?", model.ClaimStatusPending).
+			Select("Status", "ScheduleID", "DecidedBy", "DecidedAt").Updates(claim)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOpenShiftClaimDecided
+		}
+	}
+
+	// 4. Close the shift once every place is taken
+	if int(approved)+1 >= openShift.Headcount {
+		if err := tx.Model(&openShift).Update("status", model.OpenShiftStatusFilled).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.OpenShiftClaim{}).
+			Where("open_shift_id = ? AND status = ?", openShift.ID, model.ClaimStatusPending).
+			Updates(map[string]interface{}{"status": model.ClaimStatusRejected, "decided_by": claim.DecidedBy, "decided_at": claim.DecidedAt}).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// RejectOpenShiftClaim rejects a pending claim
+func RejectOpenShiftClaim(claim *model.OpenShiftClaim) error {
+	claim.Status = model.ClaimStatusRejected
+	result := db.DB.Model(claim).Where("status = ?", model.ClaimStatusPending).
+		Select("Status", "DecidedBy", "DecidedAt").Updates(claim)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOpenShiftClaimDecided
+	}
+	return nil
+}
+
+// CancelOpenShift cancels an open or filled shift, deletes the schedules created for its approved
+// claims and marks all pending or approved claims cancelled, in one transaction
+func CancelOpenShift(openShift *model.OpenShift, decidedBy string, decidedAt time.Time) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	var current model.OpenShift
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", openShift.ID).First(&current).Error; err != nil {
+		return err
+	}
+	if current.Status == model.OpenShiftStatusCancelled {
+		return ErrOpenShiftUnavailable
+	}
+
+	if err := deleteOpenShiftSchedules(tx, openShift.ID); err != nil {
+		return err
+	}
+	if err := tx.Model(&model.OpenShiftClaim{}).
+		Where("open_shift_id = ? AND status IN ?", openShift.ID, []string{model.ClaimStatusPending, model.ClaimStatusApproved}).
+		Updates(map[string]interface{}{"status": model.ClaimStatusCancelled, "schedule_id": nil, "decided_by": decidedBy, "decided_at": decidedAt}).Error; err != nil {
+		return err
+	}
+
+	openShift.Status = model.OpenShiftStatusCancelled
+	if err := tx.Model(openShift).Update("status", model.OpenShiftStatusCancelled).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// deleteOpenShiftSchedules deletes the schedules created for approved claims of an open shift
+func deleteOpenShiftSchedules(tx *gorm.DB, openShiftID uuid.UUID) error {
+	var scheduleIDs []uuid.UUID
+	if err := tx.Model(&model.OpenShiftClaim{}).
+		Where("open_shift_id = ? AND schedule_id IS NOT NULL", openShiftID).
+		Pluck("schedule_id", &scheduleIDs).Error; err != nil {
+		return err
+	}
+	if len(scheduleIDs) == 0 {
+		return nil
+	}
+	return tx.Where("id IN ?", scheduleIDs).Delete(&model.Schedule{}).Error
+}
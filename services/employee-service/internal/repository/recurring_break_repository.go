@@ -58,6 +58,7 @@ func CheckOverlappingRecurringBreak(
 		StartTime   string    `gorm:"type:time without time zone;not null"`
 		EndTime     string    `gorm:"type:time without time zone;not null"`
 		Reason      string    `gorm:"type:text;not null"`
+		ValidUntil  *time.Time `gorm:"type:date"`
 		CreatedAt   time.Time
 		UpdatedAt   time.Time
 	}
@@ -98,6 +99,7 @@ func GetRecurringBreakByID(id uuid.UUID) (model.RecurringBreak, error) {
 		StartTime   string    `gorm:"type:time without time zone;not null"`
 		EndTime     string    `gorm:"type:time without time zone;not null"`
 		Reason      string    `gorm:"type:text;not null"`
+		ValidUntil  *time.Time `gorm:"type:date"`
 		CreatedAt   time.Time
 		UpdatedAt   time.Time
 	}
@@ -119,6 +121,7 @@ func GetRecurringBreakByID(id uuid.UUID) (model.RecurringBreak, error) {
 		StartTime:  startTime,
 		EndTime:    endTime,
 		Reason:     recurringBreakDTO.Reason,
+		ValidUntil: recurringBreakDTO.ValidUntil,
 		CreatedAt:  recurringBreakDTO.CreatedAt,
 		UpdatedAt:  recurringBreakDTO.UpdatedAt,
 	}
@@ -136,6 +139,7 @@ func GetEmployeeRecurringBreaks(employeeID uuid.UUID) ([]model.RecurringBreak, e
 		StartTime   string    `gorm:"type:time without time zone;not null"`
 		EndTime     string    `gorm:"type:time without time zone;not null"`
 		Reason      string    `gorm:"type:text;not null"`
+		ValidUntil  *time.Time `gorm:"type:date"`
 		CreatedAt   time.Time
 		UpdatedAt   time.Time
 	}
@@ -159,6 +163,7 @@ func GetEmployeeRecurringBreaks(employeeID uuid.UUID) ([]model.RecurringBreak, e
 			StartTime:  startTime,
 			EndTime:    endTime,
 			Reason:     dto.Reason,
+			ValidUntil: dto.ValidUntil,
 			CreatedAt:  dto.CreatedAt,
 			UpdatedAt:  dto.UpdatedAt,
 		}
@@ -180,6 +185,7 @@ func GetFilteredRecurringBreaks(employeeID *uuid.UUID, dayOfWeek *int) ([]model.
 		StartTime   string    `gorm:"type:time without time zone;not null"` // String format from DB
 		EndTime     string    `gorm:"type:time without time zone;not null"` // String format from DB
 		Reason      string    `gorm:"type:text;not null"`
+		ValidUntil  *time.Time `gorm:"type:date"`
 		CreatedAt   time.Time
 		UpdatedAt   time.Time
 	}
@@ -215,6 +221,7 @@ func GetFilteredRecurringBreaks(employeeID *uuid.UUID, dayOfWeek *int) ([]model.
 			StartTime:  startTime,
 			EndTime:    endTime,
 			Reason:     dto.Reason,
+			ValidUntil: dto.ValidUntil,
 			CreatedAt:  dto.CreatedAt,
 			UpdatedAt:  dto.UpdatedAt,
 		}
@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"services/shared/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"gorm.io/gorm"
+)
+
+// CreateResource creates a new resource in the database
+func CreateResource(resource *model.Resource) error {
+	if resource.ID == uuid.Nil {
+		resource.ID = uuid.New()
+	}
+	return db.DB.Create(resource).Error
+}
+
+// GetResourceByID returns a resource by ID
+func GetResourceByID(id uuid.UUID) (model.Resource, error) {
+	var resource model.Resource
+	err := db.DB.Where("id = ?", id).First(&resource).Error
+	return resource, err
+}
+
+// GetResourceByName returns a resource by name
+func GetResourceByName(name string) (model.Resource, error) {
+	var resource model.Resource
+	err := db.DB.Where("name = ?", name).First(&resource).Error
+	return resource, err
+}
+
+// GetFilteredResources returns resources based on filter criteria
+// If resourceType is provided, filters by type
+// If activeOnly is true, excludes inactive resources
+func GetFilteredResources(resourceType string, activeOnly bool) ([]model.Resource, error) {
+	var resources []model.Resource
+	query := db.DB.Model(&model.Resource{})
+
+	if resourceType != "" {
+		query = query.Where("type = ?", resourceType)
+	}
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	err := query.Order("name ASC").Find(&resources).Error
+	return resources, err
+}
+
+// UpdateResource updates a resource in the database
+func UpdateResource(resource *model.Resource) error {
+	return db.DB.Save(resource).Error
+}
+
+// DeleteResource deletes a resource together with its opening hours and blocks
+func DeleteResource(id uuid.UUID) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Where("resource_id = ?", id).Delete(&model.ResourceOpeningHour{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("resource_id = ?", id).Delete(&model.ResourceBlock{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(&model.Resource{}, id).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// CreateResourceOpeningHour creates a new opening hour entry for a resource
+func CreateResourceOpeningHour(openingHour *model.ResourceOpeningHour) error {
+	if openingHour.ID == uuid.Nil {
+		openingHour.ID = uuid.New()
+	}
+	return db.DB.Create(openingHour).Error
+}
+
+// CheckOverlappingResourceOpeningHour checks if an opening hour entry overlaps with an existing one
+// for the same resource. Opening hours that end before they start run past midnight, so entries of
+// the neighbouring days are compared as well.
+func CheckOverlappingResourceOpeningHour(
+	resourceID uuid.UUID,
+	dayOfWeek int,
+	startTime time.Time,
+	endTime time.Time,
+	excludeID *uuid.UUID,
+) (bool, error) {
+	query := db.DB.Model(&model.ResourceOpeningHour{}).
+		Where("resource_id = ?", resourceID)
+
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	existing, err := findResourceOpeningHours(query)
+	if err != nil {
+		return false, err
+	}
+
+	for _, openingHour := range existing {
+		if weeklyHoursOverlap(openingHour.DayOfWeek, openingHour.StartTime, openingHour.EndTime, dayOfWeek, startTime, endTime) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// secondsPerWeek is the length of the weekly cycle opening hours repeat in
+const secondsPerWeek = 7 * 24 * 60 * 60
+
+// weeklyHoursOverlap reports whether two weekly time ranges overlap. A range whose end is not after
+// its start ends on the next day, and Saturday ranges can run into Sunday.
+func weeklyHoursOverlap(dayA int, startA, endA time.Time, dayB int, startB, endB time.Time) bool {
+	fromA, toA := weekSeconds(dayA, startA, endA)
+	fromB, toB := weekSeconds(dayB, startB, endB)
+	for _, shift := range []int{-secondsPerWeek, 0, secondsPerWeek} {
+		if fromA < toB+shift && fromB+shift < toA {
+			return true
+		}
+	}
+	return false
+}
+
+// weekSeconds returns a weekly time range as seconds since the start of Sunday
+func weekSeconds(day int, start, end time.Time) (int, int) {
+	startOfDay := start.Hour()*3600 + start.Minute()*60 + start.Second()
+	endOfDay := end.Hour()*3600 + end.Minute()*60 + end.Second()
+	if endOfDay <= startOfDay {
+		endOfDay += 24 * 3600
+	}
+	from := day*24*3600 + startOfDay
+	return from, from + endOfDay - startOfDay
+}
+
+// GetResourceOpeningHourByID returns an opening hour entry by ID
+func GetResourceOpeningHourByID(id uuid.UUID) (model.ResourceOpeningHour, error) {
+	openingHours, err := findResourceOpeningHours(db.DB.Model(&model.ResourceOpeningHour{}).Where("id = ?", id))
+	if err != nil {
+		return model.ResourceOpeningHour{}, err
+	}
+	if len(openingHours) == 0 {
+		return model.ResourceOpeningHour{}, gorm.ErrRecordNotFound
+	}
+	return openingHours[0], nil
+}
+
+// GetResourceOpeningHours returns all opening hours of a resource ordered by day and start time
+func GetResourceOpeningHours(resourceID uuid.UUID) ([]model.ResourceOpeningHour, error) {
+	return findResourceOpeningHours(db.DB.Model(&model.ResourceOpeningHour{}).
+		Where("resource_id = ?", resourceID).
+		Order("day_of_week ASC, start_time ASC"))
+}
+
+// GetResourceOpeningHoursForDay returns the opening hours of a resource on a specific day of week
+func GetResourceOpeningHoursForDay(resourceID uuid.UUID, dayOfWeek int) ([]model.ResourceOpeningHour, error) {
+	return findResourceOpeningHours(db.DB.Model(&model.ResourceOpeningHour{}).
+		Where("resource_id = ? AND day_of_week = ?", resourceID, dayOfWeek).
+		Order("start_time ASC"))
+}
+
+// DeleteResourceOpeningHour deletes an opening hour entry
+func DeleteResourceOpeningHour(id uuid.UUID) error {
+	return db.DB.Delete(&model.ResourceOpeningHour{}, id).Error
+}
+
+// CreateResourceBlock creates a new resource block in the database
+func CreateResourceBlock(block *model.ResourceBlock) error {
+	if block.ID == uuid.Nil {
+		block.ID = uuid.New()
+	}
+	return db.DB.Create(block).Error
+}
+
+// GetOverlappingResourceBlocks returns the blocks of a resource that overlap a date-time range
+func GetOverlappingResourceBlocks(
+	resourceID uuid.UUID,
+	startDateTime time.Time,
+	endDateTime time.Time,
+	excludeID *uuid.UUID,
+) ([]model.ResourceBlock, error) {
+	query := db.DB.Model(&model.ResourceBlock{}).
+		Where("resource_id = ? AND start_date_time < ? AND end_date_time > ?", resourceID, endDateTime, startDateTime)
+
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	var blocks []model.ResourceBlock
+	err := query.Find(&blocks).Error
+	return blocks, err
+}
+
+// GetResourceBlockByID returns a resource block by ID
+func GetResourceBlockByID(id uuid.UUID) (model.ResourceBlock, error) {
+	var block model.ResourceBlock
+	err := db.DB.Where("id = ?", id).First(&block).Error
+	return block, err
+}
+
+// GetFilteredResourceBlocks returns resource blocks based on filter criteria
+// If resourceID is provided, filters by resource
+// If startDate and endDate are provided, returns blocks that overlap with that period
+func GetFilteredResourceBlocks(resourceID *uuid.UUID, startDate *time.Time, endDate *time.Time) ([]model.ResourceBlock, error) {
+	var blocks []model.ResourceBlock
+	query := db.DB.Model(&model.ResourceBlock{})
+
+	if resourceID != nil {
+		query = query.Where("resource_id = ?", *resourceID)
+	}
+	if startDate != nil {
+		query = query.Where("end_date_time >= ?", *startDate)
+	}
+	if endDate != nil {
+		query = query.Where("start_date_time <= ?", *endDate)
+	}
+
+	err := query.Order("start_date_time ASC").Find(&blocks).Error
+	return blocks, err
+}
+
+// UpdateResourceBlock updates a resource block in the database
+func UpdateResourceBlock(block *model.ResourceBlock) error {
+	return db.DB.Save(block).Error
+}
+
+// DeleteResourceBlock deletes a resource block
+func DeleteResourceBlock(id uuid.UUID) error {
+	return db.DB.Delete(&model.ResourceBlock{}, id).Error
+}
+
+// findResourceOpeningHours runs the given query and converts the time-of-day
+// columns, which the database returns as strings, into time.Time values
+func findResourceOpeningHours(query *gorm.DB) ([]model.ResourceOpeningHour, error) {
+	type ResourceOpeningHourDTO struct {
+		ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+		ResourceID uuid.UUID `gorm:"type:uuid;not null"`
+		DayOfWeek  int       `gorm:"type:smallint;not null"`
+		StartTime  string    `gorm:"type:time without time zone;not null"`
+		EndTime    string    `gorm:"type:time without time zone;not null"`
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
+	}
+
+	var dtos []ResourceOpeningHourDTO
+	if err := query.Select("*").Find(&dtos).Error; err != nil {
+		return nil, err
+	}
+
+	openingHours := make([]model.ResourceOpeningHour, len(dtos))
+	for i, dto := range dtos {
+		startTime, err := time.Parse("15:04:05", dto.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		endTime, err := time.Parse("15:04:05", dto.EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		openingHours[i] = model.ResourceOpeningHour{
+			ID:         dto.ID,
+			ResourceID: dto.ResourceID,
+			DayOfWeek:  dto.DayOfWeek,
+			StartTime:  startTime,
+			EndTime:    endTime,
+			CreatedAt:  dto.CreatedAt,
+			UpdatedAt:  dto.UpdatedAt,
+		}
+	}
+
+	return openingHours, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func clock(hour, minute int) time.Time {
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestWeeklyHoursOverlap(t *testing.T) {
+	tests := []struct {
+		name         string
+		dayA         int
+		startA, endA time.Time
+		dayB         int
+		startB, endB time.Time
+		want         bool
+	}{
+		{"same day disjoint", 1, clock(9, 0), clock(12, 0), 1, clock(13, 0), clock(17, 0), false},
+		{"same day touching", 1, clock(9, 0), clock(12, 0), 1, clock(12, 0), clock(17, 0), false},
+		{"same day overlapping", 1, clock(9, 0), clock(12, 0), 1, clock(11, 0), clock(17, 0), true},
+		{"other day", 1, clock(9, 0), clock(17, 0), 2, clock(9, 0), clock(17, 0), false},
+		{"overnight overlaps next morning", 1, clock(22, 0), clock(2, 0), 2, clock(1, 0), clock(5, 0), true},
+		{"overnight ends before next morning", 1, clock(22, 0), clock(2, 0), 2, clock(2, 0), clock(5, 0), false},
+		{"overnight against same evening", 1, clock(22, 0), clock(2, 0), 1, clock(18, 0), clock(23, 0), true},
+		{"overnight against same morning", 1, clock(22, 0), clock(2, 0), 1, clock(1, 0), clock(5, 0), false},
+		{"saturday night into sunday", 6, clock(22, 0), clock(3, 0), 0, clock(2, 0), clock(4, 0), true},
+		{"sunday before saturday night ends", 0, clock(4, 0), clock(8, 0), 6, clock(22, 0), clock(3, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := weeklyHoursOverlap(tt.dayA, tt.startA, tt.endA, tt.dayB, tt.startB, tt.endB); got != tt.want {
+				t.Errorf("weeklyHoursOverlap() = %v, want %v", got, tt.want)
+			}
+			if got := weeklyHoursOverlap(tt.dayB, tt.startB, tt.endB, tt.dayA, tt.startA, tt.endA); got != tt.want {
+				t.Errorf("weeklyHoursOverlap() reversed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/salobook/services/employee-service/internal/model"
+	"gorm.io/gorm"
 )
 
 // CreateSchedule creates a new schedule in the database
@@ -20,9 +21,16 @@ func CreateSchedule(schedule *model.Schedule) error {
 
 // CheckDuplicateSchedule checks if there's already a schedule for the same employee
 // on the same day of week with exactly the same date range
-func CheckDuplicateSchedule(employeeID uuid.UUID, dayOfWeek int, validFrom time.Time, validUntil *time.Time, excludeID *uuid.UUID) (bool, error) {
+func CheckDuplicateSchedule(employeeID uuid.UUID, dayOfWeek int, validFrom time.Time, validUntil *time.Time, locationID *uuid.UUID, excludeID *uuid.UUID) (bool, error) {
 	query := db.DB.Model(&model.Schedule{}).Where("employee_id = ? AND day_of_week = ? AND valid_from = ?", 
 		employeeID, dayOfWeek, validFrom)
+
+	// Schedules at different locations on the same day are not duplicates
+	if locationID == nil {
+		query = query.Where("location_id IS NULL")
+	} else {
+		query = query.Where("location_id = ?", *locationID)
+	}
 	
 	// Handle validUntil based on whether it's null or not
 	if validUntil == nil {
@@ -47,6 +55,7 @@ func GetScheduleByID(id uuid.UUID) (model.Schedule, error) {
 	type ScheduleDTO struct {
 		ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
 		EmployeeID  uuid.UUID  `gorm:"type:uuid;not null"`
+		LocationID  *uuid.UUID `gorm:"type:uuid"`
 		DayOfWeek   int        `gorm:"type:smallint;not null"`
 		StartTime   string     `gorm:"type:time without time zone;not null"`
 		EndTime     string     `gorm:"type:time without time zone;not null"`
@@ -70,6 +79,7 @@ func GetScheduleByID(id uuid.UUID) (model.Schedule, error) {
 	schedule := model.Schedule{
 		ID:         scheduleDTO.ID,
 		EmployeeID: scheduleDTO.EmployeeID,
+		LocationID: scheduleDTO.LocationID,
 		DayOfWeek:  scheduleDTO.DayOfWeek,
 		StartTime:  startTime,
 		EndTime:    endTime,
@@ -90,7 +100,8 @@ func GetEmployeeSchedules(employeeID uuid.UUID) ([]model.Schedule, error) {
 	return schedules, err
 }
 
-// GetEmployeeSchedulesForDate returns schedules applicable for a specific date
+// GetEmployeeSchedulesForDate returns schedules applicable for a specific date, the most recent valid_from first.
+// An employee working at several locations on a day has one schedule for each.
 func GetEmployeeSchedulesForDate(employeeID uuid.UUID, date time.Time) ([]model.Schedule, error) {
 	dayOfWeek := int(date.Weekday())
 	
@@ -98,6 +109,7 @@ func GetEmployeeSchedulesForDate(employeeID uuid.UUID, date time.Time) ([]model.
 	type ScheduleDTO struct {
 		ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
 		EmployeeID  uuid.UUID  `gorm:"type:uuid;not null"`
+		LocationID  *uuid.UUID `gorm:"type:uuid"`
 		DayOfWeek   int        `gorm:"type:smallint;not null"`
 		StartTime   string     `gorm:"type:time without time zone;not null"`
 		EndTime     string     `gorm:"type:time without time zone;not null"`
@@ -116,7 +128,7 @@ func GetEmployeeSchedulesForDate(employeeID uuid.UUID, date time.Time) ([]model.
 	// 2. The date is on or after validFrom
 	// 3. The date is on or before validUntil (if validUntil is set)
 	err := db.DB.Model(&model.Schedule{}).Select("*").Where("employee_id = ? AND day_of_week = ? AND valid_from <= ? AND (valid_until IS NULL OR valid_until >= ?)",
-		employeeID, dayOfWeek, date, date).Find(&scheduleDTOs).Error
+		employeeID, dayOfWeek, date, date).Order("valid_from DESC").Find(&scheduleDTOs).Error
 	
 	if err != nil {
 		return nil, err
@@ -131,6 +143,7 @@ func GetEmployeeSchedulesForDate(employeeID uuid.UUID, date time.Time) ([]model.
 		schedules[i] = model.Schedule{
 			ID:         dto.ID,
 			EmployeeID: dto.EmployeeID,
+			LocationID: dto.LocationID,
 			DayOfWeek:  dto.DayOfWeek,
 			StartTime:  startTime,
 			EndTime:    endTime,
@@ -157,13 +170,15 @@ func GetActiveSchedules() ([]model.Schedule, error) {
 // GetFilteredSchedules returns schedules based on filter criteria
 // If employeeID is provided, filters by employee
 // If dayOfWeek is provided, filters by day of week
+// If locationID is provided, filters by location
 // If includeExpired is false, excludes schedules where validUntil is in the past
-func GetFilteredSchedules(employeeID *uuid.UUID, dayOfWeek *int, includeExpired bool) ([]model.Schedule, error) {
+func GetFilteredSchedules(employeeID *uuid.UUID, dayOfWeek *int, locationID *uuid.UUID, includeExpired bool) ([]model.Schedule, error) {
 	// Create a custom DTO struct to handle the string time formats
 	// the problem is that db returns the string time but we need to return the time.Time
 	type ScheduleDTO struct {
 		ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
 		EmployeeID  uuid.UUID  `gorm:"type:uuid;not null"`
+		LocationID  *uuid.UUID `gorm:"type:uuid"`
 		DayOfWeek   int        `gorm:"type:smallint;not null"`
 		StartTime   string     `gorm:"type:time without time zone;not null"`
 		EndTime     string     `gorm:"type:time without time zone;not null"`
@@ -186,6 +201,11 @@ func GetFilteredSchedules(employeeID *uuid.UUID, dayOfWeek *int, includeExpired
 	if dayOfWeek != nil {
 		query = query.Where("day_of_week = ?", *dayOfWeek)
 	}
+
+	// Filter by location if provided
+	if locationID != nil {
+		query = query.Where("location_id = ?", *locationID)
+	}
 	
 	// Filter out expired schedules unless includeExpired is true
 	if !includeExpired {
@@ -207,6 +227,7 @@ func GetFilteredSchedules(employeeID *uuid.UUID, dayOfWeek *int, includeExpired
 		schedules[i] = model.Schedule{
 			ID:         dto.ID,
 			EmployeeID: dto.EmployeeID,
+			LocationID: dto.LocationID,
 			DayOfWeek:  dto.DayOfWeek,
 			StartTime:  startTime,
 			EndTime:    endTime,
@@ -229,4 +250,86 @@ func UpdateSchedule(schedule *model.Schedule) error {
 // DeleteSchedule deletes a schedule
 func DeleteSchedule(id uuid.UUID) error {
 	return db.DB.Delete(&model.Schedule{}, id).Error
-} 
\ No newline at end of file
+}
+
+// GetEmployeeSchedulesAtOtherLocations returns the schedules of an employee whose date range overlaps
+// the period and whose location differs from the given one. Schedules without a location count as a
+// location of their own, so they are returned for a location and the other way round. The period is
+// widened by a day on each side so shifts crossing midnight are included.
+func GetEmployeeSchedulesAtOtherLocations(employeeID uuid.UUID, locationID *uuid.UUID, validFrom time.Time, validUntil *time.Time, excludeID *uuid.UUID) ([]model.Schedule, error) {
+	var location interface{}
+	if locationID != nil {
+		location = *locationID
+	}
+	query := db.DB.Model(&model.Schedule{}).
+		Where("employee_id = ? AND location_id IS DISTINCT FROM ?", employeeID, location).
+		Where("(valid_until IS NULL OR valid_until >= ?)", validFrom.AddDate(0, 0, -1))
+	if validUntil != nil {
+		query = query.Where("valid_from <= ?", validUntil.AddDate(0, 0, 1))
+	}
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+	return findSchedules(query)
+}
+
+// GetLocationEmployeeIDsForDate returns the employees with a schedule at a location on a date
+func GetLocationEmployeeIDsForDate(locationID uuid.UUID, date time.Time) ([]uuid.UUID, error) {
+	employeeIDs := []uuid.UUID{}
+	err := db.DB.Model(&model.Schedule{}).
+		Distinct("employee_id").
+		Where("location_id = ? AND day_of_week = ? AND valid_from <= ? AND (valid_until IS NULL OR valid_until >= ?)",
+			locationID, int(date.Weekday()), date, date).
+		Pluck("employee_id", &employeeIDs).Error
+	return employeeIDs, err
+}
+
+// findSchedules runs a schedule query and converts the time columns returned as strings
+func findSchedules(query *gorm.DB) ([]model.Schedule, error) {
+	type ScheduleDTO struct {
+		ID         uuid.UUID  `gorm:"type:uuid;primaryKey"`
+		EmployeeID uuid.UUID  `gorm:"type:uuid;not null"`
+		LocationID *uuid.UUID `gorm:"type:uuid"`
+		DayOfWeek  int        `gorm:"type:smallint;not null"`
+		StartTime  string     `gorm:"type:time without time zone;not null"`
+		EndTime    string     `gorm:"type:time without time zone;not null"`
+		ValidFrom  time.Time  `gorm:"type:date;not null"`
+		ValidUntil *time.Time `gorm:"type:date"`
+		Notes      string     `gorm:"type:text"`
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
+	}
+
+	var dtos []ScheduleDTO
+	if err := query.Select("*").Find(&dtos).Error; err != nil {
+		return nil, err
+	}
+
+	schedules := make([]model.Schedule, len(dtos))
+	for i, dto := range dtos {
+		startTime, err := time.Parse("15:04:05", dto.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		endTime, err := time.Parse("15:04:05", dto.EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		schedules[i] = model.Schedule{
+			ID:         dto.ID,
+			EmployeeID: dto.EmployeeID,
+			LocationID: dto.LocationID,
+			DayOfWeek:  dto.DayOfWeek,
+			StartTime:  startTime,
+			EndTime:    endTime,
+			ValidFrom:  dto.ValidFrom,
+			ValidUntil: dto.ValidUntil,
+			Notes:      dto.Notes,
+			CreatedAt:  dto.CreatedAt,
+			UpdatedAt:  dto.UpdatedAt,
+		}
+	}
+
+	return schedules, nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"strings"
+
+	"services/shared/db"
+)
+
+// likeEscaper escapes the LIKE wildcards and the escape character itself
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns a LIKE pattern that matches term anywhere in a value. Wildcards in term
+// are matched literally, so the condition must use ESCAPE '\'.
+func containsPattern(term string) string {
+	return "%" + likeEscaper.Replace(term) + "%"
+}
+
+// searchIndexes are trigram indexes for the substring searches. A b-tree index cannot serve
+// LIKE '%term%', a GIN index with gin_trgm_ops can.
+var searchIndexes = []string{
+	"CREATE INDEX IF NOT EXISTS idx_customers_name_trgm ON customers USING gin (LOWER(first_name || ' ' || last_name) gin_trgm_ops)",
+	"CREATE INDEX IF NOT EXISTS idx_customers_email_trgm ON customers USING gin (LOWER(email) gin_trgm_ops)",
+	"CREATE INDEX IF NOT EXISTS idx_customers_phone_trgm ON customers USING gin (phone gin_trgm_ops)",
+	"CREATE INDEX IF NOT EXISTS idx_employees_name_trgm ON employees USING gin (LOWER(first_name || ' ' || last_name) gin_trgm_ops)",
+	"CREATE INDEX IF NOT EXISTS idx_employees_email_trgm ON employees USING gin (LOWER(email) gin_trgm_ops)",
+}
+
+// CreateSearchIndexes enables pg_trgm and creates the search indexes. Searches work without them,
+// only slower, so callers may treat an error as a warning.
+func CreateSearchIndexes() error {
+	if err := db.EnableExtension("pg_trgm"); err != nil {
+		return err
+	}
+	for _, statement := range searchIndexes {
+		if err := db.DB.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
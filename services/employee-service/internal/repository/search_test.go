@@ -0,0 +1,22 @@
+package repository
+
+import "testing"
+
+func TestContainsPattern(t *testing.T) {
+	tests := []struct {
+		term string
+		want string
+	}{
+		{"anna", `%anna%`},
+		{"100%", `%100\%%`},
+		{"first_name", `%first\_name%`},
+		{`back\slash`, `%back\\slash%`},
+		{"", `%%`},
+	}
+
+	for _, tt := range tests {
+		if got := containsPattern(tt.term); got != tt.want {
+			t.Errorf("containsPattern(%q) = %q, want %q", tt.term, got, tt.want)
+		}
+	}
+}
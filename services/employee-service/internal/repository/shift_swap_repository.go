@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"errors"
+	"services/shared/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrShiftSwapStatusChanged is returned when a shift swap changed status while it was being updated
+var ErrShiftSwapStatusChanged = errors.New("shift swap status changed")
+
+// CreateShiftSwap creates a new shift swap offer in the database
+func CreateShiftSwap(swap *model.ShiftSwap) error {
+	if swap.ID == uuid.Nil {
+		swap.ID = uuid.New()
+	}
+	return db.DB.Create(swap).Error
+}
+
+// GetShiftSwapByID returns a shift swap by ID
+func GetShiftSwapByID(id uuid.UUID) (model.ShiftSwap, error) {
+	var swap model.ShiftSwap
+	err := db.DB.Where("id = ?", id).First(&swap).Error
+	return swap, err
+}
+
+// GetFilteredShiftSwaps returns shift swaps filtered by status and by employee when provided.
+// The employee filters match both the offering and the accepting employee.
+func GetFilteredShiftSwaps(employeeID *uuid.UUID, employeeIDs []uuid.UUID, status string) ([]model.ShiftSwap, error) {
+	var swaps []model.ShiftSwap
+	query := db.DB.Model(&model.ShiftSwap{})
+
+	if employeeID != nil {
+		query = query.Where("offering_employee_id = ? OR accepting_employee_id = ?", *employeeID, *employeeID)
+	}
+	if employeeIDs != nil {
+		query = query.Where("offering_employee_id IN ? OR accepting_employee_id IN ?", employeeIDs, employeeIDs)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	err := query.Order("shift_date ASC, start_date_time ASC").Find(&swaps).Error
+	return swaps, err
+}
+
+// CheckOpenShiftSwap checks if the shift of an employee on a date is already offered or accepted
+func CheckOpenShiftSwap(offeringEmployeeID uuid.UUID, shiftDate time.Time) (bool, error) {
+	var count int64
+	err := db.DB.Model(&model.ShiftSwap{}).
+		Where("offering_employee_id = ? AND shift_date = ? AND status IN ?",
+			offeringEmployeeID, shiftDate, []string{model.SwapStatusOffered, model.SwapStatusAccepted}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateShiftSwapStatus saves a shift swap if its stored status is still one of the expected statuses
+func UpdateShiftSwapStatus(swap *model.ShiftSwap, expectedStatuses []string) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := lockShiftSwap(tx, swap.ID, expectedStatuses); err != nil {
+		return err
+	}
+	if err := tx.Save(swap).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// ApproveShiftSwap creates the one-time schedule of the accepting employee and the block of the
+// offering employee, and marks the swap approved, all in one transaction
+func ApproveShiftSwap(swap *model.ShiftSwap, schedule *model.Schedule, block *model.OnetimeBlock) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := lockShiftSwap(tx, swap.ID, []string{model.SwapStatusAccepted}); err != nil {
+		return err
+	}
+
+	if schedule.ID == uuid.Nil {
+		schedule.ID = uuid.New()
+	}
+	if err := tx.Create(schedule).Error; err != nil {
+		return err
+	}
+	if block.ID == uuid.Nil {
+		block.ID = uuid.New()
+	}
+	if err := tx.Create(block).Error; err != nil {
+		return err
+	}
+
+	swap.Status = model.SwapStatusApproved
+	swap.CreatedScheduleID = &schedule.ID
+	swap.CreatedBlockID = &block.ID
+	if err := tx.Save(swap).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// lockShiftSwap locks the shift swap row and checks its stored status
+func lockShiftSwap(tx *gorm.DB, id uuid.UUID, expectedStatuses []string) error {
+	var current model.ShiftSwap
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&current).Error; err != nil {
+		return err
+	}
+	for _, status := range expectedStatuses {
+		if current.Status == status {
+			return nil
+		}
+	}
+	return ErrShiftSwapStatusChanged
+}
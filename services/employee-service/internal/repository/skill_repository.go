@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"services/shared/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// CreateEmployeeSkill creates a new employee skill in the database
+func CreateEmployeeSkill(skill *model.EmployeeSkill) error {
+	if skill.ID == uuid.Nil {
+		skill.ID = uuid.New()
+	}
+	return db.DB.Create(skill).Error
+}
+
+// GetEmployeeSkills returns the skills of an employee ordered by name
+func GetEmployeeSkills(employeeID uuid.UUID) ([]model.EmployeeSkill, error) {
+	skills := []model.EmployeeSkill{}
+	err := db.DB.Where("employee_id = ?", employeeID).Order("name ASC").Find(&skills).Error
+	return skills, err
+}
+
+// GetEmployeeSkillByID returns a skill of an employee by ID
+func GetEmployeeSkillByID(employeeID uuid.UUID, id uuid.UUID) (model.EmployeeSkill, error) {
+	var skill model.EmployeeSkill
+	err := db.DB.Where("id = ? AND employee_id = ?", id, employeeID).First(&skill).Error
+	return skill, err
+}
+
+// CheckDuplicateEmployeeSkill checks if an employee already has a skill with the name
+func CheckDuplicateEmployeeSkill(employeeID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error) {
+	var count int64
+	query := db.DB.Model(&model.EmployeeSkill{}).Where("employee_id = ? AND name = ?", employeeID, name)
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateEmployeeSkill updates an employee skill in the database
+func UpdateEmployeeSkill(skill *model.EmployeeSkill) error {
+	return db.DB.Save(skill).Error
+}
+
+// DeleteEmployeeSkill deletes an employee skill by ID
+func DeleteEmployeeSkill(id uuid.UUID) error {
+	return db.DB.Delete(&model.EmployeeSkill{}, id).Error
+}
+
+// GetEmployeesWithSkill returns the current employees holding a skill at minLevel or above.
+// Certifications only count while they have not expired on asOf.
+func GetEmployeesWithSkill(name string, minLevel int, asOf time.Time) ([]model.Employee, error) {
+	employees := []model.Employee{}
+	err := db.DB.Where("termination_date IS NULL OR termination_date >= ?", asOf).
+		Where("id IN (?)", db.DB.Model(&model.EmployeeSkill{}).
+		Select("employee_id").
+		Where("name = ? AND level >= ?", name, minLevel).
+		Where("is_certification = ? OR expires_on IS NULL OR expires_on >= ?", false, asOf)).
+		Find(&employees).Error
+	return employees, err
+}
+
+// GetExpiringCertifications returns certifications expiring on or before the given date, soonest first
+func GetExpiringCertifications(before time.Time) ([]model.EmployeeSkill, error) {
+	skills := []model.EmployeeSkill{}
+	err := db.DB.Where("is_certification = ? AND expires_on IS NOT NULL AND expires_on <= ?", true, before).
+		Order("expires_on ASC").
+		Find(&skills).Error
+	return skills, err
+}
+
+// CreateRoleRequirement creates a new role requirement in the database
+func CreateRoleRequirement(requirement *model.RoleRequirement) error {
+	if requirement.ID == uuid.Nil {
+		requirement.ID = uuid.New()
+	}
+	return db.DB.Create(requirement).Error
+}
+
+// GetRoleRequirements returns the requirements of a role, or of all roles when role is empty
+func GetRoleRequirements(role string) ([]model.RoleRequirement, error) {
+	requirements := []model.RoleRequirement{}
+	query := db.DB.Model(&model.RoleRequirement{})
+	if role != "" {
+		query = query.Where("role = ?", role)
+	}
+	err := query.Order("role ASC, skill_name ASC").Find(&requirements).Error
+	return requirements, err
+}
+
+// GetRoleRequirementByID returns a role requirement by ID
+func GetRoleRequirementByID(id uuid.UUID) (model.RoleRequirement, error) {
+	var requirement model.RoleRequirement
+	err := db.DB.Where("id = ?", id).First(&requirement).Error
+	return requirement, err
+}
+
+// CheckDuplicateRoleRequirement checks if a role already requires a skill
+func CheckDuplicateRoleRequirement(role string, skillName string) (bool, error) {
+	var count int64
+	err := db.DB.Model(&model.RoleRequirement{}).Where("role = ? AND skill_name = ?", role, skillName).Count(&count).Error
+	return count > 0, err
+}
+
+// DeleteRoleRequirement deletes a role requirement by ID
+func DeleteRoleRequirement(id uuid.UUID) error {
+	return db.DB.Delete(&model.RoleRequirement{}, id).Error
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"services/shared/db"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// maxHierarchyDepth bounds the recursive reporting line and team queries.
+// Assignments refuse cycles, the bound only keeps a corrupted hierarchy from looping.
+const maxHierarchyDepth = 32
+
+// CreateTeam creates a new team in the database
+func CreateTeam(team *model.Team) error {
+	if team.ID == uuid.Nil {
+		team.ID = uuid.New()
+	}
+	return db.DB.Create(team).Error
+}
+
+// GetAllTeams returns all teams ordered by name
+func GetAllTeams() ([]model.Team, error) {
+	teams := []model.Team{}
+	err := db.DB.Order("name ASC").Find(&teams).Error
+	return teams, err
+}
+
+// GetTeamByID returns a team by ID
+func GetTeamByID(id uuid.UUID) (model.Team, error) {
+	var team model.Team
+	err := db.DB.Where("id = ?", id).First(&team).Error
+	return team, err
+}
+
+// CheckDuplicateTeam checks if another team already has the name
+func CheckDuplicateTeam(name string, excludeID *uuid.UUID) (bool, error) {
+	var count int64
+	query := db.DB.Model(&model.Team{}).Where("LOWER(name) = LOWER(?)", name)
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateTeam updates a team in the database
+func UpdateTeam(team *model.Team) error {
+	return db.DB.Save(team).Error
+}
+
+// DeleteTeam deletes a team and moves its sub-teams and members up to its parent team
+func DeleteTeam(team model.Team) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Model(&model.Team{}).Where("parent_id = ?", team.ID).Update("parent_id", team.ParentID).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Model(&model.Employee{}).Where("team_id = ?", team.ID).Update("team_id", team.ParentID).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(&model.Team{}, team.ID).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// GetSubTeamIDs returns the IDs of all teams nested below a team, at any depth
+func GetSubTeamIDs(teamID uuid.UUID) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	err := db.DB.Raw(`
+		WITH RECURSIVE sub_teams AS (
+			SELECT id, 1 AS depth FROM teams WHERE parent_id = ?
+			UNION
+			SELECT t.id, s.depth + 1 FROM teams t JOIN sub_teams s ON t.parent_id = s.id WHERE s.depth < ?
+		)
+		SELECT DISTINCT id FROM sub_teams`, teamID, maxHierarchyDepth).
+		Scan(&ids).Error
+	return ids, err
+}
+
+// GetTeamMembers returns the current members of the given teams ordered by name
+func GetTeamMembers(teamIDs []uuid.UUID) ([]model.Employee, error) {
+	employees := []model.Employee{}
+	err := db.DB.Where("team_id IN ?", teamIDs).
+		Where("termination_date IS NULL OR termination_date >= CURRENT_DATE").
+		Order("last_name ASC, first_name ASC").
+		Find(&employees).Error
+	return employees, err
+}
+
+// SetEmployeeTeam moves an employee into a team, or out of any team when teamID is nil
+func SetEmployeeTeam(employee *model.Employee, teamID *uuid.UUID) error {
+	employee.TeamID = teamID
+	return db.DB.Model(employee).Update("team_id", teamID).Error
+}
+
+// SetEmployeeManager sets the manager of an employee, or clears it when managerID is nil
+func SetEmployeeManager(employee *model.Employee, managerID *uuid.UUID) error {
+	employee.ManagerID = managerID
+	return db.DB.Model(employee).Update("manager_id", managerID).Error
+}
+
+// GetReports returns the current employees reporting to a manager down to maxDepth levels,
+// with the number of levels between them and the manager, ordered by depth and name
+func GetReports(managerID uuid.UUID, maxDepth int) ([]model.EmployeeReport, error) {
+	var depths []struct {
+		ID    uuid.UUID
+		Depth int
+	}
+	err := db.DB.Raw(`
+		WITH RECURSIVE reports AS (
+			SELECT id, 1 AS depth FROM employees WHERE manager_id = ? AND deleted_at IS NULL
+			UNION
+			SELECT e.id, r.depth + 1 FROM employees e JOIN reports r ON e.manager_id = r.id
+			WHERE e.deleted_at IS NULL AND r.depth < ?
+		)
+		SELECT id, MIN(depth) AS depth FROM reports GROUP BY id`, managerID, maxDepth).
+		Scan(&depths).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []model.EmployeeReport{}
+	if len(depths) == 0 {
+		return reports, nil
+	}
+	depthByID := make(map[uuid.UUID]int, len(depths))
+	ids := make([]uuid.UUID, 0, len(depths))
+	for _, d := range depths {
+		depthByID[d.ID] = d.Depth
+		ids = append(ids, d.ID)
+	}
+
+	var employees []model.Employee
+	if err := db.DB.Where("id IN ?", ids).
+		Where("termination_date IS NULL OR termination_date >= CURRENT_DATE").
+		Order("last_name ASC, first_name ASC").
+		Find(&employees).Error; err != nil {
+		return nil, err
+	}
+	for _, employee := range employees {
+		reports = append(reports, model.EmployeeReport{Employee: employee, Depth: depthByID[employee.ID]})
+	}
+	// Keep the name order within each level
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Depth < reports[j].Depth
+	})
+	return reports, nil
+}
+
+// GetManagedEmployeeIDs returns the IDs of every employee a manager manages: their direct and indirect
+// reports and the members of the teams they lead, including sub-teams. The manager is never included.
+// Terminated employees are included so their past requests stay in scope.
+func GetManagedEmployeeIDs(managerID uuid.UUID) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	err := db.DB.Raw(`
+		WITH RECURSIVE reports AS (
+			SELECT id, 1 AS depth FROM employees WHERE manager_id = @manager AND deleted_at IS NULL
+			UNION
+			SELECT e.id, r.depth + 1 FROM employees e JOIN reports r ON e.manager_id = r.id
+			WHERE e.deleted_at IS NULL AND r.depth < @max_depth
+		), led_teams AS (
+			SELECT id, 1 AS depth FROM teams WHERE lead_id = @manager
+			UNION
+			SELECT t.id, l.depth + 1 FROM teams t JOIN led_teams l ON t.parent_id = l.id WHERE l.depth < @max_depth
+		)
+		SELECT id FROM reports WHERE id <> @manager
+		UNION
+		SELECT id FROM employees
+		WHERE team_id IN (SELECT id FROM led_teams) AND deleted_at IS NULL AND id <> @manager`,
+		map[string]interface{}{"manager": managerID, "max_depth": maxHierarchyDepth}).
+		Scan(&ids).Error
+	if ids == nil {
+		// An empty scope must still filter lists down to nothing
+		ids = []uuid.UUID{}
+	}
+	return ids, err
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"errors"
+	"services/shared/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// ErrTimeEntryChanged is returned when the open time entry or break changed while it was being closed
+var ErrTimeEntryChanged = errors.New("time entry changed")
+
+// CreateTimeEntry creates a new (open) time entry in the database
+func CreateTimeEntry(entry *model.TimeEntry) error {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	return db.DB.Create(entry).Error
+}
+
+// GetOpenTimeEntry returns the open time entry of an employee, or nil if they are not clocked in
+func GetOpenTimeEntry(employeeID uuid.UUID) (*model.TimeEntry, error) {
+	var entries []model.TimeEntry
+	if err := db.DB.Where("employee_id = ? AND clock_out IS NULL", employeeID).Limit(1).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	if err := loadTimeEntryBreaks(entries); err != nil {
+		return nil, err
+	}
+	return &entries[0], nil
+}
+
+// GetTimeEntryByID returns a time entry by ID, including its breaks
+func GetTimeEntryByID(id uuid.UUID) (model.TimeEntry, error) {
+	var entry model.TimeEntry
+	if err := db.DB.Where("id = ?", id).First(&entry).Error; err != nil {
+		return entry, err
+	}
+	entries := []model.TimeEntry{entry}
+	err := loadTimeEntryBreaks(entries)
+	return entries[0], err
+}
+
+// GetTimeEntriesForPeriod returns the time entries of an employee that were clocked in during [start, end),
+// including their breaks. If employeeID is nil, entries of all employees are returned.
+func GetTimeEntriesForPeriod(employeeID *uuid.UUID, start time.Time, end time.Time) ([]model.TimeEntry, error) {
+	entries := []model.TimeEntry{}
+	query := db.DB.Where("clock_in >= ? AND clock_in < ?", start, end)
+	if employeeID != nil {
+		query = query.Where("employee_id = ?", *employeeID)
+	}
+
+	if err := query.Order("clock_in ASC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	err := loadTimeEntryBreaks(entries)
+	return entries, err
+}
+
+// CheckOverlappingTimeEntry checks if a clock-in time falls inside an existing closed entry of the employee
+func CheckOverlappingTimeEntry(employeeID uuid.UUID, at time.Time) (bool, error) {
+	var count int64
+	err := db.DB.Model(&model.TimeEntry{}).
+		Where("employee_id = ? AND clock_in <= ? AND clock_out > ?", employeeID, at, at).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CheckOverlappingTimeEntryRange checks if a range overlaps another entry of the employee.
+// Open entries are treated as running until now.
+func CheckOverlappingTimeEntryRange(employeeID uuid.UUID, start time.Time, end time.Time, excludeID *uuid.UUID) (bool, error) {
+	query := db.DB.Model(&model.TimeEntry{}).
+		Where("employee_id = ? AND clock_in < ? AND COALESCE(clock_out, ?) > ?", employeeID, end, time.Now().UTC(), start)
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// SaveTimeEntryCorrection stores the corrected times of an entry. When the correction clocks out
+// an open entry, an open break ends at the same time, in the same transaction.
+func SaveTimeEntryCorrection(entry *model.TimeEntry) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if entry.ClockOut != nil {
+		if err := tx.Model(&model.TimeEntryBreak{}).
+			Where("time_entry_id = ? AND end_time IS NULL", entry.ID).
+			Update("end_time", *entry.ClockOut).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Model(&model.TimeEntry{}).Where("id = ?", entry.ID).Updates(map[string]interface{}{
+		"clock_in":          entry.ClockIn,
+		"clock_out":         entry.ClockOut,
+		"notes":             entry.Notes,
+		"corrected_by":      entry.CorrectedBy,
+		"corrected_at":      entry.CorrectedAt,
+		"correction_reason": entry.CorrectionReason,
+	}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	for i := range entry.Breaks {
+		if entry.Breaks[i].EndTime == nil && entry.ClockOut != nil {
+			entry.Breaks[i].EndTime = entry.ClockOut
+		}
+	}
+	return nil
+}
+
+// CloseTimeEntry clocks out an open entry, ending an open break at the same time, in one transaction
+func CloseTimeEntry(entry *model.TimeEntry, clockOut time.Time) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Model(&model.TimeEntryBreak{}).
+		Where("time_entry_id = ? AND end_time IS NULL", entry.ID).
+		Update("end_time", clockOut).Error; err != nil {
+		return err
+	}
+
+	result := tx.Model(&model.TimeEntry{}).
+		Where("id = ? AND clock_out IS NULL", entry.ID).
+		Update("clock_out", clockOut)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTimeEntryChanged
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	entry.ClockOut = &clockOut
+	for i := range entry.Breaks {
+		if entry.Breaks[i].EndTime == nil {
+			entry.Breaks[i].EndTime = &clockOut
+		}
+	}
+	return nil
+}
+
+// CreateTimeEntryBreak starts a break on a time entry
+func CreateTimeEntryBreak(entryBreak *model.TimeEntryBreak) error {
+	if entryBreak.ID == uuid.Nil {
+		entryBreak.ID = uuid.New()
+	}
+	return db.DB.Create(entryBreak).Error
+}
+
+// CloseTimeEntryBreak ends an open break
+func CloseTimeEntryBreak(entryBreak *model.TimeEntryBreak, endTime time.Time) error {
+	result := db.DB.Model(&model.TimeEntryBreak{}).
+		Where("id = ? AND end_time IS NULL", entryBreak.ID).
+		Update("end_time", endTime)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTimeEntryChanged
+	}
+	entryBreak.EndTime = &endTime
+	return nil
+}
+
+// loadTimeEntryBreaks fills in the breaks of the given entries with a single query
+func loadTimeEntryBreaks(entries []model.TimeEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.ID
+		entries[i].Breaks = []model.TimeEntryBreak{}
+	}
+
+	var breaks []model.TimeEntryBreak
+	if err := db.DB.Where("time_entry_id IN ?", ids).Order("start_time ASC").Find(&breaks).Error; err != nil {
+		return err
+	}
+
+	index := make(map[uuid.UUID]int, len(entries))
+	for i, entry := range entries {
+		index[entry.ID] = i
+	}
+	for _, entryBreak := range breaks {
+		i := index[entryBreak.TimeEntryID]
+		entries[i].Breaks = append(entries[i].Breaks, entryBreak)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"services/shared/db"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// CreateHoliday creates a new holiday in the database
+func CreateHoliday(holiday *model.Holiday) error {
+	if holiday.ID == uuid.Nil {
+		holiday.ID = uuid.New()
+	}
+	return db.DB.Create(holiday).Error
+}
+
+// CheckDuplicateHoliday checks if a holiday already exists on the date
+func CheckDuplicateHoliday(date time.Time) (bool, error) {
+	var count int64
+	err := db.DB.Model(&model.Holiday{}).Where("date = ?", date).Count(&count).Error
+	return count > 0, err
+}
+
+// GetHolidays returns holidays within [start, end), or all holidays when both are zero
+func GetHolidays(start time.Time, end time.Time) ([]model.Holiday, error) {
+	holidays := []model.Holiday{}
+	query := db.DB.Model(&model.Holiday{})
+	if !start.IsZero() {
+		query = query.Where("date >= ?", start)
+	}
+	if !end.IsZero() {
+		query = query.Where("date < ?", end)
+	}
+	err := query.Order("date ASC").Find(&holidays).Error
+	return holidays, err
+}
+
+// GetHolidayByID returns a holiday by ID
+func GetHolidayByID(id uuid.UUID) (model.Holiday, error) {
+	var holiday model.Holiday
+	err := db.DB.Where("id = ?", id).First(&holiday).Error
+	return holiday, err
+}
+
+// DeleteHoliday deletes a holiday by ID
+func DeleteHoliday(id uuid.UUID) error {
+	return db.DB.Delete(&model.Holiday{}, id).Error
+}
+
+// GetApprovedTimesheet returns the approved timesheet of an employee for a week, including its days,
+// or nil if the week has not been approved
+func GetApprovedTimesheet(employeeID uuid.UUID, weekStart time.Time) (*model.Timesheet, error) {
+	var timesheets []model.Timesheet
+	if err := db.DB.Where("employee_id = ? AND week_start = ?", employeeID, weekStart).Limit(1).Find(&timesheets).Error; err != nil {
+		return nil, err
+	}
+	if len(timesheets) == 0 {
+		return nil, nil
+	}
+
+	timesheet := timesheets[0]
+	timesheet.Days = []model.TimesheetDay{}
+	err := db.DB.Where("timesheet_id = ?", timesheet.ID).Order("date ASC").Find(&timesheet.Days).Error
+	return &timesheet, err
+}
+
+// GetApprovedTimesheetsForWeek returns all approved timesheets of a week
+func GetApprovedTimesheetsForWeek(weekStart time.Time) ([]model.Timesheet, error) {
+	timesheets := []model.Timesheet{}
+	err := db.DB.Where("week_start = ?", weekStart).Find(&timesheets).Error
+	return timesheets, err
+}
+
+// CheckTimesheetLocked checks if the employee's week starting at weekStart has an approved timesheet
+func CheckTimesheetLocked(employeeID uuid.UUID, weekStart time.Time) (bool, error) {
+	var count int64
+	err := db.DB.Model(&model.Timesheet{}).
+		Where("employee_id = ? AND week_start = ?", employeeID, weekStart).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// SaveApprovedTimesheet stores an approved timesheet and its days in one transaction
+func SaveApprovedTimesheet(timesheet *model.Timesheet) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if timesheet.ID == uuid.Nil {
+		timesheet.ID = uuid.New()
+	}
+	if err := tx.Create(timesheet).Error; err != nil {
+		return err
+	}
+	for i := range timesheet.Days {
+		timesheet.Days[i].ID = uuid.New()
+		timesheet.Days[i].TimesheetID = timesheet.ID
+	}
+	if len(timesheet.Days) > 0 {
+		if err := tx.Create(&timesheet.Days).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// DeleteApprovedTimesheet removes an approval and its days, unlocking the week
+func DeleteApprovedTimesheet(id uuid.UUID) error {
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	if err := tx.Where("timesheet_id = ?", id).Delete(&model.TimesheetDay{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(&model.Timesheet{}, id).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// GetTimesheetByID returns an approved timesheet by ID
+func GetTimesheetByID(id uuid.UUID) (model.Timesheet, error) {
+	var timesheet model.Timesheet
+	err := db.DB.Where("id = ?", id).First(&timesheet).Error
+	return timesheet, err
+}
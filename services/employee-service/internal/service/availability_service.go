@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"services/shared/utils"
@@ -22,6 +23,13 @@ func NewAvailabilityService() *AvailabilityService {
 // GetEmployeeAvailability calculates the complete availability for an employee on a specific date
 // This includes schedule, one-time blocks, and recurring breaks with conflict resolution
 func (s *AvailabilityService) GetEmployeeAvailability(employeeID uuid.UUID, date time.Time) (*model.AvailabilityResponse, error) {
+	return s.GetEmployeeAvailabilityAtLocation(employeeID, date, nil)
+}
+
+// GetEmployeeAvailabilityAtLocation calculates the availability of an employee on a specific date.
+// When locationID is set only the employee's schedule at that location counts, so an employee working
+// at two locations on the date is available at each during their shift there.
+func (s *AvailabilityService) GetEmployeeAvailabilityAtLocation(employeeID uuid.UUID, date time.Time, locationID *uuid.UUID) (*model.AvailabilityResponse, error) {
 	// Step 1: Validate employee exists
 	exists, err := repository.CheckEmployeeExists(employeeID)
 	if err != nil {
@@ -32,13 +40,14 @@ func (s *AvailabilityService) GetEmployeeAvailability(employeeID uuid.UUID, date
 		return nil, fmt.Errorf("employee not found")
 	}
 
-	// Step 2: Find applicable schedule for the date
-	schedule, err := repository.GetEmployeeScheduleForDate(employeeID, date)
+	// Step 2: Find applicable schedule for the date, at the location if one is given
+	schedules, err := repository.GetEmployeeSchedulesForDate(employeeID, date)
 	if err != nil {
 		utils.Error(fmt.Sprintf("Failed to get schedule for employee %s on date %s: %v", employeeID, date.Format("2006-01-02"), err))
 		return nil, fmt.Errorf("internal server error")
 	}
-	if schedule == nil {
+	schedules = schedulesAtLocation(schedules, locationID)
+	if len(schedules) == 0 {
 		return nil, fmt.Errorf("no schedule found for employee on this date")
 	}
 
@@ -58,47 +67,137 @@ func (s *AvailabilityService) GetEmployeeAvailability(employeeID uuid.UUID, date
 	}
 
 	// Step 5: Process and build the response
-	response := s.buildAvailabilityResponse(employeeID, date, schedule, oneTimeBlocks, recurringBreaks)
+	response := s.buildAvailabilityResponse(employeeID, date, schedules, oneTimeBlocks, recurringBreaks)
 	
 	return response, nil
 }
 
-// buildAvailabilityResponse constructs the availability response with all necessary processing
+// schedulesAtLocation picks the schedules that apply from schedules ordered by valid_from, most recent
+// first. Schedules at the same location replace each other, so the most recent one per location applies;
+// a day split between locations has one for each. With a location only the one there is returned.
+func schedulesAtLocation(schedules []model.Schedule, locationID *uuid.UUID) []model.Schedule {
+	applicable := []model.Schedule{}
+	seen := make(map[uuid.UUID]bool)
+	for _, schedule := range schedules {
+		key := uuid.Nil // Schedules without a location replace each other as well
+		if schedule.LocationID != nil {
+			key = *schedule.LocationID
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if locationID == nil || schedule.LocationID != nil && *schedule.LocationID == *locationID {
+			applicable = append(applicable, schedule)
+		}
+	}
+	return applicable
+}
+
+// GetEmployeeAvailabilityWithResources calculates the availability of an employee on a specific date,
+// optionally at a location, and narrows the free slots down to the times when every requested resource is free as well
+func (s *AvailabilityService) GetEmployeeAvailabilityWithResources(employeeID uuid.UUID, date time.Time, locationID *uuid.UUID, resourceIDs []uuid.UUID) (*model.AvailabilityResponse, error) {
+	response, err := s.GetEmployeeAvailabilityAtLocation(employeeID, date, locationID)
+	if err != nil {
+		return nil, err
+	}
+	if len(resourceIDs) == 0 {
+		return response, nil
+	}
+
+	resourceService := NewResourceService()
+	freeRanges := toTimeRanges(response.FreeSlots)
+	response.Resources = make([]model.ResourceAvailabilityResponse, 0, len(resourceIDs))
+
+	for _, resourceID := range resourceIDs {
+		resourceAvailability, err := resourceService.GetResourceAvailability(resourceID, date)
+		if err != nil {
+			return nil, err
+		}
+		response.Resources = append(response.Resources, *resourceAvailability)
+		freeRanges = intersectTimeRanges(freeRanges, toTimeRanges(resourceAvailability.FreeSlots))
+	}
+
+	response.FreeSlots = toAvailabilitySlots(freeRanges)
+	return response, nil
+}
+
+// buildAvailabilityResponse constructs the availability response with all necessary processing.
+// Every schedule is processed on its own; a day split between locations yields one shift for each.
 func (s *AvailabilityService) buildAvailabilityResponse(
 	employeeID uuid.UUID,
 	date time.Time,
-	schedule *model.Schedule,
+	schedules []model.Schedule,
 	oneTimeBlocks []model.OnetimeBlock,
 	recurringBreaks []model.RecurringBreak,
 ) *model.AvailabilityResponse {
 	
-	// Build schedule information with full datetime
-	availabilitySchedule := s.buildScheduleInfo(date, schedule)
-	
-	// Process one-time blocks - trim to schedule hours and date boundaries
-	processedBlocks := s.processOneTimeBlocks(date, schedule, oneTimeBlocks)
-	
-	// Process recurring breaks - convert to full datetime and trim to schedule hours
-	processedBreaks := s.processRecurringBreaks(date, schedule, recurringBreaks)
-	
-	// Resolve conflicts between one-time blocks and breaks (one-time blocks take priority)
-	finalBreaks := s.resolveBreakConflicts(processedBreaks, processedBlocks)
-	
-	// Ensure slices are never nil to avoid null in JSON response
-	if processedBlocks == nil {
-		processedBlocks = []model.AvailabilityBlock{}
+	shifts := make([]model.AvailabilitySchedule, 0, len(schedules))
+	processedBlocks := make([]model.AvailabilityBlock, 0)
+	finalBreaks := make([]model.AvailabilityBreak, 0)
+	for i := range schedules {
+		schedule := &schedules[i]
+
+		// Build schedule information with full datetime
+		shift := s.buildScheduleInfo(date, schedule)
+		shift.LocationID = schedule.LocationID
+		shifts = append(shifts, *shift)
+
+		// Process one-time blocks - trim to schedule hours and date boundaries
+		blocks := s.processOneTimeBlocks(date, schedule, oneTimeBlocks)
+
+		// Process recurring breaks - convert to full datetime and trim to schedule hours
+		breaks := s.processRecurringBreaks(date, schedule, recurringBreaks)
+
+		// Resolve conflicts between one-time blocks and breaks (one-time blocks take priority)
+		processedBlocks = append(processedBlocks, blocks...)
+		finalBreaks = append(finalBreaks, s.resolveBreakConflicts(breaks, blocks)...)
+	}
+	sort.Slice(shifts, func(i, j int) bool { return shifts[i].StartTime.Before(shifts[j].StartTime) })
+	sort.SliceStable(processedBlocks, func(i, j int) bool { return processedBlocks[i].StartTime.Before(processedBlocks[j].StartTime) })
+	sort.SliceStable(finalBreaks, func(i, j int) bool { return finalBreaks[i].StartTime.Before(finalBreaks[j].StartTime) })
+
+	// Free time is the shifts minus one-time blocks and breaks
+	unavailable := make([]model.TimeRange, 0, len(processedBlocks)+len(finalBreaks))
+	for _, block := range processedBlocks {
+		unavailable = append(unavailable, model.TimeRange{Start: block.StartTime, End: block.EndTime})
 	}
-	if finalBreaks == nil {
-		finalBreaks = []model.AvailabilityBreak{}
+	for _, breakItem := range finalBreaks {
+		unavailable = append(unavailable, model.TimeRange{Start: breakItem.StartTime, End: breakItem.EndTime})
+	}
+	freeSlots := toAvailabilitySlots(subtractTimeRanges(shiftRanges(shifts), unavailable))
+
+	// The schedule spans the whole working day, the location is set when every shift is at the same one
+	availabilitySchedule := &model.AvailabilitySchedule{StartTime: shifts[0].StartTime, EndTime: shifts[0].EndTime}
+	locationID := shifts[0].LocationID
+	for _, shift := range shifts[1:] {
+		if shift.EndTime.After(availabilitySchedule.EndTime) {
+			availabilitySchedule.EndTime = shift.EndTime
+		}
+		if shift.LocationID == nil || locationID == nil || *shift.LocationID != *locationID {
+			locationID = nil
+		}
 	}
 
 	return &model.AvailabilityResponse{
 		Date:          date,
 		EmployeeID:    employeeID,
+		LocationID:    locationID,
 		Schedule:      availabilitySchedule,
+		Shifts:        shifts,
 		OneTimeBlocks: processedBlocks,
 		Breaks:        finalBreaks,
+		FreeSlots:     freeSlots,
+	}
+}
+
+// shiftRanges converts the shifts of an availability response to time ranges
+func shiftRanges(shifts []model.AvailabilitySchedule) []model.TimeRange {
+	ranges := make([]model.TimeRange, 0, len(shifts))
+	for _, shift := range shifts {
+		ranges = append(ranges, model.TimeRange{Start: shift.StartTime, End: shift.EndTime})
 	}
+	return ranges
 }
 
 // buildScheduleInfo converts schedule to availability schedule with full datetime
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+func TestSchedulesAtLocation(t *testing.T) {
+	salonA, salonB := uuid.New(), uuid.New()
+	morningA := model.Schedule{ID: uuid.New(), LocationID: &salonA, StartTime: timeOfDay(8, 0), EndTime: timeOfDay(12, 0)}
+	afternoonB := model.Schedule{ID: uuid.New(), LocationID: &salonB, StartTime: timeOfDay(13, 0), EndTime: timeOfDay(18, 0)}
+	olderA := model.Schedule{ID: uuid.New(), LocationID: &salonA, StartTime: timeOfDay(10, 0), EndTime: timeOfDay(16, 0)}
+	unlocated := model.Schedule{ID: uuid.New(), StartTime: timeOfDay(9, 0), EndTime: timeOfDay(17, 0)}
+	olderUnlocated := model.Schedule{ID: uuid.New(), StartTime: timeOfDay(7, 0), EndTime: timeOfDay(11, 0)}
+
+	tests := []struct {
+		name      string
+		schedules []model.Schedule
+		location  *uuid.UUID
+		want      []uuid.UUID
+	}{
+		{"no schedules", nil, nil, nil},
+		{"split day without a location filter", []model.Schedule{afternoonB, morningA}, nil, []uuid.UUID{afternoonB.ID, morningA.ID}},
+		{"first location of a split day", []model.Schedule{afternoonB, morningA}, &salonA, []uuid.UUID{morningA.ID}},
+		{"second location of a split day", []model.Schedule{afternoonB, morningA}, &salonB, []uuid.UUID{afternoonB.ID}},
+		{"most recent at the same location", []model.Schedule{morningA, afternoonB, olderA}, nil, []uuid.UUID{morningA.ID, afternoonB.ID}},
+		{"most recent without a location", []model.Schedule{unlocated, olderUnlocated}, nil, []uuid.UUID{unlocated.ID}},
+		{"not scheduled at the location", []model.Schedule{morningA}, &salonB, nil},
+		{"schedule without a location is at no location", []model.Schedule{unlocated}, &salonA, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := schedulesAtLocation(tt.schedules, tt.location)
+			if len(got) != len(tt.want) {
+				t.Fatalf("schedulesAtLocation() returned %d schedules, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].ID != tt.want[i] {
+					t.Errorf("schedulesAtLocation()[%d] = %s, want %s", i, got[i].ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildAvailabilityResponseSplitDay(t *testing.T) {
+	salonA, salonB := uuid.New(), uuid.New()
+	schedules := []model.Schedule{
+		{ID: uuid.New(), LocationID: &salonB, StartTime: timeOfDay(13, 0), EndTime: timeOfDay(18, 0)},
+		{ID: uuid.New(), LocationID: &salonA, StartTime: timeOfDay(8, 0), EndTime: timeOfDay(12, 0)},
+	}
+	breaks := []model.RecurringBreak{{ID: uuid.New(), StartTime: timeOfDay(11, 30), EndTime: timeOfDay(13, 30), Reason: "lunch"}}
+
+	response := NewAvailabilityService().buildAvailabilityResponse(uuid.New(), at(0, 0), schedules, nil, breaks)
+
+	if len(response.Shifts) != 2 || *response.Shifts[0].LocationID != salonA || *response.Shifts[1].LocationID != salonB {
+		t.Fatalf("shifts = %+v, want the morning at salon A and the afternoon at salon B", response.Shifts)
+	}
+	if response.LocationID != nil {
+		t.Errorf("location = %s, want none for a split day", *response.LocationID)
+	}
+	if !response.Schedule.StartTime.Equal(at(8, 0)) || !response.Schedule.EndTime.Equal(at(18, 0)) {
+		t.Errorf("schedule = %s-%s, want 08:00-18:00", response.Schedule.StartTime.Format("15:04"), response.Schedule.EndTime.Format("15:04"))
+	}
+	want := []model.TimeRange{span(8, 0, 11, 30), span(13, 30, 18, 0)}
+	got := toTimeRanges(response.FreeSlots)
+	if len(got) != len(want) {
+		t.Fatalf("got %d free slots, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Errorf("free slot %d = %s-%s, want %s-%s", i, got[i].Start.Format("15:04"), got[i].End.Format("15:04"),
+				want[i].Start.Format("15:04"), want[i].End.Format("15:04"))
+		}
+	}
+}
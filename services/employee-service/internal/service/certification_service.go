@@ -0,0 +1,191 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// CertificationPolicy controls how far ahead expiring certifications are reported and
+// whether problems block scheduling or are only returned as warnings.
+type CertificationPolicy struct {
+	WarningDays int
+	Enforcement string // warn, block
+}
+
+// CertificationError represents an error when an employee does not meet the requirements of their role
+// and the certification policy blocks scheduling.
+type CertificationError struct {
+	Problems []string
+}
+
+func (e *CertificationError) Error() string {
+	return "employee does not meet the requirements of their role: " + strings.Join(e.Problems, "; ")
+}
+
+// DuplicateSkillError represents an error when an employee or role already has the skill
+type DuplicateSkillError struct {
+	Name string
+}
+
+func (e *DuplicateSkillError) Error() string {
+	return fmt.Sprintf("skill %s already exists", e.Name)
+}
+
+// LoadCertificationPolicy reads the certification policy from the environment.
+//
+//	CERTIFICATION_WARNING_DAYS (default 30)
+//	CERTIFICATION_ENFORCEMENT  (warn or block, default warn)
+func LoadCertificationPolicy() CertificationPolicy {
+	policy := CertificationPolicy{WarningDays: 30, Enforcement: model.CertificationEnforcementWarn}
+
+	if value := os.Getenv("CERTIFICATION_WARNING_DAYS"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 {
+			utils.Warning(fmt.Sprintf("Invalid CERTIFICATION_WARNING_DAYS %q, using %d", value, policy.WarningDays))
+		} else {
+			policy.WarningDays = days
+		}
+	}
+
+	switch value := os.Getenv("CERTIFICATION_ENFORCEMENT"); value {
+	case "", model.CertificationEnforcementWarn:
+	case model.CertificationEnforcementBlock:
+		policy.Enforcement = model.CertificationEnforcementBlock
+	default:
+		utils.Warning(fmt.Sprintf("Invalid CERTIFICATION_ENFORCEMENT %q, using %s", value, policy.Enforcement))
+	}
+
+	return policy
+}
+
+// CheckRoleRequirements compares the skills of an employee with the requirements of their role
+// as of a date. It returns one message per missing, insufficient, expired or soon expiring skill.
+func CheckRoleRequirements(employee model.Employee, asOf time.Time, policy CertificationPolicy) ([]string, error) {
+	if employee.Role == "" {
+		return nil, nil
+	}
+	requirements, err := repository.GetRoleRequirements(employee.Role)
+	if err != nil {
+		utils.Error("Failed to get role requirements: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	if len(requirements) == 0 {
+		return nil, nil
+	}
+
+	skills, err := repository.GetEmployeeSkills(employee.ID)
+	if err != nil {
+		utils.Error("Failed to get employee skills: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	skillsByName := make(map[string]model.EmployeeSkill, len(skills))
+	for _, skill := range skills {
+		skillsByName[skill.Name] = skill
+	}
+
+	warnUntil := asOf.AddDate(0, 0, policy.WarningDays)
+	var problems []string
+	for _, requirement := range requirements {
+		skill, ok := skillsByName[requirement.SkillName]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s is required for the %s role", requirement.SkillName, employee.Role))
+		case skill.Level < requirement.MinLevel:
+			problems = append(problems, fmt.Sprintf("%s is at level %d, the %s role requires level %d", skill.Name, skill.Level, employee.Role, requirement.MinLevel))
+		case skill.IsCertification && skill.ExpiresOn != nil && skill.ExpiresOn.Before(asOf):
+			problems = append(problems, fmt.Sprintf("%s expired on %s", skill.Name, skill.ExpiresOn.Format("2006-01-02")))
+		case skill.IsCertification && skill.ExpiresOn != nil && !skill.ExpiresOn.After(warnUntil):
+			problems = append(problems, fmt.Sprintf("%s expires on %s", skill.Name, skill.ExpiresOn.Format("2006-01-02")))
+		}
+	}
+
+	return problems, nil
+}
+
+// CheckSchedulingCertifications checks an employee against the requirements of their role before
+// scheduling them from a date. Under the warn policy problems are returned as warnings; under the
+// block policy they are returned as a CertificationError.
+func CheckSchedulingCertifications(employee model.Employee, from time.Time) ([]string, error) {
+	asOf := time.Now().UTC().Truncate(24 * time.Hour)
+	if from.After(asOf) {
+		asOf = from
+	}
+
+	policy := LoadCertificationPolicy()
+	problems, err := CheckRoleRequirements(employee, asOf, policy)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 && policy.Enforcement == model.CertificationEnforcementBlock {
+		return nil, &CertificationError{Problems: problems}
+	}
+	return problems, nil
+}
+
+// CheckScheduleEmployee loads the employee of a schedule and checks that the schedule ends by their
+// termination date and that they hold the certifications required by their role
+func CheckScheduleEmployee(employeeID uuid.UUID, validFrom time.Time, validUntil *time.Time) ([]string, error) {
+	employee, err := repository.GetEmployeeByID(employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("employee not found")
+	}
+	if err := CheckEmploymentPeriod(employee, validFrom, validUntil); err != nil {
+		return nil, err
+	}
+	return CheckSchedulingCertifications(employee, validFrom)
+}
+
+// GetCertificationAlerts returns the certifications that have expired or expire within withinDays,
+// marking the ones required by the holder's role.
+func GetCertificationAlerts(withinDays int) ([]model.CertificationAlert, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	skills, err := repository.GetExpiringCertifications(today.AddDate(0, 0, withinDays))
+	if err != nil {
+		utils.Error("Failed to get expiring certifications: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	requirements, err := repository.GetRoleRequirements("")
+	if err != nil {
+		utils.Error("Failed to get role requirements: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	required := make(map[string]bool, len(requirements))
+	for _, requirement := range requirements {
+		required[requirement.Role+"\x00"+requirement.SkillName] = true
+	}
+
+	employees := make(map[uuid.UUID]model.Employee)
+	alerts := []model.CertificationAlert{}
+	for _, skill := range skills {
+		employee, ok := employees[skill.EmployeeID]
+		if !ok {
+			employee, err = repository.GetEmployeeByID(skill.EmployeeID)
+			if err != nil {
+				continue
+			}
+			employees[skill.EmployeeID] = employee
+		}
+		alerts = append(alerts, model.CertificationAlert{
+			EmployeeID: employee.ID,
+			FirstName:  employee.FirstName,
+			LastName:   employee.LastName,
+			Role:       employee.Role,
+			SkillName:  skill.Name,
+			ExpiresOn:  *skill.ExpiresOn,
+			DaysLeft:   int(skill.ExpiresOn.Sub(today).Hours() / 24),
+			Required:   required[employee.Role+"\x00"+skill.Name],
+		})
+	}
+
+	return alerts, nil
+}
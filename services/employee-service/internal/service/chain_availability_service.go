@@ -0,0 +1,282 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// chainCandidate holds the free time of one employee for one leg of a chained search
+type chainCandidate struct {
+	employeeID uuid.UUID
+	free       []model.TimeRange
+	preferred  bool
+}
+
+// chainState is a leg of a chained search together with the time it starts
+type chainState struct {
+	legIndex int
+	start    int64 // Unix nanoseconds
+}
+
+// chainSearch holds the state of one pass of the chained search
+type chainSearch struct {
+	legs       []model.ChainLegQuery
+	candidates [][]chainCandidate
+	maxResults int
+	sequences  *[]model.ChainSequence
+	found      map[string]bool     // Sequences found by any pass, keyed by their legs
+	deadEnds   map[chainState]bool // Legs and start times from which no new sequence can be completed
+}
+
+// FindChainedAvailability searches for sequences of consecutive slots on a date, one slot per leg,
+// where each leg may be served by a different employee. Every leg starts exactly when the previous
+// leg ends plus its processing gap, so the customer never waits between legs longer than required.
+// When locationID is set every leg must be served by an employee working at that location.
+//
+// Sequences served by the preferred employees of every leg that has them come first; the remaining
+// results fall back to the other employees. Booking the legs of a sequence is left to the caller.
+func (s *AvailabilityService) FindChainedAvailability(
+	date time.Time,
+	locationID *uuid.UUID,
+	legs []model.ChainLegQuery,
+	step time.Duration,
+	maxResults int,
+) (*model.ChainAvailabilityResponse, error) {
+	// Step 1: Resolve the free time of every candidate employee for every leg
+	employeeFree := make(map[uuid.UUID][]model.TimeRange)
+	resourceFree := make(map[uuid.UUID][]model.TimeRange)
+	var activeEmployeeIDs []uuid.UUID
+
+	candidates := make([][]chainCandidate, len(legs))
+	preferredCandidates := make([][]chainCandidate, len(legs))
+	hasPreferences := false
+	for i, leg := range legs {
+		if activeEmployeeIDs == nil && locationID != nil {
+			locationEmployeeIDs, err := repository.GetLocationEmployeeIDsForDate(*locationID, date)
+			if err != nil {
+				utils.Error(fmt.Sprintf("Failed to get employees of location %s: %v", *locationID, err))
+				return nil, fmt.Errorf("internal server error")
+			}
+			activeEmployeeIDs = locationEmployeeIDs
+		}
+		if activeEmployeeIDs == nil {
+			employees, err := repository.GetActiveEmployees()
+			if err != nil {
+				utils.Error(fmt.Sprintf("Failed to get active employees: %v", err))
+				return nil, fmt.Errorf("internal server error")
+			}
+			activeEmployeeIDs = make([]uuid.UUID, 0, len(employees))
+			for _, employee := range employees {
+				activeEmployeeIDs = append(activeEmployeeIDs, employee.ID)
+			}
+		}
+
+		// Resources required by this leg
+		legResourceFree := make([][]model.TimeRange, 0, len(leg.ResourceIDs))
+		for _, resourceID := range leg.ResourceIDs {
+			free, ok := resourceFree[resourceID]
+			if !ok {
+				availability, err := NewResourceService().GetResourceAvailability(resourceID, date)
+				if err != nil {
+					return nil, err
+				}
+				free = toTimeRanges(availability.FreeSlots)
+				resourceFree[resourceID] = free
+			}
+			legResourceFree = append(legResourceFree, free)
+		}
+
+		// Preferred employees are tried first, then everybody else
+		preferred := make(map[uuid.UUID]bool, len(leg.EmployeeIDs))
+		employeeIDs := make([]uuid.UUID, 0, len(leg.EmployeeIDs)+len(activeEmployeeIDs))
+		for _, employeeID := range leg.EmployeeIDs {
+			if !preferred[employeeID] {
+				preferred[employeeID] = true
+				employeeIDs = append(employeeIDs, employeeID)
+			}
+		}
+		for _, employeeID := range activeEmployeeIDs {
+			if !preferred[employeeID] {
+				employeeIDs = append(employeeIDs, employeeID)
+			}
+		}
+
+		for _, employeeID := range employeeIDs {
+			free, ok := employeeFree[employeeID]
+			if !ok {
+				availability, err := s.GetEmployeeAvailabilityAtLocation(employeeID, date, locationID)
+				switch {
+				case err == nil:
+					free = toTimeRanges(availability.FreeSlots)
+				case err.Error() == "no schedule found for employee on this date":
+					free = []model.TimeRange{}
+				default:
+					return nil, err
+				}
+				employeeFree[employeeID] = free
+			}
+
+			for _, resourceRanges := range legResourceFree {
+				free = intersectTimeRanges(free, resourceRanges)
+			}
+			if len(free) > 0 {
+				candidates[i] = append(candidates[i], chainCandidate{employeeID: employeeID, free: free, preferred: preferred[employeeID]})
+			}
+		}
+
+		// A leg nobody can serve means there is no sequence at all
+		if len(candidates[i]) == 0 {
+			return &model.ChainAvailabilityResponse{Date: date, Sequences: []model.ChainSequence{}}, nil
+		}
+
+		if len(preferred) == 0 {
+			preferredCandidates[i] = candidates[i]
+			continue
+		}
+		hasPreferences = true
+		for _, candidate := range candidates[i] {
+			if candidate.preferred {
+				preferredCandidates[i] = append(preferredCandidates[i], candidate)
+			}
+		}
+	}
+
+	// Step 2: Search with the preferred employees only, then fill up with everybody
+	sequences := make([]model.ChainSequence, 0)
+	found := make(map[string]bool)
+	if hasPreferences {
+		searchChains(date, step, &chainSearch{legs: legs, candidates: preferredCandidates, maxResults: maxResults, sequences: &sequences, found: found})
+	}
+	searchChains(date, step, &chainSearch{legs: legs, candidates: candidates, maxResults: maxResults, sequences: &sequences, found: found})
+
+	return &model.ChainAvailabilityResponse{Date: date, Sequences: sequences}, nil
+}
+
+// searchChains walks every candidate start time of the first leg on a date and extends it leg by leg
+func searchChains(date time.Time, step time.Duration, search *chainSearch) {
+	if len(*search.sequences) >= search.maxResults {
+		return
+	}
+
+	// Determine the window in which the first leg can start
+	var windowStart, windowEnd time.Time
+	for _, candidate := range search.candidates[0] {
+		for _, free := range candidate.free {
+			if windowStart.IsZero() || free.Start.Before(windowStart) {
+				windowStart = free.Start
+			}
+			if free.End.After(windowEnd) {
+				windowEnd = free.End
+			}
+		}
+	}
+	if windowStart.IsZero() {
+		return
+	}
+
+	// Align the first candidate start to the step, counted from midnight of the requested date
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	offset := windowStart.Sub(dayStart)
+	if remainder := offset % step; remainder != 0 {
+		offset += step - remainder
+	}
+
+	search.deadEnds = make(map[chainState]bool)
+	for start := dayStart.Add(offset); start.Add(search.legs[0].Duration).Compare(windowEnd) <= 0; start = start.Add(step) {
+		search.extendChain(0, start, nil)
+		if len(*search.sequences) >= search.maxResults {
+			return
+		}
+	}
+}
+
+// extendChain recursively assigns an employee to each remaining leg starting at the given time.
+// It reports whether a new sequence was found. Whether the remaining legs fit does not depend on
+// the legs already assigned, so a leg and start time that found nothing is not tried again.
+func (search *chainSearch) extendChain(legIndex int, start time.Time, assigned []model.ChainLeg) bool {
+	if len(*search.sequences) >= search.maxResults {
+		return false
+	}
+
+	// All legs assigned - record the sequence unless an earlier pass found it
+	if legIndex == len(search.legs) {
+		key := chainKey(assigned)
+		if search.found[key] {
+			return false
+		}
+		search.found[key] = true
+
+		chain := make([]model.ChainLeg, len(assigned))
+		copy(chain, assigned)
+		*search.sequences = append(*search.sequences, model.ChainSequence{
+			StartTime: chain[0].StartTime,
+			EndTime:   chain[len(chain)-1].EndTime,
+			Legs:      chain,
+		})
+		return true
+	}
+
+	state := chainState{legIndex: legIndex, start: start.UnixNano()}
+	if search.deadEnds[state] {
+		return false
+	}
+
+	leg := search.legs[legIndex]
+	slot := model.TimeRange{Start: start, End: start.Add(leg.Duration)}
+
+	completed := false
+	for _, candidate := range search.candidates[legIndex] {
+		if !fitsInRanges(slot, candidate.free) {
+			continue
+		}
+
+		next := append(assigned, model.ChainLeg{
+			LegIndex:   legIndex,
+			EmployeeID: candidate.employeeID,
+			Preferred:  candidate.preferred,
+			StartTime:  slot.Start,
+			EndTime:    slot.End,
+		})
+		if search.extendChain(legIndex+1, slot.End.Add(leg.GapAfter), next) {
+			completed = true
+		}
+
+		if len(*search.sequences) >= search.maxResults {
+			return completed
+		}
+	}
+
+	if !completed {
+		search.deadEnds[state] = true
+	}
+	return completed
+}
+
+// chainKey identifies a sequence by the employee and start time of every leg
+func chainKey(legs []model.ChainLeg) string {
+	key := make([]byte, 0, len(legs)*48)
+	for _, leg := range legs {
+		key = append(key, leg.EmployeeID.String()...)
+		key = append(key, '@')
+		key = strconv.AppendInt(key, leg.StartTime.UnixNano(), 10)
+		key = append(key, ';')
+	}
+	return string(key)
+}
+
+// fitsInRanges checks if a slot lies completely within one of the given ranges
+func fitsInRanges(slot model.TimeRange, ranges []model.TimeRange) bool {
+	for _, available := range ranges {
+		if !slot.Start.Before(available.Start) && !slot.End.After(available.End) {
+			return true
+		}
+	}
+	return false
+}
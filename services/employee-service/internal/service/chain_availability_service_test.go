@@ -0,0 +1,96 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+func TestSearchChains(t *testing.T) {
+	cut := model.ChainLegQuery{Duration: time.Hour, GapAfter: 30 * time.Minute}
+	blowDry := model.ChainLegQuery{Duration: 30 * time.Minute}
+	anna, ben, cara := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("consecutive legs with a processing gap", func(t *testing.T) {
+		candidates := [][]chainCandidate{
+			{{employeeID: anna, free: []model.TimeRange{span(9, 0, 10, 0)}}},
+			{{employeeID: ben, free: []model.TimeRange{span(10, 0, 12, 0)}}},
+		}
+		sequences := searchTestDay(candidates, []model.ChainLegQuery{cut, blowDry}, 10)
+		if len(sequences) != 1 {
+			t.Fatalf("got %d sequences, want 1", len(sequences))
+		}
+		if got := sequences[0]; !got.StartTime.Equal(at(9, 0)) || !got.Legs[1].StartTime.Equal(at(10, 30)) || !got.EndTime.Equal(at(11, 0)) {
+			t.Errorf("sequence = %s-%s with the second leg at %s, want 09:00-11:00 with the second leg at 10:30",
+				got.StartTime.Format("15:04"), got.EndTime.Format("15:04"), got.Legs[1].StartTime.Format("15:04"))
+		}
+	})
+
+	t.Run("preferred employees first, then the others", func(t *testing.T) {
+		all := [][]chainCandidate{
+			{
+				{employeeID: anna, free: []model.TimeRange{span(11, 0, 12, 0)}, preferred: true},
+				{employeeID: ben, free: []model.TimeRange{span(9, 0, 10, 0)}},
+			},
+			{{employeeID: cara, free: []model.TimeRange{span(9, 0, 14, 0)}}},
+		}
+		preferred := [][]chainCandidate{all[0][:1], all[1]}
+		legs := []model.ChainLegQuery{cut, blowDry}
+
+		found := make(map[string]bool)
+		var sequences []model.ChainSequence
+		searchChains(at(0, 0), 30*time.Minute, &chainSearch{legs: legs, candidates: preferred, maxResults: 10, sequences: &sequences, found: found})
+		searchChains(at(0, 0), 30*time.Minute, &chainSearch{legs: legs, candidates: all, maxResults: 10, sequences: &sequences, found: found})
+
+		if len(sequences) != 2 {
+			t.Fatalf("got %d sequences, want 2", len(sequences))
+		}
+		if first := sequences[0].Legs[0]; first.EmployeeID != anna || !first.Preferred {
+			t.Errorf("first sequence starts with %s (preferred %v), want the preferred employee", first.EmployeeID, first.Preferred)
+		}
+		if second := sequences[1].Legs[0]; second.EmployeeID != ben || second.Preferred {
+			t.Errorf("second sequence starts with %s (preferred %v), want the fallback employee", second.EmployeeID, second.Preferred)
+		}
+	})
+
+	t.Run("last leg never fits", func(t *testing.T) {
+		legs := make([]model.ChainLegQuery, 5)
+		candidates := make([][]chainCandidate, 5)
+		for i := range legs {
+			legs[i] = model.ChainLegQuery{Duration: 15 * time.Minute}
+			free := []model.TimeRange{span(8, 0, 20, 0)}
+			if i == len(legs)-1 {
+				free = []model.TimeRange{span(21, 0, 21, 10)} // Too short for the leg
+			}
+			for e := 0; e < 40; e++ {
+				candidates[i] = append(candidates[i], chainCandidate{employeeID: uuid.New(), free: free})
+			}
+		}
+
+		search := &chainSearch{legs: legs, candidates: candidates, maxResults: 10, sequences: &[]model.ChainSequence{}, found: map[string]bool{}}
+		searchChains(at(0, 0), 5*time.Minute, search)
+		if len(*search.sequences) != 0 {
+			t.Fatalf("got %d sequences, want none", len(*search.sequences))
+		}
+		// Every leg is tried once per start time, at most every 5 minutes of the 13 hours the legs can start in
+		if maxStates := len(legs) * 13 * 12; len(search.deadEnds) > maxStates {
+			t.Errorf("recorded %d dead ends, want at most %d", len(search.deadEnds), maxStates)
+		}
+	})
+
+	t.Run("results are limited", func(t *testing.T) {
+		candidates := [][]chainCandidate{{{employeeID: anna, free: []model.TimeRange{span(8, 0, 18, 0)}}}}
+		if got := searchTestDay(candidates, []model.ChainLegQuery{blowDry}, 3); len(got) != 3 {
+			t.Errorf("got %d sequences, want 3", len(got))
+		}
+	})
+}
+
+// searchTestDay searches chains on the test day with a 30-minute step
+func searchTestDay(candidates [][]chainCandidate, legs []model.ChainLegQuery, maxResults int) []model.ChainSequence {
+	var sequences []model.ChainSequence
+	searchChains(at(0, 0), 30*time.Minute, &chainSearch{legs: legs, candidates: candidates, maxResults: maxResults, sequences: &sequences, found: map[string]bool{}})
+	return sequences
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/validator"
+)
+
+// DuplicateCustomFieldError represents an error when a custom field with the same key already exists.
+type DuplicateCustomFieldError struct{}
+
+func (e *DuplicateCustomFieldError) Error() string {
+	return "a custom field with this key already exists"
+}
+
+// CustomFieldInUseError represents an error when enum options that employees still use are removed.
+type CustomFieldInUseError struct {
+	Options []string
+}
+
+func (e *CustomFieldInUseError) Error() string {
+	return "options still used by employees cannot be removed: " + strings.Join(e.Options, ", ")
+}
+
+// CustomFieldValueError represents a custom field value that is unknown or does not pass validation.
+type CustomFieldValueError struct {
+	Message string
+}
+
+func (e *CustomFieldValueError) Error() string {
+	return e.Message
+}
+
+// MissingCustomFieldError represents required custom fields left without a value.
+type MissingCustomFieldError struct {
+	Keys []string
+}
+
+func (e *MissingCustomFieldError) Error() string {
+	return "required custom fields are missing: " + strings.Join(e.Keys, ", ")
+}
+
+// CheckForDuplicateCustomField checks if another custom field already uses the same key.
+func CheckForDuplicateCustomField(field *model.CustomFieldDefinition) error {
+	existing, err := repository.GetCustomFieldDefinitionByKey(field.Key)
+	if err == nil && existing.ID != field.ID {
+		return &DuplicateCustomFieldError{}
+	}
+	return nil
+}
+
+// CheckRemovedCustomFieldOptions checks that enum options removed from a field are not used by any employee.
+func CheckRemovedCustomFieldOptions(existing model.CustomFieldDefinition, updated model.CustomFieldDefinition) error {
+	kept := make(map[string]bool, len(updated.Options))
+	for _, option := range updated.Options {
+		kept[option] = true
+	}
+	var removed []string
+	for _, option := range existing.Options {
+		if !kept[option] {
+			removed = append(removed, option)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	var inUse []string
+	for _, option := range removed {
+		count, err := repository.CountCustomFieldValues(existing.ID, []string{option})
+		if err != nil {
+			utils.Error("Failed to count custom field values: " + err.Error())
+			return fmt.Errorf("internal server error")
+		}
+		if count > 0 {
+			inUse = append(inUse, option)
+		}
+	}
+	if len(inUse) > 0 {
+		return &CustomFieldInUseError{Options: inUse}
+	}
+	return nil
+}
+
+// BuildEmployeeCustomFieldValues validates custom field values keyed by field key against their definitions.
+// A null value removes the field. Required fields must have a value once the changes are applied,
+// so employeeID is nil for new employees without stored values.
+func BuildEmployeeCustomFieldValues(employeeID *uuid.UUID, input map[string]interface{}) ([]model.EmployeeCustomFieldValue, []uuid.UUID, error) {
+	fields, err := repository.GetAllCustomFieldDefinitions()
+	if err != nil {
+		utils.Error("Failed to get custom field definitions: " + err.Error())
+		return nil, nil, fmt.Errorf("internal server error")
+	}
+	byKey := make(map[string]model.CustomFieldDefinition, len(fields))
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	// 1. Fields that currently have a value
+	hasValue := make(map[uuid.UUID]bool)
+	if employeeID != nil {
+		existing, err := repository.GetEmployeeCustomFieldValues(*employeeID)
+		if err != nil {
+			utils.Error("Failed to get employee custom field values: " + err.Error())
+			return nil, nil, fmt.Errorf("internal server error")
+		}
+		for _, value := range existing {
+			hasValue[value.FieldID] = true
+		}
+	}
+
+	// 2. Validate the changes in a stable order so errors are reproducible
+	keys := make([]string, 0, len(input))
+	for key := range input {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := []model.EmployeeCustomFieldValue{}
+	var removeFieldIDs []uuid.UUID
+	for _, key := range keys {
+		field, ok := byKey[strings.ToLower(strings.TrimSpace(key))]
+		if !ok {
+			return nil, nil, &CustomFieldValueError{Message: "unknown custom field: " + key}
+		}
+		if input[key] == nil {
+			removeFieldIDs = append(removeFieldIDs, field.ID)
+			hasValue[field.ID] = false
+			continue
+		}
+		value, err := normalizeCustomFieldValue(field, input[key])
+		if err != nil {
+			return nil, nil, &CustomFieldValueError{Message: err.Error()}
+		}
+		values = append(values, model.EmployeeCustomFieldValue{FieldID: field.ID, Value: value})
+		hasValue[field.ID] = true
+	}
+
+	// 3. Required fields must end up with a value
+	var missing []string
+	for _, field := range fields {
+		if field.Required && !hasValue[field.ID] {
+			missing = append(missing, field.Key)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, &MissingCustomFieldError{Keys: missing}
+	}
+
+	return values, removeFieldIDs, nil
+}
+
+// GetEmployeeCustomFields returns the custom field values of an employee keyed by field key.
+// Numbers are returned as JSON numbers, all other types as strings.
+func GetEmployeeCustomFields(employeeID uuid.UUID) (map[string]interface{}, error) {
+	values, err := repository.GetEmployeeCustomFieldValues(employeeID)
+	if err != nil {
+		utils.Error("Failed to get employee custom field values: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	customFields := make(map[string]interface{}, len(values))
+	if len(values) == 0 {
+		return customFields, nil
+	}
+
+	fields, err := repository.GetAllCustomFieldDefinitions()
+	if err != nil {
+		utils.Error("Failed to get custom field definitions: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	byID := make(map[uuid.UUID]model.CustomFieldDefinition, len(fields))
+	for _, field := range fields {
+		byID[field.ID] = field
+	}
+
+	for _, value := range values {
+		field, ok := byID[value.FieldID]
+		if !ok {
+			continue
+		}
+		if field.Type == model.CustomFieldTypeNumber {
+			if number, err := strconv.ParseFloat(value.Value, 64); err == nil {
+				customFields[field.Key] = number
+				continue
+			}
+		}
+		customFields[field.Key] = value.Value
+	}
+	return customFields, nil
+}
+
+// ResolveCustomFieldFilters turns cf.<key>=<value> list filters into filters on field IDs and normalized values
+func ResolveCustomFieldFilters(filters map[string]string) ([]model.CustomFieldFilter, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	resolved := make([]model.CustomFieldFilter, 0, len(filters))
+	for _, key := range keys {
+		field, err := repository.GetCustomFieldDefinitionByKey(strings.ToLower(key))
+		if err != nil {
+			return nil, &CustomFieldValueError{Message: "unknown custom field: " + key}
+		}
+		value, err := normalizeCustomFieldFilterValue(field, filters[key])
+		if err != nil {
+			return nil, &CustomFieldValueError{Message: err.Error()}
+		}
+		resolved = append(resolved, model.CustomFieldFilter{FieldID: field.ID, Value: value})
+	}
+	return resolved, nil
+}
+
+// normalizeCustomFieldValue validates a value against the type and rules of its field and returns the
+// text stored for it. Numbers may be sent as JSON numbers or strings.
+func normalizeCustomFieldValue(field model.CustomFieldDefinition, value interface{}) (string, error) {
+	switch field.Type {
+	case model.CustomFieldTypeNumber:
+		var number float64
+		switch v := value.(type) {
+		case float64:
+			number = v
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return "", fmt.Errorf("%s must be a number", field.Key)
+			}
+			number = parsed
+		default:
+			return "", fmt.Errorf("%s must be a number", field.Key)
+		}
+		if field.MinValue != nil && number < *field.MinValue {
+			return "", fmt.Errorf("%s must be at least %s", field.Key, strconv.FormatFloat(*field.MinValue, 'f', -1, 64))
+		}
+		if field.MaxValue != nil && number > *field.MaxValue {
+			return "", fmt.Errorf("%s must be at most %s", field.Key, strconv.FormatFloat(*field.MaxValue, 'f', -1, 64))
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%s must be a string", field.Key)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("%s cannot be empty", field.Key)
+	}
+
+	switch field.Type {
+	case model.CustomFieldTypeDate:
+		date, err := time.Parse("2006-01-02", text)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s format, use YYYY-MM-DD", field.Key)
+		}
+		return date.Format("2006-01-02"), nil
+	case model.CustomFieldTypeEnum:
+		for _, option := range field.Options {
+			if strings.EqualFold(option, text) {
+				return option, nil
+			}
+		}
+		return "", fmt.Errorf("invalid %s, must be one of: %s", field.Key, strings.Join(field.Options, ", "))
+	}
+
+	if len(text) > validator.MaxCustomFieldTextLength || (field.MaxLength != nil && len(text) > *field.MaxLength) {
+		maxLength := validator.MaxCustomFieldTextLength
+		if field.MaxLength != nil {
+			maxLength = *field.MaxLength
+		}
+		return "", fmt.Errorf("%s cannot be longer than %d characters", field.Key, maxLength)
+	}
+	if field.Pattern != "" {
+		pattern, err := regexp.Compile(`^(?:` + field.Pattern + `)$`)
+		if err != nil || !pattern.MatchString(text) {
+			return "", fmt.Errorf("%s does not match the required format", field.Key)
+		}
+	}
+	return text, nil
+}
+
+// normalizeCustomFieldFilterValue normalizes the value of a custom field list filter so it compares
+// equal to stored values. Range rules are not applied, a filter outside them simply matches nobody.
+func normalizeCustomFieldFilterValue(field model.CustomFieldDefinition, value string) (string, error) {
+	switch field.Type {
+	case model.CustomFieldTypeNumber:
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return "", fmt.Errorf("filter cf.%s must be a number", field.Key)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case model.CustomFieldTypeDate:
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("invalid filter cf.%s format, use YYYY-MM-DD", field.Key)
+		}
+		return date.Format("2006-01-02"), nil
+	}
+	return strings.TrimSpace(value), nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// CustomerMergeError represents an error when customers cannot be merged.
+type CustomerMergeError struct {
+	Reason string
+}
+
+func (e *CustomerMergeError) Error() string {
+	return "cannot merge customers: " + e.Reason
+}
+
+// UnknownEmployeeError represents an error when a referenced employee does not exist.
+type UnknownEmployeeError struct {
+	EmployeeID uuid.UUID
+}
+
+func (e *UnknownEmployeeError) Error() string {
+	return fmt.Sprintf("employee %s not found", e.EmployeeID)
+}
+
+// CheckEmployeesExist verifies that every referenced employee exists
+func CheckEmployeesExist(employeeIDs []uuid.UUID) error {
+	for _, employeeID := range employeeIDs {
+		exists, err := repository.CheckEmployeeExists(employeeID)
+		if err != nil {
+			utils.Error("Failed to check employee existence: " + err.Error())
+			return err
+		}
+		if !exists {
+			return &UnknownEmployeeError{EmployeeID: employeeID}
+		}
+	}
+	return nil
+}
+
+// MergeCustomerDuplicates merges the given duplicates into the primary customer.
+// Contact details missing on the primary are taken from the duplicates, notes are combined,
+// and marketing consent is kept as recorded on the primary because consent is not transferable.
+func MergeCustomerDuplicates(primaryID uuid.UUID, duplicateIDs []uuid.UUID) (*model.Customer, error) {
+	if len(duplicateIDs) == 0 {
+		return nil, &CustomerMergeError{Reason: "no duplicates given"}
+	}
+
+	primary, err := repository.GetCustomerByID(primaryID)
+	if err != nil {
+		return nil, fmt.Errorf("customer not found")
+	}
+	if primary.MergedIntoID != nil {
+		return nil, &CustomerMergeError{Reason: "the target customer was itself merged into another customer"}
+	}
+
+	notes := []string{}
+	if strings.TrimSpace(primary.Notes) != "" {
+		notes = append(notes, primary.Notes)
+	}
+
+	for _, duplicateID := range duplicateIDs {
+		if duplicateID == primaryID {
+			return nil, &CustomerMergeError{Reason: "a customer cannot be merged into itself"}
+		}
+
+		duplicate, err := repository.GetCustomerByID(duplicateID)
+		if err != nil {
+			return nil, &CustomerMergeError{Reason: fmt.Sprintf("customer %s not found", duplicateID)}
+		}
+		if duplicate.MergedIntoID != nil {
+			return nil, &CustomerMergeError{Reason: fmt.Sprintf("customer %s was already merged", duplicateID)}
+		}
+
+		if primary.Email == "" {
+			primary.Email = duplicate.Email
+		}
+		if primary.Phone == "" {
+			primary.Phone = duplicate.Phone
+		}
+		if strings.TrimSpace(duplicate.Notes) != "" {
+			notes = append(notes, duplicate.Notes)
+		}
+	}
+	primary.Notes = strings.Join(notes, "\n\n")
+
+	if err := repository.MergeCustomers(&primary, duplicateIDs); err != nil {
+		utils.Error("Failed to merge customers: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	merged, err := repository.GetCustomerByID(primaryID)
+	if err != nil {
+		return nil, fmt.Errorf("internal server error")
+	}
+	return &merged, nil
+}
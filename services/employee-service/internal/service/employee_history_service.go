@@ -0,0 +1,116 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EmployeeHistoryError represents a change that cannot be recorded in the employee history.
+type EmployeeHistoryError struct {
+	Message string
+}
+
+func (e *EmployeeHistoryError) Error() string {
+	return e.Message
+}
+
+// SaveEmployeeChanges saves an updated employee. Changes to role, active status, home location or
+// pay grade are recorded in the employee history as valid from effectiveFrom, which cannot be before
+// the last recorded change.
+func SaveEmployeeChanges(previous model.Employee, updated *model.Employee, effectiveFrom time.Time, changedBy string, reason string) error {
+	if !model.HistoryChanged(previous, *updated) {
+		if err := repository.UpdateEmployee(updated); err != nil {
+			utils.Error("Failed to update employee: " + err.Error())
+			return fmt.Errorf("internal server error")
+		}
+		return nil
+	}
+
+	current, err := repository.GetCurrentEmployeeHistory(updated.ID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		utils.Error("Failed to get employee history: " + err.Error())
+		return fmt.Errorf("internal server error")
+	}
+	if err == nil && effectiveFrom.Before(current.ValidFrom) {
+		return &EmployeeHistoryError{Message: fmt.Sprintf("effective_from cannot be before %s, the date of the last change", current.ValidFrom.Format("2006-01-02"))}
+	}
+
+	if err := repository.UpdateEmployeeWithHistory(updated, previous, effectiveFrom, changedBy, reason); err != nil {
+		utils.Error("Failed to update employee with history: " + err.Error())
+		return fmt.Errorf("internal server error")
+	}
+	return nil
+}
+
+// GetEmployeeHistory returns the versions of an employee, oldest first. Employees without history
+// get a single version with their current values from the day they were created.
+func GetEmployeeHistory(employee model.Employee) ([]model.EmployeeHistory, error) {
+	versions, err := repository.GetEmployeeHistory(employee.ID)
+	if err != nil {
+		utils.Error("Failed to get employee history: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	if len(versions) == 0 {
+		versions = append(versions, model.NewEmployeeHistory(employee, employee.CreatedAt.UTC().Truncate(24*time.Hour)))
+	}
+	return versions, nil
+}
+
+// GetEmployeeAsOf returns the employee with the attributes that were valid on a date.
+// ok is false when the employee did not exist yet on that date.
+func GetEmployeeAsOf(employee model.Employee, date time.Time) (model.Employee, bool, error) {
+	versions, err := repository.GetEmployeeHistory(employee.ID)
+	if err != nil {
+		utils.Error("Failed to get employee history: " + err.Error())
+		return employee, false, fmt.Errorf("internal server error")
+	}
+	asOf, ok := employeeAsOf(employee, versions, date)
+	return asOf, ok, nil
+}
+
+// ApplyEmployeeHistoryAsOf replaces the tracked attributes of employees with the values valid on a date
+func ApplyEmployeeHistoryAsOf(employees []model.Employee, date time.Time) error {
+	employeeIDs := make([]uuid.UUID, len(employees))
+	for i, employee := range employees {
+		employeeIDs[i] = employee.ID
+	}
+	versions, err := repository.GetEmployeeHistories(employeeIDs)
+	if err != nil {
+		utils.Error("Failed to get employee histories: " + err.Error())
+		return fmt.Errorf("internal server error")
+	}
+
+	byEmployee := make(map[uuid.UUID][]model.EmployeeHistory)
+	for _, version := range versions {
+		byEmployee[version.EmployeeID] = append(byEmployee[version.EmployeeID], version)
+	}
+	for i, employee := range employees {
+		employees[i], _ = employeeAsOf(employee, byEmployee[employee.ID], date)
+	}
+	return nil
+}
+
+// employeeAsOf applies the version valid on a date to an employee. Without versions the current
+// values apply from the day the employee was created.
+func employeeAsOf(employee model.Employee, versions []model.EmployeeHistory, date time.Time) (model.Employee, bool) {
+	if len(versions) == 0 {
+		return employee, !employee.CreatedAt.UTC().Truncate(24 * time.Hour).After(date)
+	}
+	for _, version := range versions {
+		if version.Covers(date) {
+			employee.Role = version.Role
+			employee.IsActive = version.IsActive
+			employee.LocationID = version.LocationID
+			employee.PayGrade = version.PayGrade
+			return employee, true
+		}
+	}
+	return employee, false
+}
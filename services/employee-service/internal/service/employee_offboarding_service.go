@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// EmploymentError represents an error when an action does not fit the employment period of an employee.
+type EmploymentError struct {
+	Reason string
+}
+
+func (e *EmploymentError) Error() string {
+	return e.Reason
+}
+
+// TerminateEmployee records the last working day of an employee and archives their schedules,
+// blocks, leave, swaps and open shift claims after it. A termination can be moved earlier but not later;
+// restore the employee to undo it.
+func TerminateEmployee(employee model.Employee, terminationDate time.Time, decidedBy string) (*model.Employee, error) {
+	if employee.TerminationDate != nil && employee.TerminationDate.Before(terminationDate) {
+		return nil, &EmploymentError{Reason: fmt.Sprintf("employee was already terminated on %s", employee.TerminationDate.Format("2006-01-02"))}
+	}
+
+	if err := repository.TerminateEmployee(&employee, terminationDate, decidedBy, time.Now()); err != nil {
+		utils.Error(fmt.Sprintf("Failed to terminate employee %s: %v", employee.ID, err))
+		return nil, fmt.Errorf("internal server error")
+	}
+	utils.Info(fmt.Sprintf("Employee %s terminated as of %s", employee.ID, terminationDate.Format("2006-01-02")))
+
+	return &employee, nil
+}
+
+// OffboardEmployee soft-deletes an employee. Employees who have not been terminated yet are
+// terminated as of today first so nothing stays scheduled for them.
+func OffboardEmployee(employee model.Employee, decidedBy string) error {
+	if employee.TerminationDate == nil {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		if _, err := TerminateEmployee(employee, today, decidedBy); err != nil {
+			return err
+		}
+	}
+
+	if err := repository.DeleteEmployee(employee.ID); err != nil {
+		utils.Error(fmt.Sprintf("Failed to delete employee %s: %v", employee.ID, err))
+		return fmt.Errorf("internal server error")
+	}
+	return nil
+}
+
+// CheckEmploymentPeriod checks that a schedule valid from validFrom until validUntil (nil for
+// open-ended) ends by the termination date of the employee.
+func CheckEmploymentPeriod(employee model.Employee, validFrom time.Time, validUntil *time.Time) error {
+	if employee.TerminationDate == nil {
+		return nil
+	}
+	terminationDate := employee.TerminationDate.Format("2006-01-02")
+	if validFrom.After(*employee.TerminationDate) {
+		return &EmploymentError{Reason: fmt.Sprintf("employee leaves on %s and cannot be scheduled after that", terminationDate)}
+	}
+	if validUntil == nil || validUntil.After(*employee.TerminationDate) {
+		return &EmploymentError{Reason: fmt.Sprintf("employee leaves on %s, valid_until must not be later", terminationDate)}
+	}
+	return nil
+}
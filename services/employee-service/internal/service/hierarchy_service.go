@@ -0,0 +1,184 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"gorm.io/gorm"
+)
+
+// HierarchyError represents an error when a team or reporting line change would break the hierarchy.
+type HierarchyError struct {
+	Reason string
+}
+
+func (e *HierarchyError) Error() string {
+	return e.Reason
+}
+
+// ScopeError represents an error when a manager acts on an employee outside the people they manage.
+type ScopeError struct {
+	Reason string
+}
+
+func (e *ScopeError) Error() string {
+	return e.Reason
+}
+
+// AssignManager sets the manager of an employee, or removes it when managerID is nil.
+// The manager must be a current employee and must not report to the employee, directly or indirectly.
+func AssignManager(employee model.Employee, managerID *uuid.UUID) (*model.Employee, error) {
+	if managerID != nil {
+		if *managerID == employee.ID {
+			return nil, &HierarchyError{Reason: "an employee cannot be their own manager"}
+		}
+		manager, err := repository.GetEmployeeByID(*managerID)
+		if err != nil {
+			return nil, fmt.Errorf("manager not found")
+		}
+		if !manager.IsEmployedOn(time.Now().UTC().Truncate(24 * time.Hour)) {
+			return nil, &HierarchyError{Reason: "manager has left the company"}
+		}
+
+		reports, err := repository.GetReports(employee.ID, maxReportingDepth)
+		if err != nil {
+			utils.Error(fmt.Sprintf("Failed to get reports of employee %s: %v", employee.ID, err))
+			return nil, fmt.Errorf("internal server error")
+		}
+		for _, report := range reports {
+			if report.ID == *managerID {
+				return nil, &HierarchyError{Reason: "manager reports to this employee, which would create a cycle"}
+			}
+		}
+	}
+
+	if err := repository.SetEmployeeManager(&employee, managerID); err != nil {
+		utils.Error(fmt.Sprintf("Failed to set manager of employee %s: %v", employee.ID, err))
+		return nil, fmt.Errorf("internal server error")
+	}
+	return &employee, nil
+}
+
+// AssignTeam moves an employee into a team, or out of any team when teamID is nil
+func AssignTeam(employee model.Employee, teamID *uuid.UUID) (*model.Employee, error) {
+	if teamID != nil {
+		if _, err := repository.GetTeamByID(*teamID); err != nil {
+			return nil, fmt.Errorf("team not found")
+		}
+	}
+
+	if err := repository.SetEmployeeTeam(&employee, teamID); err != nil {
+		utils.Error(fmt.Sprintf("Failed to set team of employee %s: %v", employee.ID, err))
+		return nil, fmt.Errorf("internal server error")
+	}
+	return &employee, nil
+}
+
+// CheckTeamHierarchy checks the parent team and lead of a team before they are saved.
+// The parent must exist and must not be the team itself or one of its sub-teams.
+func CheckTeamHierarchy(team model.Team) error {
+	if team.ParentID != nil {
+		if *team.ParentID == team.ID {
+			return &HierarchyError{Reason: "a team cannot be its own parent"}
+		}
+		if _, err := repository.GetTeamByID(*team.ParentID); err != nil {
+			return fmt.Errorf("parent team not found")
+		}
+		if team.ID != uuid.Nil {
+			subTeamIDs, err := repository.GetSubTeamIDs(team.ID)
+			if err != nil {
+				utils.Error(fmt.Sprintf("Failed to get sub-teams of team %s: %v", team.ID, err))
+				return fmt.Errorf("internal server error")
+			}
+			for _, id := range subTeamIDs {
+				if id == *team.ParentID {
+					return &HierarchyError{Reason: "parent team is nested inside this team, which would create a cycle"}
+				}
+			}
+		}
+	}
+
+	if team.LeadID != nil {
+		lead, err := repository.GetEmployeeByID(*team.LeadID)
+		if err != nil {
+			return fmt.Errorf("lead not found")
+		}
+		if !lead.IsEmployedOn(time.Now().UTC().Truncate(24 * time.Hour)) {
+			return &HierarchyError{Reason: "lead has left the company"}
+		}
+	}
+	return nil
+}
+
+// GetTeamMembers returns the current members of a team, including the members of its sub-teams when requested
+func GetTeamMembers(team model.Team, includeSubTeams bool) ([]model.Employee, error) {
+	teamIDs := []uuid.UUID{team.ID}
+	if includeSubTeams {
+		subTeamIDs, err := repository.GetSubTeamIDs(team.ID)
+		if err != nil {
+			return nil, err
+		}
+		teamIDs = append(teamIDs, subTeamIDs...)
+	}
+	return repository.GetTeamMembers(teamIDs)
+}
+
+// maxReportingDepth is the depth used when listing indirect reports
+const maxReportingDepth = 32
+
+// GetReports returns the direct reports of a manager, or all direct and indirect reports when requested
+func GetReports(managerID uuid.UUID, indirect bool) ([]model.EmployeeReport, error) {
+	depth := 1
+	if indirect {
+		depth = maxReportingDepth
+	}
+	return repository.GetReports(managerID, depth)
+}
+
+// CheckManagerScope checks that the acting user may act on the given employees.
+// They may only act on the employees they manage through their reporting line or the teams they
+// lead, and on themselves when allowSelf is set. Users that are not linked to an employee manage
+// nobody. Owners are not limited by the hierarchy; the handlers skip this check for them.
+func CheckManagerScope(actingUserID string, allowSelf bool, employeeIDs ...uuid.UUID) error {
+	userID, err := uuid.Parse(actingUserID)
+	if err != nil {
+		return &ScopeError{Reason: "your account is not linked to an employee"}
+	}
+	manager, err := repository.GetEmployeeByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &ScopeError{Reason: "your account is not linked to an employee"}
+		}
+		utils.Error(fmt.Sprintf("Failed to get employee of user %s: %v", userID, err))
+		return fmt.Errorf("internal server error")
+	}
+
+	managed, err := repository.GetManagedEmployeeIDs(manager.ID)
+	if err != nil {
+		utils.Error(fmt.Sprintf("Failed to get employees managed by %s: %v", manager.ID, err))
+		return fmt.Errorf("internal server error")
+	}
+	inScope := make(map[uuid.UUID]bool, len(managed))
+	for _, id := range managed {
+		inScope[id] = true
+	}
+
+	for _, employeeID := range employeeIDs {
+		if employeeID == manager.ID {
+			if allowSelf {
+				continue
+			}
+			return &ScopeError{Reason: "managers cannot decide on their own requests"}
+		}
+		if !inScope[employeeID] {
+			return &ScopeError{Reason: "employee is not managed by you"}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// DuplicateLeaveEntitlementError represents an error when an employee already has an entitlement for the leave type and year.
+type DuplicateLeaveEntitlementError struct{}
+
+func (e *DuplicateLeaveEntitlementError) Error() string {
+	return "employee already has an entitlement for this leave type and year"
+}
+
+// CheckForDuplicateLeaveEntitlement checks whether the entitlement would duplicate an existing one.
+func CheckForDuplicateLeaveEntitlement(entitlement *model.LeaveEntitlement, excludeID *uuid.UUID) error {
+	exists, err := repository.CheckDuplicateLeaveEntitlement(entitlement.EmployeeID, entitlement.LeaveType, entitlement.Year, excludeID)
+	if err != nil {
+		utils.Error("Failed to check for duplicate leave entitlements: " + err.Error())
+		return err
+	}
+	if exists {
+		return &DuplicateLeaveEntitlementError{}
+	}
+	return nil
+}
+
+// scheduleResolver resolves and caches the schedule of one employee per date,
+// so overlapping leave requests do not query the same day twice. The recurring
+// breaks of the employee are loaded once on first use.
+type scheduleResolver struct {
+	employeeID uuid.UUID
+	cache      map[string]*model.Schedule
+	breaks     []model.RecurringBreak
+	breaksRead bool
+}
+
+func newScheduleResolver(employeeID uuid.UUID) *scheduleResolver {
+	return &scheduleResolver{employeeID: employeeID, cache: make(map[string]*model.Schedule)}
+}
+
+func (r *scheduleResolver) scheduleForDate(date time.Time) (*model.Schedule, error) {
+	key := date.Format("2006-01-02")
+	if schedule, ok := r.cache[key]; ok {
+		return schedule, nil
+	}
+	schedule, err := repository.GetEmployeeScheduleForDate(r.employeeID, date)
+	if err != nil {
+		return nil, err
+	}
+	r.cache[key] = schedule
+	return schedule, nil
+}
+
+func (r *scheduleResolver) recurringBreaks() ([]model.RecurringBreak, error) {
+	if !r.breaksRead {
+		breaks, err := repository.GetEmployeeRecurringBreaks(r.employeeID)
+		if err != nil {
+			return nil, err
+		}
+		r.breaks = breaks
+		r.breaksRead = true
+	}
+	return r.breaks, nil
+}
+
+// scheduledWorkRanges returns the scheduled time of a date without the recurring breaks of that weekday
+func scheduledWorkRanges(date time.Time, schedule *model.Schedule, breaks []model.RecurringBreak) []model.TimeRange {
+	breakRanges := make([]model.TimeRange, 0)
+	for _, recurringBreak := range breaks {
+		if recurringBreak.DayOfWeek == int(date.Weekday()) {
+			breakRanges = append(breakRanges, timeOfDayRange(date, recurringBreak.StartTime, recurringBreak.EndTime))
+		}
+	}
+	return subtractTimeRanges([]model.TimeRange{timeOfDayRange(date, schedule.StartTime, schedule.EndTime)}, breakRanges)
+}
+
+// scheduledLeaveHours returns how many working hours a leave request covers on schedule dates
+// within [periodStart, periodEnd), and on how many scheduled days it takes time off.
+// Recurring breaks are not worked and cost nothing, nor does leave on days without a schedule.
+func (r *scheduleResolver) scheduledLeaveHours(leaveRequest model.LeaveRequest, periodStart time.Time, periodEnd time.Time) (float64, int, error) {
+	leaveRange := model.TimeRange{Start: leaveRequest.StartDateTime.UTC(), End: leaveRequest.EndDateTime.UTC()}
+
+	// Start one day early so a shift that crosses midnight into the leave is counted
+	first := time.Date(leaveRange.Start.Year(), leaveRange.Start.Month(), leaveRange.Start.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	if first.Before(periodStart) {
+		first = periodStart
+	}
+
+	breaks, err := r.recurringBreaks()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var hours float64
+	days := 0
+	for date := first; date.Before(leaveRange.End) && date.Before(periodEnd); date = date.AddDate(0, 0, 1) {
+		schedule, err := r.scheduleForDate(date)
+		if err != nil {
+			return 0, 0, err
+		}
+		if schedule == nil {
+			continue
+		}
+
+		var dayHours float64
+		for _, overlap := range intersectTimeRanges(scheduledWorkRanges(date, schedule, breaks), []model.TimeRange{leaveRange}) {
+			dayHours += overlap.End.Sub(overlap.Start).Hours()
+		}
+		if dayHours > 0 {
+			hours += dayHours
+			days++
+		}
+	}
+
+	return hours, days, nil
+}
+
+// CalculateLeaveBalance computes the balance of an employee for a leave type and year as of a date.
+// Returns nil when the employee has no entitlement for that leave type and year.
+func CalculateLeaveBalance(employeeID uuid.UUID, leaveType string, year int, asOf time.Time) (*model.LeaveBalance, error) {
+	entitlement, err := repository.GetLeaveEntitlement(employeeID, leaveType, year)
+	if err != nil {
+		utils.Error(fmt.Sprintf("Failed to get leave entitlement for employee %s: %v", employeeID, err))
+		return nil, fmt.Errorf("internal server error")
+	}
+	if entitlement == nil {
+		return nil, nil
+	}
+
+	return calculateBalanceForEntitlement(*entitlement, asOf, newScheduleResolver(employeeID))
+}
+
+// GetEmployeeLeaveBalances computes the balance of every leave type the employee has an entitlement for in the year
+func GetEmployeeLeaveBalances(employeeID uuid.UUID, year int, asOf time.Time) ([]model.LeaveBalance, error) {
+	entitlements, err := repository.GetFilteredLeaveEntitlements(&employeeID, &year)
+	if err != nil {
+		utils.Error(fmt.Sprintf("Failed to get leave entitlements for employee %s: %v", employeeID, err))
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	resolver := newScheduleResolver(employeeID)
+	balances := make([]model.LeaveBalance, 0, len(entitlements))
+	for _, entitlement := range entitlements {
+		balance, err := calculateBalanceForEntitlement(entitlement, asOf, resolver)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, *balance)
+	}
+
+	return balances, nil
+}
+
+// calculateBalanceForEntitlement computes accrual, carry-over and usage for one entitlement.
+// Carry-over is the unused balance at the end of the previous year, limited by that year's cap,
+// and is only available when the previous year had an entitlement as well.
+func calculateBalanceForEntitlement(entitlement model.LeaveEntitlement, asOf time.Time, resolver *scheduleResolver) (*model.LeaveBalance, error) {
+	yearStart := time.Date(entitlement.Year, 1, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+
+	balance := &model.LeaveBalance{
+		EmployeeID:     entitlement.EmployeeID,
+		LeaveType:      entitlement.LeaveType,
+		Year:           entitlement.Year,
+		AsOf:           asOf,
+		AllowanceHours: entitlement.AllowanceHours,
+		AccruedHours:   accruedHours(entitlement, asOf),
+	}
+
+	// Carry-over from the previous year
+	previous, err := repository.GetLeaveEntitlement(entitlement.EmployeeID, entitlement.LeaveType, entitlement.Year-1)
+	if err != nil {
+		utils.Error(fmt.Sprintf("Failed to get previous leave entitlement for employee %s: %v", entitlement.EmployeeID, err))
+		return nil, fmt.Errorf("internal server error")
+	}
+	if previous != nil {
+		previousBalance, err := calculateBalanceForEntitlement(*previous, yearStart.AddDate(0, 0, -1), resolver)
+		if err != nil {
+			return nil, err
+		}
+		balance.CarriedOverHours = math.Max(0, math.Min(previousBalance.AvailableHours, previous.CarryOverCapHours))
+	}
+
+	// Used and pending hours within the year
+	for _, status := range []string{model.LeaveStatusApproved, model.LeaveStatusPending} {
+		leaveRequests, err := repository.GetLeaveRequestsInPeriod(entitlement.EmployeeID, entitlement.LeaveType, status, yearStart.AddDate(0, 0, -1), yearEnd)
+		if err != nil {
+			utils.Error(fmt.Sprintf("Failed to get leave requests for employee %s: %v", entitlement.EmployeeID, err))
+			return nil, fmt.Errorf("internal server error")
+		}
+
+		for _, leaveRequest := range leaveRequests {
+			hours, days, err := resolver.scheduledLeaveHours(leaveRequest, yearStart, yearEnd)
+			if err != nil {
+				utils.Error(fmt.Sprintf("Failed to resolve schedules for employee %s: %v", entitlement.EmployeeID, err))
+				return nil, fmt.Errorf("internal server error")
+			}
+			if status == model.LeaveStatusApproved {
+				balance.UsedHours += hours
+				balance.UsedScheduledDays += days
+			} else {
+				balance.PendingHours += hours
+			}
+		}
+	}
+
+	balance.AccruedHours = roundHours(balance.AccruedHours)
+	balance.CarriedOverHours = roundHours(balance.CarriedOverHours)
+	balance.UsedHours = roundHours(balance.UsedHours)
+	balance.PendingHours = roundHours(balance.PendingHours)
+	balance.AvailableHours = roundHours(balance.AccruedHours + balance.CarriedOverHours - balance.UsedHours)
+
+	return balance, nil
+}
+
+// accruedHours returns the part of the allowance earned as of the date.
+// Monthly accrual adds one twelfth at the start of each month.
+func accruedHours(entitlement model.LeaveEntitlement, asOf time.Time) float64 {
+	if asOf.Year() < entitlement.Year {
+		return 0
+	}
+	if asOf.Year() > entitlement.Year || entitlement.AccrualMethod != model.AccrualMonthly {
+		return entitlement.AllowanceHours
+	}
+	return entitlement.AllowanceHours * float64(asOf.Month()) / 12
+}
+
+// roundHours rounds hours to two decimals for display
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
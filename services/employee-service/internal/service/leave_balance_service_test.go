@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// timeOfDay returns a time-of-day value as stored for schedules and breaks
+func timeOfDay(hour, minute int) time.Time {
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestScheduledWorkRanges(t *testing.T) {
+	monday := at(0, 0) // 2025-03-10 is a Monday
+	schedule := &model.Schedule{StartTime: timeOfDay(9, 0), EndTime: timeOfDay(17, 0)}
+	lunch := model.RecurringBreak{DayOfWeek: 1, StartTime: timeOfDay(12, 0), EndTime: timeOfDay(13, 0)}
+	tuesdayLunch := model.RecurringBreak{DayOfWeek: 2, StartTime: timeOfDay(12, 0), EndTime: timeOfDay(13, 0)}
+
+	tests := []struct {
+		name     string
+		schedule *model.Schedule
+		breaks   []model.RecurringBreak
+		want     []model.TimeRange
+	}{
+		{"no breaks", schedule, nil, []model.TimeRange{span(9, 0, 17, 0)}},
+		{"break on the day", schedule, []model.RecurringBreak{lunch}, []model.TimeRange{span(9, 0, 12, 0), span(13, 0, 17, 0)}},
+		{"break on another day", schedule, []model.RecurringBreak{tuesdayLunch}, []model.TimeRange{span(9, 0, 17, 0)}},
+		{
+			"overnight schedule",
+			&model.Schedule{StartTime: timeOfDay(20, 0), EndTime: timeOfDay(4, 0)},
+			[]model.RecurringBreak{{DayOfWeek: 1, StartTime: timeOfDay(23, 30), EndTime: timeOfDay(0, 30)}},
+			[]model.TimeRange{
+				span(20, 0, 23, 30),
+				{Start: at(0, 30).AddDate(0, 0, 1), End: at(4, 0).AddDate(0, 0, 1)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scheduledWorkRanges(monday, tt.schedule, tt.breaks); !equalRanges(got, tt.want) {
+				t.Errorf("scheduledWorkRanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccruedHours(t *testing.T) {
+	upfront := model.LeaveEntitlement{Year: 2025, AllowanceHours: 120, AccrualMethod: model.AccrualUpfront}
+	monthly := model.LeaveEntitlement{Year: 2025, AllowanceHours: 120, AccrualMethod: model.AccrualMonthly}
+
+	tests := []struct {
+		name        string
+		entitlement model.LeaveEntitlement
+		asOf        time.Time
+		want        float64
+	}{
+		{"upfront before the year", upfront, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), 0},
+		{"upfront on the first day", upfront, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 120},
+		{"monthly in january", monthly, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), 10},
+		{"monthly in june", monthly, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), 60},
+		{"monthly in december", monthly, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), 120},
+		{"monthly after the year", monthly, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), 120},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := accruedHours(tt.entitlement, tt.asOf); got != tt.want {
+				t.Errorf("accruedHours() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
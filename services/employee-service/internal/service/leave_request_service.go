@@ -0,0 +1,205 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// LeaveStatusError represents an error when a leave request is not in a status that allows the action.
+type LeaveStatusError struct {
+	Status string
+	Action string
+}
+
+func (e *LeaveStatusError) Error() string {
+	return fmt.Sprintf("cannot %s a leave request that is %s", e.Action, e.Status)
+}
+
+// LeaveConflictError represents an error when a leave request cannot be approved because of conflicts.
+type LeaveConflictError struct {
+	Conflicts []model.LeaveConflict
+}
+
+func (e *LeaveConflictError) Error() string {
+	return "leave request conflicts with existing one-time blocks"
+}
+
+// BuildLeaveRequestModel creates a leave request model from validated inputs.
+func BuildLeaveRequestModel(
+	employeeID uuid.UUID,
+	leaveType string,
+	startDateTime time.Time,
+	endDateTime time.Time,
+	reason string,
+	requestedBy string,
+) *model.LeaveRequest {
+	return &model.LeaveRequest{
+		EmployeeID:    employeeID,
+		Type:          leaveType,
+		Status:        model.LeaveStatusPending,
+		StartDateTime: startDateTime,
+		EndDateTime:   endDateTime,
+		Reason:        reason,
+		RequestedBy:   requestedBy,
+	}
+}
+
+// GetLeaveConflicts returns everything that clashes with a leave request:
+// one-time blocks of the employee (other than the one created by this request)
+// and other pending or approved leave of the same employee.
+func GetLeaveConflicts(leaveRequest model.LeaveRequest) ([]model.LeaveConflict, error) {
+	conflicts := []model.LeaveConflict{}
+
+	blocks, err := repository.GetOverlappingOnetimeBlocks(leaveRequest.EmployeeID, leaveRequest.StartDateTime, leaveRequest.EndDateTime, leaveRequest.OnetimeBlockID)
+	if err != nil {
+		utils.Error("Failed to get overlapping one-time blocks: " + err.Error())
+		return nil, err
+	}
+	for _, block := range blocks {
+		conflicts = append(conflicts, model.LeaveConflict{
+			Type:        "onetime_block",
+			ReferenceID: block.ID,
+			StartTime:   block.StartDateTime,
+			EndTime:     block.EndDateTime,
+			Description: block.Reason,
+		})
+	}
+
+	otherLeave, err := repository.GetOverlappingLeaveRequests(leaveRequest.EmployeeID, leaveRequest.StartDateTime, leaveRequest.EndDateTime, &leaveRequest.ID)
+	if err != nil {
+		utils.Error("Failed to get overlapping leave requests: " + err.Error())
+		return nil, err
+	}
+	for _, other := range otherLeave {
+		// Approved leave already shows up through its one-time block
+		if other.OnetimeBlockID != nil {
+			continue
+		}
+		conflicts = append(conflicts, model.LeaveConflict{
+			Type:        "leave_request",
+			ReferenceID: other.ID,
+			StartTime:   other.StartDateTime,
+			EndTime:     other.EndDateTime,
+			Description: fmt.Sprintf("%s leave (%s)", other.Type, other.Status),
+		})
+	}
+
+	return conflicts, nil
+}
+
+// ApproveLeaveRequest approves a pending leave request and creates its one-time block.
+// Approval is refused while the request overlaps an existing one-time block, since
+// the block could not be created without overlapping.
+func ApproveLeaveRequest(leaveRequest model.LeaveRequest, decidedBy string, note string) (*model.LeaveRequest, error) {
+	if leaveRequest.Status != model.LeaveStatusPending {
+		return nil, &LeaveStatusError{Status: leaveRequest.Status, Action: "approve"}
+	}
+
+	if err := checkLeaveBlockConflicts(leaveRequest); err != nil {
+		return nil, err
+	}
+
+	block := BuildOnetimeBlockModel(
+		leaveRequest.EmployeeID,
+		leaveRequest.StartDateTime,
+		leaveRequest.EndDateTime,
+		fmt.Sprintf("%s leave", leaveRequest.Type),
+	)
+	if leaveRequest.Reason != "" {
+		block.Reason = fmt.Sprintf("%s leave: %s", leaveRequest.Type, leaveRequest.Reason)
+	}
+
+	now := time.Now()
+	leaveRequest.DecidedBy = decidedBy
+	leaveRequest.DecidedAt = &now
+	leaveRequest.DecisionNote = note
+
+	if err := repository.ApproveLeaveRequest(&leaveRequest, block); err != nil {
+		if err == repository.ErrLeaveRequestStatusChanged {
+			return nil, &LeaveStatusError{Status: "no longer pending", Action: "approve"}
+		}
+		if err == repository.ErrLeaveOverlapsBlock {
+			// A block was created since the check above, e.g. by approving another request
+			if err := checkLeaveBlockConflicts(leaveRequest); err != nil {
+				return nil, err
+			}
+			return nil, &LeaveConflictError{Conflicts: []model.LeaveConflict{}}
+		}
+		utils.Error("Failed to approve leave request: " + err.Error())
+		return nil, err
+	}
+
+	return &leaveRequest, nil
+}
+
+// checkLeaveBlockConflicts returns a LeaveConflictError when leave overlaps one-time blocks of the employee
+func checkLeaveBlockConflicts(leaveRequest model.LeaveRequest) error {
+	conflicts, err := GetLeaveConflicts(leaveRequest)
+	if err != nil {
+		return err
+	}
+	blocking := []model.LeaveConflict{}
+	for _, conflict := range conflicts {
+		if conflict.Type == "onetime_block" {
+			blocking = append(blocking, conflict)
+		}
+	}
+	if len(blocking) > 0 {
+		return &LeaveConflictError{Conflicts: blocking}
+	}
+	return nil
+}
+
+// RejectLeaveRequest rejects a pending leave request.
+func RejectLeaveRequest(leaveRequest model.LeaveRequest, decidedBy string, note string) (*model.LeaveRequest, error) {
+	if leaveRequest.Status != model.LeaveStatusPending {
+		return nil, &LeaveStatusError{Status: leaveRequest.Status, Action: "reject"}
+	}
+
+	now := time.Now()
+	leaveRequest.Status = model.LeaveStatusRejected
+	leaveRequest.DecidedBy = decidedBy
+	leaveRequest.DecidedAt = &now
+	leaveRequest.DecisionNote = note
+
+	if err := repository.DecideLeaveRequest(&leaveRequest, []string{model.LeaveStatusPending}); err != nil {
+		if err == repository.ErrLeaveRequestStatusChanged {
+			return nil, &LeaveStatusError{Status: "no longer pending", Action: "reject"}
+		}
+		utils.Error("Failed to reject leave request: " + err.Error())
+		return nil, err
+	}
+
+	return &leaveRequest, nil
+}
+
+// CancelLeaveRequest cancels a pending or approved leave request.
+// Cancelling approved leave removes the one-time block it created.
+func CancelLeaveRequest(leaveRequest model.LeaveRequest, decidedBy string, note string) (*model.LeaveRequest, error) {
+	if leaveRequest.Status != model.LeaveStatusPending && leaveRequest.Status != model.LeaveStatusApproved {
+		return nil, &LeaveStatusError{Status: leaveRequest.Status, Action: "cancel"}
+	}
+
+	now := time.Now()
+	leaveRequest.Status = model.LeaveStatusCancelled
+	leaveRequest.DecidedBy = decidedBy
+	leaveRequest.DecidedAt = &now
+	leaveRequest.DecisionNote = note
+
+	expected := []string{model.LeaveStatusPending, model.LeaveStatusApproved}
+	if err := repository.DecideLeaveRequest(&leaveRequest, expected); err != nil {
+		if err == repository.ErrLeaveRequestStatusChanged {
+			return nil, &LeaveStatusError{Status: "already decided", Action: "cancel"}
+		}
+		utils.Error("Failed to cancel leave request: " + err.Error())
+		return nil, err
+	}
+
+	return &leaveRequest, nil
+}
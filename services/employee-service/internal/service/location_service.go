@@ -0,0 +1,230 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// DuplicateLocationError represents an error when a location with the same name already exists.
+type DuplicateLocationError struct{}
+
+func (e *DuplicateLocationError) Error() string {
+	return "a location with this name already exists"
+}
+
+// OverlappingLocationOpeningHourError represents an error when opening hours overlap with existing ones.
+type OverlappingLocationOpeningHourError struct{}
+
+func (e *OverlappingLocationOpeningHourError) Error() string {
+	return "these opening hours overlap with existing opening hours for the same location on the same day"
+}
+
+// LocationConflictError represents an error when a schedule overlaps a schedule of the same employee at
+// another location, or a schedule without a location. LocationID is nil in the latter case.
+type LocationConflictError struct {
+	ScheduleID uuid.UUID
+	LocationID *uuid.UUID
+}
+
+func (e *LocationConflictError) Error() string {
+	if e.LocationID == nil {
+		return "employee already has a schedule without a location at overlapping times"
+	}
+	return "employee is already scheduled at another location at overlapping times"
+}
+
+// BuildLocationOpeningHourModel creates an opening hour model from validated inputs.
+func BuildLocationOpeningHourModel(locationID uuid.UUID, dayOfWeek int, startTime time.Time, endTime time.Time) *model.LocationOpeningHour {
+	return &model.LocationOpeningHour{
+		LocationID: locationID,
+		DayOfWeek:  dayOfWeek,
+		StartTime:  startTime,
+		EndTime:    endTime,
+	}
+}
+
+// CheckForDuplicateLocation checks if another location already uses the same name.
+func CheckForDuplicateLocation(location *model.Location) error {
+	existing, err := repository.GetLocationByName(location.Name)
+	if err == nil && existing.ID != location.ID {
+		return &DuplicateLocationError{}
+	}
+	return nil
+}
+
+// CheckForOverlappingLocationOpeningHour is a service-level function to check for overlaps.
+func CheckForOverlappingLocationOpeningHour(openingHour *model.LocationOpeningHour, excludeID *uuid.UUID) error {
+	hasOverlap, err := repository.CheckOverlappingLocationOpeningHour(
+		openingHour.LocationID,
+		openingHour.DayOfWeek,
+		openingHour.StartTime,
+		openingHour.EndTime,
+		excludeID,
+	)
+	if err != nil {
+		utils.Error("Failed to check for overlapping location opening hours: " + err.Error())
+		return err
+	}
+	if hasOverlap {
+		return &OverlappingLocationOpeningHourError{}
+	}
+	return nil
+}
+
+// CheckScheduleLocationConflict checks that a schedule never puts its employee at two locations at the
+// same time. Every shift of the schedule is compared with the shifts of the employee's schedules at
+// other locations, including shifts crossing midnight. A schedule without a location may be worked
+// anywhere, so it must not overlap a located schedule either. Schedules at the same location, or both
+// without one, are not compared: the one with the latest valid_from replaces the other.
+func CheckScheduleLocationConflict(schedule *model.Schedule, excludeID *uuid.UUID) error {
+	others, err := repository.GetEmployeeSchedulesAtOtherLocations(schedule.EmployeeID, schedule.LocationID, schedule.ValidFrom, schedule.ValidUntil, excludeID)
+	if err != nil {
+		utils.Error("Failed to get schedules at other locations: " + err.Error())
+		return fmt.Errorf("internal server error")
+	}
+	for _, other := range others {
+		if schedulesOverlap(*schedule, other) {
+			return &LocationConflictError{ScheduleID: other.ID, LocationID: other.LocationID}
+		}
+	}
+	return nil
+}
+
+// schedulesOverlap reports whether any shift of a overlaps any shift of b.
+// Weekly schedules repeat, so comparing the shifts of the first two weeks both are valid is enough.
+func schedulesOverlap(a model.Schedule, b model.Schedule) bool {
+	from := a.ValidFrom
+	if b.ValidFrom.After(from) {
+		from = b.ValidFrom
+	}
+	from = from.AddDate(0, 0, -1) // Shifts of the previous day may cross midnight
+	to := from.AddDate(0, 0, 15)
+
+	shiftsB := scheduleShifts(b, from, to)
+	for _, shiftA := range scheduleShifts(a, from, to) {
+		for _, shiftB := range shiftsB {
+			if shiftA.HasOverlap(shiftB) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// scheduleShifts returns the shifts a schedule describes on the dates between from and to
+func scheduleShifts(schedule model.Schedule, from time.Time, to time.Time) []model.TimeRange {
+	start := schedule.ValidFrom
+	if from.After(start) {
+		start = from
+	}
+	end := to
+	if schedule.ValidUntil != nil && schedule.ValidUntil.Before(end) {
+		end = *schedule.ValidUntil
+	}
+
+	var shifts []model.TimeRange
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		if int(date.Weekday()) == schedule.DayOfWeek {
+			shifts = append(shifts, timeOfDayRange(date, schedule.StartTime, schedule.EndTime))
+		}
+	}
+	return shifts
+}
+
+// GetLocationCoverage lists who works at a location on every date of a period and the opening hours
+// during which nobody is free to serve customers. Shifts crossing midnight cover the next morning too.
+func (s *AvailabilityService) GetLocationCoverage(location model.Location, startDate time.Time, endDate time.Time) (*model.LocationCoverage, error) {
+	coverage := &model.LocationCoverage{
+		LocationID: location.ID,
+		TimeZone:   location.TimeZone,
+		StartDate:  startDate.Format("2006-01-02"),
+		EndDate:    endDate.Format("2006-01-02"),
+		Days:       []model.LocationCoverageDay{},
+	}
+
+	employees := make(map[uuid.UUID]model.Employee)
+	histories := make(map[uuid.UUID][]model.EmployeeHistory)
+	var previousFree []model.TimeRange
+	for date := startDate.AddDate(0, 0, -1); !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		// 1. Shifts worked at the location on the date
+		employeeIDs, err := repository.GetLocationEmployeeIDsForDate(location.ID, date)
+		if err != nil {
+			utils.Error(fmt.Sprintf("Failed to get employees of location %s on %s: %v", location.ID, date.Format("2006-01-02"), err))
+			return nil, fmt.Errorf("internal server error")
+		}
+
+		day := model.LocationCoverageDay{
+			Date:           date.Format("2006-01-02"),
+			OpeningHours:   []model.AvailabilitySchedule{},
+			Shifts:         []model.LocationShift{},
+			UncoveredSlots: []model.AvailabilitySlot{},
+		}
+		free := []model.TimeRange{}
+		for _, employeeID := range employeeIDs {
+			availability, err := s.GetEmployeeAvailabilityAtLocation(employeeID, date, &location.ID)
+			if err != nil {
+				if err.Error() == "no schedule found for employee on this date" || err.Error() == "employee not found" {
+					continue // The schedule ended meanwhile, or the employee was removed
+				}
+				return nil, err
+			}
+
+			employee, ok := employees[employeeID]
+			if !ok {
+				employee, err = repository.GetEmployeeByID(employeeID)
+				if err != nil {
+					continue
+				}
+				employees[employeeID] = employee
+				if histories[employeeID], err = repository.GetEmployeeHistory(employeeID); err != nil {
+					utils.Error("Failed to get employee history: " + err.Error())
+					return nil, fmt.Errorf("internal server error")
+				}
+			}
+			// The role the employee had on the date
+			employeeAsOfDate, _ := employeeAsOf(employee, histories[employeeID], date)
+			day.Shifts = append(day.Shifts, model.LocationShift{
+				EmployeeID: employeeID,
+				FirstName:  employee.FirstName,
+				LastName:   employee.LastName,
+				Role:       employeeAsOfDate.Role,
+				StartTime:  availability.Schedule.StartTime,
+				EndTime:    availability.Schedule.EndTime,
+				FreeSlots:  availability.FreeSlots,
+			})
+			free = append(free, toTimeRanges(availability.FreeSlots)...)
+		}
+		sort.SliceStable(day.Shifts, func(i, j int) bool {
+			return day.Shifts[i].StartTime.Before(day.Shifts[j].StartTime)
+		})
+
+		// 2. Opening hours nobody is free during, counting overnight shifts of the previous day
+		if !date.Before(startDate) {
+			openingHours, err := repository.GetLocationOpeningHoursForDay(location.ID, int(date.Weekday()))
+			if err != nil {
+				utils.Error(fmt.Sprintf("Failed to get opening hours of location %s: %v", location.ID, err))
+				return nil, fmt.Errorf("internal server error")
+			}
+			openRanges := make([]model.TimeRange, 0, len(openingHours))
+			for _, openingHour := range openingHours {
+				openRange := timeOfDayRange(date, openingHour.StartTime, openingHour.EndTime)
+				openRanges = append(openRanges, openRange)
+				day.OpeningHours = append(day.OpeningHours, model.AvailabilitySchedule{StartTime: openRange.Start, EndTime: openRange.End})
+			}
+			if location.IsActive {
+				day.UncoveredSlots = toAvailabilitySlots(subtractTimeRanges(subtractTimeRanges(openRanges, previousFree), free))
+			}
+			coverage.Days = append(coverage.Days, day)
+		}
+		previousFree = free
+	}
+
+	return coverage, nil
+}
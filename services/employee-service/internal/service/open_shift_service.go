@@ -0,0 +1,267 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// OpenShiftClaimError represents an error when an employee cannot claim an open shift.
+type OpenShiftClaimError struct {
+	Reason string
+}
+
+func (e *OpenShiftClaimError) Error() string {
+	return "cannot claim open shift: " + e.Reason
+}
+
+// OpenShiftUnavailableError represents an error when an open shift or claim is no longer in a state that allows the action.
+type OpenShiftUnavailableError struct {
+	Reason string
+}
+
+func (e *OpenShiftUnavailableError) Error() string {
+	return e.Reason
+}
+
+// BuildOpenShiftModel creates an open shift model from validated inputs.
+// Times of day are combined with the date; shifts ending before they start cross midnight.
+func BuildOpenShiftModel(
+	shiftDate time.Time,
+	startTime time.Time,
+	endTime time.Time,
+	role string,
+	headcount int,
+	claimMode string,
+	notes string,
+	postedBy string,
+	locationID *uuid.UUID,
+) *model.OpenShift {
+	shift := timeOfDayRange(shiftDate, startTime, endTime)
+	return &model.OpenShift{
+		ShiftDate:     shiftDate,
+		StartDateTime: shift.Start,
+		EndDateTime:   shift.End,
+		Role:          role,
+		Headcount:     headcount,
+		ClaimMode:     claimMode,
+		Status:        model.OpenShiftStatusOpen,
+		Notes:         notes,
+		PostedBy:      postedBy,
+		LocationID:    locationID,
+	}
+}
+
+// CheckOpenShiftEligibility checks whether an employee can work an open shift.
+// It returns a reason when they cannot, or "" when they are eligible.
+func CheckOpenShiftEligibility(openShift model.OpenShift, employee model.Employee) (string, error) {
+	if !employee.IsActive {
+		return "employee is not active", nil
+	}
+	if !employee.IsEmployedOn(openShift.ShiftDate) {
+		return "employee leaves before the shift", nil
+	}
+	if openShift.Role != "" && employee.Role != openShift.Role {
+		return fmt.Sprintf("employee does not have the %s role", openShift.Role), nil
+	}
+	if _, err := CheckSchedulingCertifications(employee, openShift.ShiftDate); err != nil {
+		if certErr, ok := err.(*CertificationError); ok {
+			return certErr.Error(), nil
+		}
+		return "", err
+	}
+
+	reason, err := CheckEmployeeFreeForShift(employee.ID, openShift.ShiftDate, model.TimeRange{Start: openShift.StartDateTime, End: openShift.EndDateTime})
+	if err != nil || reason == "" {
+		return "", err
+	}
+	return "employee " + reason, nil
+}
+
+// GetEligibleEmployees returns the active employees who can still claim an open shift
+func GetEligibleEmployees(openShift model.OpenShift) ([]model.EligibleEmployee, error) {
+	employees, err := repository.GetActiveEmployees()
+	if err != nil {
+		utils.Error("Failed to get active employees: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	claimed := make(map[uuid.UUID]bool, len(openShift.Claims))
+	for _, claim := range openShift.Claims {
+		claimed[claim.EmployeeID] = true
+	}
+
+	eligible := []model.EligibleEmployee{}
+	for _, employee := range employees {
+		if claimed[employee.ID] {
+			continue
+		}
+		reason, err := CheckOpenShiftEligibility(openShift, employee)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			continue
+		}
+		eligible = append(eligible, model.EligibleEmployee{
+			EmployeeID: employee.ID,
+			FirstName:  employee.FirstName,
+			LastName:   employee.LastName,
+			Role:       employee.Role,
+		})
+	}
+
+	return eligible, nil
+}
+
+// ClaimOpenShift records an employee's claim on an open shift. With first-come claiming the claim is
+// approved straight away and the schedule is created; otherwise it waits for a manager.
+func ClaimOpenShift(openShift model.OpenShift, employeeID uuid.UUID) (*model.OpenShiftClaim, error) {
+	if openShift.Status != model.OpenShiftStatusOpen {
+		return nil, &OpenShiftUnavailableError{Reason: fmt.Sprintf("open shift is %s", openShift.Status)}
+	}
+
+	employee, err := repository.GetEmployeeByID(employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("employee not found")
+	}
+
+	exists, err := repository.CheckExistingOpenShiftClaim(openShift.ID, employeeID)
+	if err != nil {
+		utils.Error("Failed to check for existing open shift claims: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	if exists {
+		return nil, &OpenShiftClaimError{Reason: "employee already claimed this shift"}
+	}
+
+	reason, err := CheckOpenShiftEligibility(openShift, employee)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		return nil, &OpenShiftClaimError{Reason: reason}
+	}
+
+	claim := &model.OpenShiftClaim{
+		OpenShiftID: openShift.ID,
+		EmployeeID:  employeeID,
+		Status:      model.ClaimStatusPending,
+	}
+
+	if openShift.ClaimMode != model.ClaimModeFirstCome {
+		if err := repository.CreateOpenShiftClaim(claim); err != nil {
+			utils.Error("Failed to create open shift claim: " + err.Error())
+			return nil, fmt.Errorf("internal server error")
+		}
+		return claim, nil
+	}
+
+	now := time.Now()
+	claim.DecidedAt = &now
+	if err := fillOpenShiftClaim(openShift, claim, true); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// ApproveOpenShiftClaim approves a pending claim after re-checking eligibility and creates the schedule.
+func ApproveOpenShiftClaim(openShift model.OpenShift, claim model.OpenShiftClaim, decidedBy string) (*model.OpenShiftClaim, error) {
+	if claim.Status != model.ClaimStatusPending {
+		return nil, &OpenShiftUnavailableError{Reason: fmt.Sprintf("claim is %s", claim.Status)}
+	}
+
+	employee, err := repository.GetEmployeeByID(claim.EmployeeID)
+	if err != nil {
+		return nil, &OpenShiftClaimError{Reason: "employee no longer exists"}
+	}
+	reason, err := CheckOpenShiftEligibility(openShift, employee)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		return nil, &OpenShiftClaimError{Reason: reason}
+	}
+
+	now := time.Now()
+	claim.DecidedBy = decidedBy
+	claim.DecidedAt = &now
+	if err := fillOpenShiftClaim(openShift, &claim, false); err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+// RejectOpenShiftClaim rejects a pending claim.
+func RejectOpenShiftClaim(claim model.OpenShiftClaim, decidedBy string) (*model.OpenShiftClaim, error) {
+	if claim.Status != model.ClaimStatusPending {
+		return nil, &OpenShiftUnavailableError{Reason: fmt.Sprintf("claim is %s", claim.Status)}
+	}
+
+	now := time.Now()
+	claim.DecidedBy = decidedBy
+	claim.DecidedAt = &now
+	if err := repository.RejectOpenShiftClaim(&claim); err != nil {
+		if err == repository.ErrOpenShiftClaimDecided {
+			return nil, &OpenShiftUnavailableError{Reason: "claim was already decided"}
+		}
+		utils.Error("Failed to reject open shift claim: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	return &claim, nil
+}
+
+// CancelOpenShift cancels an open shift and removes the schedules created for it.
+func CancelOpenShift(openShift model.OpenShift, decidedBy string) (*model.OpenShift, error) {
+	if openShift.Status == model.OpenShiftStatusCancelled {
+		return nil, &OpenShiftUnavailableError{Reason: "open shift is already cancelled"}
+	}
+
+	if err := repository.CancelOpenShift(&openShift, decidedBy, time.Now()); err != nil {
+		if err == repository.ErrOpenShiftUnavailable {
+			return nil, &OpenShiftUnavailableError{Reason: "open shift is already cancelled"}
+		}
+		utils.Error("Failed to cancel open shift: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	return &openShift, nil
+}
+
+// fillOpenShiftClaim creates the one-time schedule for a claim and approves it
+func fillOpenShiftClaim(openShift model.OpenShift, claim *model.OpenShiftClaim, newClaim bool) error {
+	start := openShift.StartDateTime.UTC()
+	end := openShift.EndDateTime.UTC()
+	schedule := BuildScheduleModel(
+		claim.EmployeeID,
+		int(openShift.ShiftDate.Weekday()),
+		time.Date(0, 1, 1, start.Hour(), start.Minute(), start.Second(), 0, time.UTC),
+		time.Date(0, 1, 1, end.Hour(), end.Minute(), end.Second(), 0, time.UTC),
+		openShift.ShiftDate,
+		&openShift.ShiftDate,
+		fmt.Sprintf("Open shift %s", openShift.ID),
+		openShift.LocationID,
+	)
+	if err := CheckScheduleLocationConflict(schedule, nil); err != nil {
+		if _, ok := err.(*LocationConflictError); ok {
+			return &OpenShiftUnavailableError{Reason: "employee is already scheduled at another location during the shift"}
+		}
+		return err
+	}
+
+	if err := repository.FillOpenShiftClaim(claim, schedule, newClaim); err != nil {
+		if err == repository.ErrOpenShiftUnavailable {
+			return &OpenShiftUnavailableError{Reason: "open shift has no places left"}
+		}
+		if err == repository.ErrOpenShiftClaimDecided {
+			return &OpenShiftUnavailableError{Reason: "claim was already decided"}
+		}
+		utils.Error("Failed to fill open shift claim: " + err.Error())
+		return fmt.Errorf("internal server error")
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"net/http"
+	"os"
+	"strconv"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+	"github.com/salobook/services/employee-service/internal/storage"
+)
+
+// Photo processing limits
+const (
+	defaultPhotoMaxBytes = 5 << 20
+	photoMaxPixels       = 40_000_000 // Guards against decompression bombs
+	photoFullSize        = 1024
+	photoThumbnailSize   = 256
+	photoJPEGQuality     = 85
+)
+
+// acceptedPhotoTypes are the sniffed content types accepted for upload
+var acceptedPhotoTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// PhotoError represents an error when an uploaded file is not an acceptable photo.
+type PhotoError struct {
+	Reason string
+}
+
+func (e *PhotoError) Error() string {
+	return e.Reason
+}
+
+// PhotoMaxBytes returns the maximum upload size from PHOTO_MAX_BYTES (default 5 MiB)
+func PhotoMaxBytes() int {
+	if value := os.Getenv("PHOTO_MAX_BYTES"); value != "" {
+		maxBytes, err := strconv.Atoi(value)
+		if err == nil && maxBytes > 0 {
+			return maxBytes
+		}
+		utils.Warning(fmt.Sprintf("Invalid PHOTO_MAX_BYTES %q, using %d", value, defaultPhotoMaxBytes))
+	}
+	return defaultPhotoMaxBytes
+}
+
+// EmployeePhotoURL returns the URL the employee record points at for a managed photo.
+// The photo ID is part of the URL so clients refetch when the photo changes.
+// PHOTO_PUBLIC_BASE_URL prefixes the gateway path, e.g. https://api.example.com.
+func EmployeePhotoURL(photo model.EmployeePhoto) string {
+	return fmt.Sprintf("%s/api/employees/%s/photo?v=%s", os.Getenv("PHOTO_PUBLIC_BASE_URL"), photo.EmployeeID, photo.ID)
+}
+
+// UploadEmployeePhoto validates an uploaded image, re-encodes it without metadata in full and
+// thumbnail size, stores both blobs and points the employee at the new photo.
+// The previous photo's blobs are removed afterwards.
+func UploadEmployeePhoto(ctx context.Context, store storage.BlobStore, employee model.Employee, data []byte, uploadedBy string) (*model.EmployeePhoto, error) {
+	// Validate size, type and dimensions before decoding the whole image
+	if maxBytes := PhotoMaxBytes(); len(data) > maxBytes {
+		return nil, &PhotoError{Reason: fmt.Sprintf("photo must not be larger than %d bytes", maxBytes)}
+	}
+	if !acceptedPhotoTypes[http.DetectContentType(data)] {
+		return nil, &PhotoError{Reason: "unsupported image type, must be one of: jpeg, png, gif"}
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, &PhotoError{Reason: "photo could not be read"}
+	}
+	if config.Width*config.Height > photoMaxPixels {
+		return nil, &PhotoError{Reason: "photo dimensions are too large"}
+	}
+
+	// Re-encode in both sizes
+	img, err := decodeImage(data)
+	if err != nil {
+		return nil, &PhotoError{Reason: "photo could not be read"}
+	}
+	full := fitWithin(img, photoFullSize)
+	fullData, err := encodeJPEG(full, photoJPEGQuality)
+	if err != nil {
+		utils.Error("Failed to encode photo: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	thumbnailData, err := encodeJPEG(squareThumbnail(img, photoThumbnailSize), photoJPEGQuality)
+	if err != nil {
+		utils.Error("Failed to encode photo thumbnail: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	previous, err := repository.GetEmployeePhoto(employee.ID)
+	if err != nil {
+		utils.Error("Failed to get employee photo: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	// Store blobs first so the record never points at missing data
+	photoID := uuid.New()
+	prefix := fmt.Sprintf("employees/%s/photos/%s", employee.ID, photoID)
+	photo := &model.EmployeePhoto{
+		ID:            photoID,
+		EmployeeID:    employee.ID,
+		FullKey:       prefix + "/full.jpg",
+		ThumbnailKey:  prefix + "/thumb.jpg",
+		ContentType:   "image/jpeg",
+		Width:         full.Bounds().Dx(),
+		Height:        full.Bounds().Dy(),
+		OriginalBytes: len(data),
+		UploadedBy:    uploadedBy,
+	}
+	if err := store.Put(ctx, photo.FullKey, photo.ContentType, fullData); err != nil {
+		utils.Error("Failed to store photo: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	if err := store.Put(ctx, photo.ThumbnailKey, photo.ContentType, thumbnailData); err != nil {
+		utils.Error("Failed to store photo thumbnail: " + err.Error())
+		deletePhotoBlobs(ctx, store, *photo)
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	if err := repository.ReplaceEmployeePhoto(photo, EmployeePhotoURL(*photo)); err != nil {
+		utils.Error("Failed to save employee photo: " + err.Error())
+		deletePhotoBlobs(ctx, store, *photo)
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	if previous != nil {
+		deletePhotoBlobs(ctx, store, *previous)
+	}
+	return photo, nil
+}
+
+// DeleteEmployeePhoto removes the managed photo of an employee and clears their picture
+func DeleteEmployeePhoto(ctx context.Context, store storage.BlobStore, photo model.EmployeePhoto) error {
+	if err := repository.DeleteEmployeePhoto(photo.EmployeeID); err != nil {
+		utils.Error("Failed to delete employee photo: " + err.Error())
+		return fmt.Errorf("internal server error")
+	}
+	deletePhotoBlobs(ctx, store, photo)
+	return nil
+}
+
+// GetEmployeePhotoData returns the stored JPEG of a photo in the requested size
+func GetEmployeePhotoData(ctx context.Context, store storage.BlobStore, photo model.EmployeePhoto, size string) ([]byte, string, error) {
+	key := photo.FullKey
+	if size == model.PhotoSizeThumbnail {
+		key = photo.ThumbnailKey
+	}
+	data, contentType, err := store.Get(ctx, key)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			utils.Warning(fmt.Sprintf("Photo blob %s of employee %s is missing", key, photo.EmployeeID))
+		} else {
+			utils.Error("Failed to read photo: " + err.Error())
+		}
+		return nil, "", fmt.Errorf("internal server error")
+	}
+	if contentType == "" {
+		contentType = photo.ContentType
+	}
+	return data, contentType, nil
+}
+
+// deletePhotoBlobs removes the blobs of a photo; failures only leave orphaned blobs and are logged
+func deletePhotoBlobs(ctx context.Context, store storage.BlobStore, photo model.EmployeePhoto) {
+	for _, key := range []string{photo.FullKey, photo.ThumbnailKey} {
+		if err := store.Delete(ctx, key); err != nil {
+			utils.Warning(fmt.Sprintf("Failed to delete photo blob %s: %v", key, err))
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// DuplicateResourceError represents an error when a resource with the same name already exists.
+type DuplicateResourceError struct{}
+
+func (e *DuplicateResourceError) Error() string {
+	return "a resource with this name already exists"
+}
+
+// OverlappingResourceOpeningHourError represents an error when opening hours overlap with existing ones.
+type OverlappingResourceOpeningHourError struct{}
+
+func (e *OverlappingResourceOpeningHourError) Error() string {
+	return "these opening hours overlap with existing opening hours for the same resource on the same day"
+}
+
+// OverlappingResourceBlockError represents an error when a resource block, together with the
+// existing blocks it overlaps, would take more units than the resource has.
+type OverlappingResourceBlockError struct{}
+
+func (e *OverlappingResourceBlockError) Error() string {
+	return "this resource block overlaps with existing blocks that leave too few free units of the resource"
+}
+
+// ResourceBlockUnitsError represents an error when a block takes more units than the resource has.
+type ResourceBlockUnitsError struct {
+	Capacity int
+}
+
+func (e *ResourceBlockUnitsError) Error() string {
+	return fmt.Sprintf("units cannot exceed the capacity of the resource (%d)", e.Capacity)
+}
+
+// BuildResourceOpeningHourModel creates an opening hour model from validated inputs.
+func BuildResourceOpeningHourModel(
+	resourceID uuid.UUID,
+	dayOfWeek int,
+	startTime time.Time,
+	endTime time.Time,
+) *model.ResourceOpeningHour {
+	return &model.ResourceOpeningHour{
+		ResourceID: resourceID,
+		DayOfWeek:  dayOfWeek,
+		StartTime:  startTime,
+		EndTime:    endTime,
+	}
+}
+
+// BuildResourceBlockModel creates a resource block model from validated inputs.
+func BuildResourceBlockModel(
+	resourceID uuid.UUID,
+	startDateTime time.Time,
+	endDateTime time.Time,
+	units *int,
+	reason string,
+) *model.ResourceBlock {
+	return &model.ResourceBlock{
+		ResourceID:    resourceID,
+		StartDateTime: startDateTime,
+		EndDateTime:   endDateTime,
+		Units:         units,
+		Reason:        reason,
+	}
+}
+
+// CheckForDuplicateResource checks if another resource already uses the same name.
+func CheckForDuplicateResource(resource *model.Resource) error {
+	existing, err := repository.GetResourceByName(resource.Name)
+	if err == nil && existing.ID != resource.ID {
+		return &DuplicateResourceError{}
+	}
+	return nil
+}
+
+// CheckForOverlappingResourceOpeningHour is a service-level function to check for overlaps.
+func CheckForOverlappingResourceOpeningHour(openingHour *model.ResourceOpeningHour, excludeID *uuid.UUID) error {
+	hasOverlap, err := repository.CheckOverlappingResourceOpeningHour(
+		openingHour.ResourceID,
+		openingHour.DayOfWeek,
+		openingHour.StartTime,
+		openingHour.EndTime,
+		excludeID,
+	)
+	if err != nil {
+		utils.Error("Failed to check for overlapping resource opening hours: " + err.Error())
+		return err
+	}
+	if hasOverlap {
+		return &OverlappingResourceOpeningHourError{}
+	}
+	return nil
+}
+
+// CheckForOverlappingResourceBlock checks that a block fits into the capacity of its resource,
+// on its own and together with the existing blocks it overlaps.
+func CheckForOverlappingResourceBlock(block *model.ResourceBlock, excludeID *uuid.UUID) error {
+	resource, err := repository.GetResourceByID(block.ResourceID)
+	if err != nil {
+		utils.Error("Failed to get resource for block: " + err.Error())
+		return err
+	}
+	if block.Units != nil && *block.Units > resource.Capacity {
+		return &ResourceBlockUnitsError{Capacity: resource.Capacity}
+	}
+
+	overlapping, err := repository.GetOverlappingResourceBlocks(
+		block.ResourceID,
+		block.StartDateTime,
+		block.EndDateTime,
+		excludeID,
+	)
+	if err != nil {
+		utils.Error("Failed to check for overlapping resource blocks: " + err.Error())
+		return err
+	}
+
+	blocked := toBlockedUnits(append(overlapping, *block), resource.Capacity)
+	blockRange := model.TimeRange{Start: block.StartDateTime.UTC(), End: block.EndDateTime.UTC()}
+	for _, slot := range capacitySlots([]model.TimeRange{blockRange}, blocked, resource.Capacity) {
+		if slot.Available < 0 {
+			return &OverlappingResourceBlockError{}
+		}
+	}
+	return nil
+}
+
+// ResourceService handles all business logic related to resource availability
+type ResourceService struct{}
+
+// NewResourceService creates a new instance of ResourceService
+func NewResourceService() *ResourceService {
+	return &ResourceService{}
+}
+
+// GetResourceAvailability calculates the availability of a resource on a specific date.
+// Inactive resources are reported as closed.
+func (s *ResourceService) GetResourceAvailability(resourceID uuid.UUID, date time.Time) (*model.ResourceAvailabilityResponse, error) {
+	// Step 1: Validate resource exists
+	resource, err := repository.GetResourceByID(resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("resource not found")
+	}
+
+	response := &model.ResourceAvailabilityResponse{
+		Date:          date,
+		ResourceID:    resourceID,
+		Capacity:      resource.Capacity,
+		OpeningHours:  []model.AvailabilitySchedule{},
+		Blocks:        []model.AvailabilityBlock{},
+		FreeSlots:     []model.AvailabilitySlot{},
+		CapacitySlots: []model.ResourceCapacitySlot{},
+	}
+	if !resource.IsActive {
+		return response, nil
+	}
+
+	// Step 2: Find opening hours for the day of week
+	openingHours, err := repository.GetResourceOpeningHoursForDay(resourceID, int(date.Weekday()))
+	if err != nil {
+		utils.Error(fmt.Sprintf("Failed to get opening hours for resource %s on date %s: %v", resourceID, date.Format("2006-01-02"), err))
+		return nil, fmt.Errorf("internal server error")
+	}
+	if len(openingHours) == 0 {
+		return response, nil
+	}
+
+	// Step 3: Turn the opening hours into ranges, those crossing midnight end on the next day
+	openRanges := make([]model.TimeRange, 0, len(openingHours))
+	span := model.TimeRange{}
+	for i, openingHour := range openingHours {
+		openRange := timeOfDayRange(date, openingHour.StartTime, openingHour.EndTime)
+		openRanges = append(openRanges, openRange)
+		response.OpeningHours = append(response.OpeningHours, model.AvailabilitySchedule{
+			StartTime: openRange.Start,
+			EndTime:   openRange.End,
+		})
+		if i == 0 || openRange.Start.Before(span.Start) {
+			span.Start = openRange.Start
+		}
+		if i == 0 || openRange.End.After(span.End) {
+			span.End = openRange.End
+		}
+	}
+
+	// Step 4: Get resource blocks that overlap with the opening hours and count the units they take
+	blocks, err := repository.GetOverlappingResourceBlocks(resourceID, span.Start, span.End, nil)
+	if err != nil {
+		utils.Error(fmt.Sprintf("Failed to get blocks for resource %s on date %s: %v", resourceID, date.Format("2006-01-02"), err))
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	blocked := toBlockedUnits(blocks, resource.Capacity)
+	for i, block := range blocks {
+		for _, openRange := range openRanges {
+			intersection := openRange.GetIntersection(blocked[i].Range)
+			if intersection == nil || !intersection.IsValid() {
+				continue
+			}
+			response.Blocks = append(response.Blocks, model.AvailabilityBlock{
+				StartTime: intersection.Start,
+				EndTime:   intersection.End,
+				Reason:    block.Reason,
+				Units:     blocked[i].Units,
+			})
+		}
+	}
+
+	// Step 5: Split the open time by free units, it is free while at least one unit is
+	for _, slot := range capacitySlots(openRanges, blocked, resource.Capacity) {
+		if slot.Available < 0 {
+			slot.Available = 0
+		}
+		response.CapacitySlots = append(response.CapacitySlots, slot)
+	}
+	response.FreeSlots = freeCapacitySlots(response.CapacitySlots)
+
+	return response, nil
+}
+
+// blockedUnits is a resource block reduced to its range and the number of units it takes
+type blockedUnits struct {
+	Range model.TimeRange
+	Units int
+}
+
+// toBlockedUnits converts resource blocks, treating blocks without units as taking the whole resource
+func toBlockedUnits(blocks []model.ResourceBlock, capacity int) []blockedUnits {
+	blocked := make([]blockedUnits, len(blocks))
+	for i, block := range blocks {
+		units := capacity
+		if block.Units != nil {
+			units = *block.Units
+		}
+		blocked[i] = blockedUnits{
+			Range: model.TimeRange{Start: block.StartDateTime.UTC(), End: block.EndDateTime.UTC()},
+			Units: units,
+		}
+	}
+	return blocked
+}
+
+// capacitySlots splits open ranges at every block boundary and computes the free units of each piece,
+// joining neighbouring pieces with the same number of free units. Overbooked pieces have negative units.
+func capacitySlots(open []model.TimeRange, blocked []blockedUnits, capacity int) []model.ResourceCapacitySlot {
+	slots := make([]model.ResourceCapacitySlot, 0)
+	for _, openRange := range open {
+		// 1. Boundaries of the pieces: the open range and every block edge inside it
+		boundaries := []time.Time{openRange.Start, openRange.End}
+		for _, block := range blocked {
+			for _, edge := range []time.Time{block.Range.Start, block.Range.End} {
+				if edge.After(openRange.Start) && edge.Before(openRange.End) {
+					boundaries = append(boundaries, edge)
+				}
+			}
+		}
+		sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+
+		// 2. Units taken in each piece
+		for i := 0; i+1 < len(boundaries); i++ {
+			piece := model.TimeRange{Start: boundaries[i], End: boundaries[i+1]}
+			if !piece.IsValid() {
+				continue
+			}
+			available := capacity
+			for _, block := range blocked {
+				if block.Range.HasOverlap(piece) {
+					available -= block.Units
+				}
+			}
+
+			if last := len(slots) - 1; last >= 0 && slots[last].EndTime.Equal(piece.Start) && slots[last].Available == available {
+				slots[last].EndTime = piece.End
+				continue
+			}
+			slots = append(slots, model.ResourceCapacitySlot{StartTime: piece.Start, EndTime: piece.End, Available: available})
+		}
+	}
+	return slots
+}
+
+// freeCapacitySlots joins the capacity slots with at least one free unit into availability slots
+func freeCapacitySlots(slots []model.ResourceCapacitySlot) []model.AvailabilitySlot {
+	free := make([]model.AvailabilitySlot, 0)
+	for _, slot := range slots {
+		if slot.Available <= 0 {
+			continue
+		}
+		if last := len(free) - 1; last >= 0 && free[last].EndTime.Equal(slot.StartTime) {
+			free[last].EndTime = slot.EndTime
+			continue
+		}
+		free = append(free, model.AvailabilitySlot{StartTime: slot.StartTime, EndTime: slot.EndTime})
+	}
+	return free
+}
+
+// timeOfDayRange combines a date with time-of-day values into a full UTC range,
+// rolling the end over to the next day for ranges that cross midnight
+func timeOfDayRange(date time.Time, startTime time.Time, endTime time.Time) model.TimeRange {
+	start := time.Date(
+		date.Year(), date.Month(), date.Day(),
+		startTime.Hour(), startTime.Minute(), startTime.Second(),
+		0, time.UTC,
+	)
+	end := time.Date(
+		date.Year(), date.Month(), date.Day(),
+		endTime.Hour(), endTime.Minute(), endTime.Second(),
+		0, time.UTC,
+	)
+	if endTime.Before(startTime) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return model.TimeRange{Start: start, End: end}
+}
+
+// subtractTimeRanges removes every range in remove from every range in base
+func subtractTimeRanges(base []model.TimeRange, remove []model.TimeRange) []model.TimeRange {
+	result := base
+	for _, conflict := range remove {
+		var next []model.TimeRange
+		for _, available := range result {
+			if !available.HasOverlap(conflict) {
+				next = append(next, available)
+				continue
+			}
+			if available.Start.Before(conflict.Start) {
+				next = append(next, model.TimeRange{Start: available.Start, End: conflict.Start})
+			}
+			if available.End.After(conflict.End) {
+				next = append(next, model.TimeRange{Start: conflict.End, End: available.End})
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+// intersectTimeRanges returns the ranges covered by both a and b
+func intersectTimeRanges(a []model.TimeRange, b []model.TimeRange) []model.TimeRange {
+	result := make([]model.TimeRange, 0)
+	for _, left := range a {
+		for _, right := range b {
+			if intersection := left.GetIntersection(right); intersection != nil && intersection.IsValid() {
+				result = append(result, *intersection)
+			}
+		}
+	}
+	return result
+}
+
+// toAvailabilitySlots converts time ranges into availability slots
+func toAvailabilitySlots(ranges []model.TimeRange) []model.AvailabilitySlot {
+	slots := make([]model.AvailabilitySlot, 0, len(ranges))
+	for _, timeRange := range ranges {
+		if timeRange.IsValid() {
+			slots = append(slots, model.AvailabilitySlot{StartTime: timeRange.Start, EndTime: timeRange.End})
+		}
+	}
+	return slots
+}
+
+// toTimeRanges converts availability slots into time ranges
+func toTimeRanges(slots []model.AvailabilitySlot) []model.TimeRange {
+	ranges := make([]model.TimeRange, 0, len(slots))
+	for _, slot := range slots {
+		ranges = append(ranges, model.TimeRange{Start: slot.StartTime, End: slot.EndTime})
+	}
+	return ranges
+}
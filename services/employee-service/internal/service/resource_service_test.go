@@ -0,0 +1,148 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// at returns a time on a fixed test date
+func at(hour, minute int) time.Time {
+	return time.Date(2025, time.March, 10, hour, minute, 0, 0, time.UTC)
+}
+
+func span(startHour, startMinute, endHour, endMinute int) model.TimeRange {
+	return model.TimeRange{Start: at(startHour, startMinute), End: at(endHour, endMinute)}
+}
+
+func equalRanges(a, b []model.TimeRange) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Start.Equal(b[i].Start) || !a[i].End.Equal(b[i].End) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSubtractTimeRanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		base   []model.TimeRange
+		remove []model.TimeRange
+		want   []model.TimeRange
+	}{
+		{"nothing removed", []model.TimeRange{span(9, 0, 17, 0)}, nil, []model.TimeRange{span(9, 0, 17, 0)}},
+		{"disjoint", []model.TimeRange{span(9, 0, 12, 0)}, []model.TimeRange{span(13, 0, 14, 0)}, []model.TimeRange{span(9, 0, 12, 0)}},
+		{"touching", []model.TimeRange{span(9, 0, 12, 0)}, []model.TimeRange{span(12, 0, 13, 0)}, []model.TimeRange{span(9, 0, 12, 0)}},
+		{"middle", []model.TimeRange{span(9, 0, 17, 0)}, []model.TimeRange{span(12, 0, 13, 0)}, []model.TimeRange{span(9, 0, 12, 0), span(13, 0, 17, 0)}},
+		{"start", []model.TimeRange{span(9, 0, 17, 0)}, []model.TimeRange{span(8, 0, 10, 0)}, []model.TimeRange{span(10, 0, 17, 0)}},
+		{"end", []model.TimeRange{span(9, 0, 17, 0)}, []model.TimeRange{span(16, 0, 18, 0)}, []model.TimeRange{span(9, 0, 16, 0)}},
+		{"everything", []model.TimeRange{span(9, 0, 17, 0)}, []model.TimeRange{span(8, 0, 18, 0)}, nil},
+		{"several", []model.TimeRange{span(9, 0, 12, 0), span(13, 0, 17, 0)}, []model.TimeRange{span(10, 0, 11, 0), span(11, 30, 14, 0)},
+			[]model.TimeRange{span(9, 0, 10, 0), span(11, 0, 11, 30), span(14, 0, 17, 0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subtractTimeRanges(tt.base, tt.remove); !equalRanges(got, tt.want) {
+				t.Errorf("subtractTimeRanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapacitySlots(t *testing.T) {
+	open := []model.TimeRange{span(9, 0, 17, 0)}
+	tests := []struct {
+		name     string
+		capacity int
+		blocked  []blockedUnits
+		want     []model.ResourceCapacitySlot
+	}{
+		{
+			name:     "no blocks",
+			capacity: 3,
+			want:     []model.ResourceCapacitySlot{{StartTime: at(9, 0), EndTime: at(17, 0), Available: 3}},
+		},
+		{
+			name:     "one unit blocked",
+			capacity: 3,
+			blocked:  []blockedUnits{{Range: span(10, 0, 12, 0), Units: 1}},
+			want: []model.ResourceCapacitySlot{
+				{StartTime: at(9, 0), EndTime: at(10, 0), Available: 3},
+				{StartTime: at(10, 0), EndTime: at(12, 0), Available: 2},
+				{StartTime: at(12, 0), EndTime: at(17, 0), Available: 3},
+			},
+		},
+		{
+			name:     "overlapping blocks add up",
+			capacity: 3,
+			blocked:  []blockedUnits{{Range: span(10, 0, 12, 0), Units: 1}, {Range: span(11, 0, 13, 0), Units: 2}},
+			want: []model.ResourceCapacitySlot{
+				{StartTime: at(9, 0), EndTime: at(10, 0), Available: 3},
+				{StartTime: at(10, 0), EndTime: at(11, 0), Available: 2},
+				{StartTime: at(11, 0), EndTime: at(12, 0), Available: 0},
+				{StartTime: at(12, 0), EndTime: at(13, 0), Available: 1},
+				{StartTime: at(13, 0), EndTime: at(17, 0), Available: 3},
+			},
+		},
+		{
+			name:     "overbooked",
+			capacity: 1,
+			blocked:  []blockedUnits{{Range: span(9, 0, 10, 0), Units: 1}, {Range: span(9, 30, 10, 0), Units: 1}},
+			want: []model.ResourceCapacitySlot{
+				{StartTime: at(9, 0), EndTime: at(9, 30), Available: 0},
+				{StartTime: at(9, 30), EndTime: at(10, 0), Available: -1},
+				{StartTime: at(10, 0), EndTime: at(17, 0), Available: 1},
+			},
+		},
+		{
+			name:     "block outside opening hours",
+			capacity: 2,
+			blocked:  []blockedUnits{{Range: span(18, 0, 19, 0), Units: 2}},
+			want:     []model.ResourceCapacitySlot{{StartTime: at(9, 0), EndTime: at(17, 0), Available: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := capacitySlots(open, tt.blocked, tt.capacity)
+			if len(got) != len(tt.want) {
+				t.Fatalf("capacitySlots() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].StartTime.Equal(tt.want[i].StartTime) || !got[i].EndTime.Equal(tt.want[i].EndTime) || got[i].Available != tt.want[i].Available {
+					t.Errorf("slot %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFreeCapacitySlots(t *testing.T) {
+	slots := []model.ResourceCapacitySlot{
+		{StartTime: at(9, 0), EndTime: at(10, 0), Available: 2},
+		{StartTime: at(10, 0), EndTime: at(11, 0), Available: 1},
+		{StartTime: at(11, 0), EndTime: at(12, 0), Available: 0},
+		{StartTime: at(12, 0), EndTime: at(17, 0), Available: 2},
+	}
+	want := []model.TimeRange{span(9, 0, 11, 0), span(12, 0, 17, 0)}
+
+	if got := toTimeRanges(freeCapacitySlots(slots)); !equalRanges(got, want) {
+		t.Errorf("freeCapacitySlots() = %v, want %v", got, want)
+	}
+}
+
+func TestTimeOfDayRangeCrossesMidnight(t *testing.T) {
+	date := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	got := timeOfDayRange(date, time.Date(0, 1, 1, 22, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 2, 0, 0, 0, time.UTC))
+
+	wantEnd := time.Date(2025, time.March, 11, 2, 0, 0, 0, time.UTC)
+	if !got.End.Equal(wantEnd) {
+		t.Errorf("end = %v, want %v", got.End, wantEnd)
+	}
+}
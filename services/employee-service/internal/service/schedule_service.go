@@ -26,6 +26,7 @@ func BuildScheduleModel(
 	validFrom time.Time, 
 	validUntil *time.Time, 
 	notes string,
+	locationID *uuid.UUID,
 ) *model.Schedule {
 	return &model.Schedule{
 		EmployeeID:  employeeID,
@@ -35,6 +36,7 @@ func BuildScheduleModel(
 		ValidFrom:   validFrom,
 		ValidUntil:  validUntil,
 		Notes:       notes,
+		LocationID:  locationID,
 	}
 }
 
@@ -45,6 +47,7 @@ func CheckForDuplicateSchedule(schedule *model.Schedule, excludeID *uuid.UUID) e
 		schedule.DayOfWeek,
 		schedule.ValidFrom,
 		schedule.ValidUntil,
+		schedule.LocationID,
 		excludeID,
 	)
 	if err != nil {
@@ -61,7 +64,7 @@ func CheckForDuplicateSchedule(schedule *model.Schedule, excludeID *uuid.UUID) e
 type DuplicateScheduleError struct{}
 
 func (e *DuplicateScheduleError) Error() string {
-	return "a duplicate schedule already exists for this employee with the same day and date range"
+	return "a duplicate schedule already exists for this employee with the same day, date range and location"
 }
 
 // DetermineRecurrenceType determines if a schedule is recurring based on validFrom and validUntil
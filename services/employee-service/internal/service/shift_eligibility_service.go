@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// CheckEmployeeFreeForShift checks whether an employee can be given an extra shift on a date.
+// It returns a human readable reason when they cannot, or "" when they are free.
+// A schedule can only describe one shift per day, so an employee already scheduled that day
+// cannot take another; a shift from the previous day crossing midnight must not overlap either.
+func CheckEmployeeFreeForShift(employeeID uuid.UUID, shiftDate time.Time, shift model.TimeRange) (string, error) {
+	for _, date := range []time.Time{shiftDate.AddDate(0, 0, -1), shiftDate} {
+		schedule, err := repository.GetEmployeeScheduleForDate(employeeID, date)
+		if err != nil {
+			utils.Error(fmt.Sprintf("Failed to get schedule for employee %s: %v", employeeID, err))
+			return "", fmt.Errorf("internal server error")
+		}
+		if schedule == nil {
+			continue
+		}
+		if date.Equal(shiftDate) {
+			return "is already scheduled on this date", nil
+		}
+		if timeOfDayRange(date, schedule.StartTime, schedule.EndTime).HasOverlap(shift) {
+			return "has an overnight shift overlapping this shift", nil
+		}
+	}
+
+	blocks, err := repository.GetOverlappingOnetimeBlocks(employeeID, shift.Start, shift.End, nil)
+	if err != nil {
+		utils.Error("Failed to get overlapping one-time blocks: " + err.Error())
+		return "", fmt.Errorf("internal server error")
+	}
+	if len(blocks) > 0 {
+		return "is blocked during the shift", nil
+	}
+
+	leave, err := repository.GetOverlappingLeaveRequests(employeeID, shift.Start, shift.End, nil)
+	if err != nil {
+		utils.Error("Failed to get overlapping leave requests: " + err.Error())
+		return "", fmt.Errorf("internal server error")
+	}
+	if len(leave) > 0 {
+		return "has leave requested during the shift", nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// ShiftSwapStatusError represents an error when a shift swap is not in a status that allows the action.
+type ShiftSwapStatusError struct {
+	Status string
+	Action string
+}
+
+func (e *ShiftSwapStatusError) Error() string {
+	return fmt.Sprintf("cannot %s a shift swap that is %s", e.Action, e.Status)
+}
+
+// ShiftSwapCheckError represents an error when a shift swap fails an overlap or qualification check.
+type ShiftSwapCheckError struct {
+	Reason string
+}
+
+func (e *ShiftSwapCheckError) Error() string {
+	return "shift swap not allowed: " + e.Reason
+}
+
+// BuildShiftSwapOffer creates a shift swap offer for the shift the employee is scheduled for on the date.
+// The shift is resolved from the employee's schedules the same way availability is.
+func BuildShiftSwapOffer(employeeID uuid.UUID, shiftDate time.Time, note string, offeredBy string) (*model.ShiftSwap, error) {
+	employee, err := repository.GetEmployeeByID(employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("employee not found")
+	}
+	if !employee.IsActive {
+		return nil, &ShiftSwapCheckError{Reason: "offering employee is not active"}
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if shiftDate.Before(today) {
+		return nil, &ShiftSwapCheckError{Reason: "shifts in the past cannot be swapped"}
+	}
+
+	schedule, err := repository.GetEmployeeScheduleForDate(employeeID, shiftDate)
+	if err != nil {
+		utils.Error(fmt.Sprintf("Failed to get schedule for employee %s: %v", employeeID, err))
+		return nil, fmt.Errorf("internal server error")
+	}
+	if schedule == nil {
+		return nil, &ShiftSwapCheckError{Reason: "employee has no shift on this date"}
+	}
+
+	open, err := repository.CheckOpenShiftSwap(employeeID, shiftDate)
+	if err != nil {
+		utils.Error("Failed to check for open shift swaps: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	if open {
+		return nil, &ShiftSwapCheckError{Reason: "this shift is already offered"}
+	}
+
+	shift := timeOfDayRange(shiftDate, schedule.StartTime, schedule.EndTime)
+	return &model.ShiftSwap{
+		OfferingEmployeeID: employeeID,
+		ShiftDate:          shiftDate,
+		SourceScheduleID:   schedule.ID,
+		StartDateTime:      shift.Start,
+		EndDateTime:        shift.End,
+		Status:             model.SwapStatusOffered,
+		Note:               note,
+		OfferedBy:          offeredBy,
+	}, nil
+}
+
+// AcceptShiftSwap records the employee taking over an offered shift after checking they can work it.
+func AcceptShiftSwap(swap model.ShiftSwap, acceptingEmployeeID uuid.UUID) (*model.ShiftSwap, error) {
+	if swap.Status != model.SwapStatusOffered {
+		return nil, &ShiftSwapStatusError{Status: swap.Status, Action: "accept"}
+	}
+	if err := CheckShiftSwapEligibility(swap, acceptingEmployeeID); err != nil {
+		return nil, err
+	}
+
+	swap.AcceptingEmployeeID = &acceptingEmployeeID
+	swap.Status = model.SwapStatusAccepted
+	if err := repository.UpdateShiftSwapStatus(&swap, []string{model.SwapStatusOffered}); err != nil {
+		if err == repository.ErrShiftSwapStatusChanged {
+			return nil, &ShiftSwapStatusError{Status: "no longer offered", Action: "accept"}
+		}
+		utils.Error("Failed to accept shift swap: " + err.Error())
+		return nil, err
+	}
+
+	return &swap, nil
+}
+
+// CheckShiftSwapEligibility verifies that the accepting employee can take over the shift:
+// they must be active, hold the same role as the offering employee, and be free for the whole shift.
+func CheckShiftSwapEligibility(swap model.ShiftSwap, acceptingEmployeeID uuid.UUID) error {
+	if acceptingEmployeeID == swap.OfferingEmployeeID {
+		return &ShiftSwapCheckError{Reason: "an employee cannot take over their own shift"}
+	}
+
+	offering, err := repository.GetEmployeeByID(swap.OfferingEmployeeID)
+	if err != nil {
+		return &ShiftSwapCheckError{Reason: "offering employee no longer exists"}
+	}
+	accepting, err := repository.GetEmployeeByID(acceptingEmployeeID)
+	if err != nil {
+		return fmt.Errorf("employee not found")
+	}
+
+	// Qualification checks
+	if !accepting.IsActive {
+		return &ShiftSwapCheckError{Reason: "accepting employee is not active"}
+	}
+	if !accepting.IsEmployedOn(swap.ShiftDate) {
+		return &ShiftSwapCheckError{Reason: "accepting employee leaves before the shift"}
+	}
+	if offering.Role != "" && accepting.Role != offering.Role {
+		return &ShiftSwapCheckError{Reason: fmt.Sprintf("accepting employee does not have the %s role", offering.Role)}
+	}
+	if _, err := CheckSchedulingCertifications(accepting, swap.ShiftDate); err != nil {
+		if certErr, ok := err.(*CertificationError); ok {
+			return &ShiftSwapCheckError{Reason: "accepting " + certErr.Error()}
+		}
+		return err
+	}
+
+	// Overlap checks
+	reason, err := CheckEmployeeFreeForShift(acceptingEmployeeID, swap.ShiftDate, model.TimeRange{Start: swap.StartDateTime, End: swap.EndDateTime})
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		return &ShiftSwapCheckError{Reason: "accepting employee " + reason}
+	}
+
+	return nil
+}
+
+// ApproveShiftSwap approves an accepted swap. All checks are repeated because schedules may have
+// changed since the offer was accepted, then the accepting employee gets a one-time schedule
+// (ValidFrom == ValidUntil) for the shift and the offering employee gets a matching block.
+func ApproveShiftSwap(swap model.ShiftSwap, decidedBy string, note string) (*model.ShiftSwap, error) {
+	if swap.Status != model.SwapStatusAccepted || swap.AcceptingEmployeeID == nil {
+		return nil, &ShiftSwapStatusError{Status: swap.Status, Action: "approve"}
+	}
+
+	// 1. The offering employee must still have the same shift
+	source, err := repository.GetEmployeeScheduleForDate(swap.OfferingEmployeeID, swap.ShiftDate)
+	if err != nil {
+		utils.Error(fmt.Sprintf("Failed to get schedule for employee %s: %v", swap.OfferingEmployeeID, err))
+		return nil, fmt.Errorf("internal server error")
+	}
+	if source == nil || source.ID != swap.SourceScheduleID {
+		return nil, &ShiftSwapCheckError{Reason: "the offered shift has changed since it was offered"}
+	}
+
+	// 2. The offering employee's block must not overlap existing blocks
+	hasOverlap, err := repository.CheckOverlappingOnetimeBlock(swap.OfferingEmployeeID, swap.StartDateTime, swap.EndDateTime, nil)
+	if err != nil {
+		utils.Error("Failed to check for overlapping one-time blocks: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	if hasOverlap {
+		return nil, &ShiftSwapCheckError{Reason: "offering employee already has a one-time block during the shift"}
+	}
+
+	// 3. The accepting employee must still be able to work the shift
+	if err := CheckShiftSwapEligibility(swap, *swap.AcceptingEmployeeID); err != nil {
+		return nil, err
+	}
+
+	// 4. Build the schedule and block
+	schedule := BuildScheduleModel(
+		*swap.AcceptingEmployeeID,
+		int(swap.ShiftDate.Weekday()),
+		source.StartTime,
+		source.EndTime,
+		swap.ShiftDate,
+		&swap.ShiftDate,
+		fmt.Sprintf("Shift swap %s", swap.ID),
+		source.LocationID,
+	)
+	if err := CheckScheduleLocationConflict(schedule, nil); err != nil {
+		if _, ok := err.(*LocationConflictError); ok {
+			return nil, &ShiftSwapCheckError{Reason: "accepting employee is already scheduled at another location during the shift"}
+		}
+		return nil, err
+	}
+	block := BuildOnetimeBlockModel(
+		swap.OfferingEmployeeID,
+		swap.StartDateTime,
+		swap.EndDateTime,
+		fmt.Sprintf("Shift handed over in swap %s", swap.ID),
+	)
+
+	now := time.Now()
+	swap.DecidedBy = decidedBy
+	swap.DecidedAt = &now
+	swap.DecisionNote = note
+
+	if err := repository.ApproveShiftSwap(&swap, schedule, block); err != nil {
+		if err == repository.ErrShiftSwapStatusChanged {
+			return nil, &ShiftSwapStatusError{Status: "no longer accepted", Action: "approve"}
+		}
+		utils.Error("Failed to approve shift swap: " + err.Error())
+		return nil, err
+	}
+
+	return &swap, nil
+}
+
+// RejectShiftSwap rejects an offered or accepted swap.
+func RejectShiftSwap(swap model.ShiftSwap, decidedBy string, note string) (*model.ShiftSwap, error) {
+	return closeShiftSwap(swap, model.SwapStatusRejected, "reject", decidedBy, note)
+}
+
+// CancelShiftSwap withdraws an offered or accepted swap.
+func CancelShiftSwap(swap model.ShiftSwap, decidedBy string, note string) (*model.ShiftSwap, error) {
+	return closeShiftSwap(swap, model.SwapStatusCancelled, "cancel", decidedBy, note)
+}
+
+// closeShiftSwap moves an open swap to a final status without touching schedules
+func closeShiftSwap(swap model.ShiftSwap, status string, action string, decidedBy string, note string) (*model.ShiftSwap, error) {
+	open := []string{model.SwapStatusOffered, model.SwapStatusAccepted}
+	if swap.Status != model.SwapStatusOffered && swap.Status != model.SwapStatusAccepted {
+		return nil, &ShiftSwapStatusError{Status: swap.Status, Action: action}
+	}
+
+	now := time.Now()
+	swap.Status = status
+	swap.DecidedBy = decidedBy
+	swap.DecidedAt = &now
+	swap.DecisionNote = note
+
+	if err := repository.UpdateShiftSwapStatus(&swap, open); err != nil {
+		if err == repository.ErrShiftSwapStatusChanged {
+			return nil, &ShiftSwapStatusError{Status: "already decided", Action: action}
+		}
+		utils.Error("Failed to update shift swap: " + err.Error())
+		return nil, err
+	}
+
+	return &swap, nil
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// TimeClockError represents an error when a punch does not fit the employee's current clock state.
+type TimeClockError struct {
+	Reason string
+}
+
+func (e *TimeClockError) Error() string {
+	return e.Reason
+}
+
+// ClockIn opens a new time entry for an employee. An employee who is already clocked in
+// cannot clock in again; a partial unique index guards against concurrent clock-ins as well.
+func ClockIn(employeeID uuid.UUID, at time.Time, notes string, recordedBy string) (*model.TimeEntry, error) {
+	employee, err := repository.GetEmployeeByID(employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("employee not found")
+	}
+	if !employee.IsActive {
+		return nil, &TimeClockError{Reason: "employee is not active"}
+	}
+	if !employee.IsEmployedOn(at.UTC().Truncate(24 * time.Hour)) {
+		return nil, &TimeClockError{Reason: "employee has left"}
+	}
+
+	open, err := repository.GetOpenTimeEntry(employeeID)
+	if err != nil {
+		utils.Error("Failed to get open time entry: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	if open != nil {
+		return nil, &TimeClockError{Reason: "employee is already clocked in"}
+	}
+	if err := checkTimesheetLocked(employeeID, at); err != nil {
+		return nil, err
+	}
+
+	overlaps, err := repository.CheckOverlappingTimeEntry(employeeID, at)
+	if err != nil {
+		utils.Error("Failed to check for overlapping time entries: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	if overlaps {
+		return nil, &TimeClockError{Reason: "clock-in time falls inside an existing time entry"}
+	}
+
+	entry := &model.TimeEntry{
+		EmployeeID: employeeID,
+		ClockIn:    at,
+		Notes:      notes,
+		RecordedBy: recordedBy,
+		Breaks:     []model.TimeEntryBreak{},
+	}
+	if err := repository.CreateTimeEntry(entry); err != nil {
+		if strings.Contains(err.Error(), "idx_open_time_entry") {
+			return nil, &TimeClockError{Reason: "employee is already clocked in"}
+		}
+		utils.Error("Failed to create time entry: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	return entry, nil
+}
+
+// ClockOut closes the open time entry of an employee, ending an open break at the same time.
+func ClockOut(employeeID uuid.UUID, at time.Time) (*model.TimeEntry, error) {
+	entry, err := getOpenTimeEntry(employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if !at.After(entry.ClockIn) {
+		return nil, &TimeClockError{Reason: "clock-out time must be after clock-in time"}
+	}
+	if openBreak := findOpenBreak(entry); openBreak != nil && !at.After(openBreak.StartTime) {
+		return nil, &TimeClockError{Reason: "clock-out time must be after the start of the current break"}
+	}
+
+	if err := repository.CloseTimeEntry(entry, at); err != nil {
+		if err == repository.ErrTimeEntryChanged {
+			return nil, &TimeClockError{Reason: "employee is not clocked in"}
+		}
+		utils.Error("Failed to close time entry: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	return entry, nil
+}
+
+// StartBreak starts a break on the open time entry of an employee.
+func StartBreak(employeeID uuid.UUID, at time.Time) (*model.TimeEntry, error) {
+	entry, err := getOpenTimeEntry(employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if findOpenBreak(entry) != nil {
+		return nil, &TimeClockError{Reason: "employee is already on a break"}
+	}
+	if at.Before(entry.ClockIn) {
+		return nil, &TimeClockError{Reason: "break cannot start before clock-in"}
+	}
+	if len(entry.Breaks) > 0 {
+		last := entry.Breaks[len(entry.Breaks)-1]
+		if last.EndTime != nil && at.Before(*last.EndTime) {
+			return nil, &TimeClockError{Reason: "break cannot start before the previous break ended"}
+		}
+	}
+
+	entryBreak := model.TimeEntryBreak{TimeEntryID: entry.ID, StartTime: at}
+	if err := repository.CreateTimeEntryBreak(&entryBreak); err != nil {
+		utils.Error("Failed to create time entry break: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	entry.Breaks = append(entry.Breaks, entryBreak)
+	return entry, nil
+}
+
+// EndBreak ends the current break of an employee.
+func EndBreak(employeeID uuid.UUID, at time.Time) (*model.TimeEntry, error) {
+	entry, err := getOpenTimeEntry(employeeID)
+	if err != nil {
+		return nil, err
+	}
+	openBreak := findOpenBreak(entry)
+	if openBreak == nil {
+		return nil, &TimeClockError{Reason: "employee is not on a break"}
+	}
+	if !at.After(openBreak.StartTime) {
+		return nil, &TimeClockError{Reason: "break end must be after break start"}
+	}
+
+	if err := repository.CloseTimeEntryBreak(openBreak, at); err != nil {
+		if err == repository.ErrTimeEntryChanged {
+			return nil, &TimeClockError{Reason: "employee is not on a break"}
+		}
+		utils.Error("Failed to close time entry break: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	return entry, nil
+}
+
+// RecordTimeEntry lets a manager add a missed, closed time entry for an employee.
+func RecordTimeEntry(employeeID uuid.UUID, clockIn time.Time, clockOut time.Time, notes string, reason string, managerID string) (*model.TimeEntry, error) {
+	employee, err := repository.GetEmployeeByID(employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("employee not found")
+	}
+	if !employee.IsEmployedOn(clockIn.UTC().Truncate(24 * time.Hour)) {
+		return nil, &TimeClockError{Reason: "employee was not employed at that time"}
+	}
+	if err := checkCorrectionFits(employeeID, clockIn, &clockOut, nil); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	entry := &model.TimeEntry{
+		EmployeeID:       employeeID,
+		ClockIn:          clockIn,
+		ClockOut:         &clockOut,
+		Notes:            notes,
+		RecordedBy:       managerID,
+		CorrectedBy:      managerID,
+		CorrectedAt:      &now,
+		CorrectionReason: reason,
+		Breaks:           []model.TimeEntryBreak{},
+	}
+	if err := repository.CreateTimeEntry(entry); err != nil {
+		utils.Error("Failed to record time entry: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	utils.Info(fmt.Sprintf("Time entry %s recorded for employee %s by %s: %s", entry.ID, employeeID, managerID, reason))
+	return entry, nil
+}
+
+// CorrectTimeEntry lets a manager change the clock-in or clock-out time of an entry. Nil times
+// keep their current value. The breaks of the entry must still fall inside it.
+func CorrectTimeEntry(entryID uuid.UUID, clockIn *time.Time, clockOut *time.Time, notes *string, reason string, managerID string) (*model.TimeEntry, error) {
+	entry, err := repository.GetTimeEntryByID(entryID)
+	if err != nil {
+		return nil, fmt.Errorf("time entry not found")
+	}
+
+	newClockIn := entry.ClockIn
+	if clockIn != nil {
+		newClockIn = *clockIn
+	}
+	newClockOut := entry.ClockOut
+	if clockOut != nil {
+		newClockOut = clockOut
+	}
+	if newClockOut != nil && !newClockOut.After(newClockIn) {
+		return nil, &TimeClockError{Reason: "clock-out time must be after clock-in time"}
+	}
+
+	// The week of the old and of the new clock-in must both be open
+	if err := checkTimesheetLocked(entry.EmployeeID, entry.ClockIn); err != nil {
+		return nil, err
+	}
+	if err := checkCorrectionFits(entry.EmployeeID, newClockIn, newClockOut, &entry.ID); err != nil {
+		return nil, err
+	}
+	for _, entryBreak := range entry.Breaks {
+		if entryBreak.StartTime.Before(newClockIn) {
+			return nil, &TimeClockError{Reason: "a break of the entry starts before the corrected clock-in time"}
+		}
+		breakEnd := entryBreak.EndTime
+		if breakEnd == nil {
+			breakEnd = &entryBreak.StartTime
+		}
+		if newClockOut != nil && breakEnd.After(*newClockOut) {
+			return nil, &TimeClockError{Reason: "a break of the entry ends after the corrected clock-out time"}
+		}
+	}
+
+	now := time.Now().UTC()
+	entry.ClockIn = newClockIn
+	entry.ClockOut = newClockOut
+	if notes != nil {
+		entry.Notes = *notes
+	}
+	entry.CorrectedBy = managerID
+	entry.CorrectedAt = &now
+	entry.CorrectionReason = reason
+	if err := repository.SaveTimeEntryCorrection(&entry); err != nil {
+		utils.Error("Failed to correct time entry: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	utils.Info(fmt.Sprintf("Time entry %s corrected by %s: %s", entry.ID, managerID, reason))
+	return &entry, nil
+}
+
+// checkCorrectionFits checks that a recorded or corrected entry lies in an open week and does not
+// overlap the other entries of the employee
+func checkCorrectionFits(employeeID uuid.UUID, clockIn time.Time, clockOut *time.Time, excludeID *uuid.UUID) error {
+	if err := checkTimesheetLocked(employeeID, clockIn); err != nil {
+		return err
+	}
+
+	end := time.Now().UTC()
+	if clockOut != nil {
+		end = *clockOut
+	}
+	overlaps, err := repository.CheckOverlappingTimeEntryRange(employeeID, clockIn, end, excludeID)
+	if err != nil {
+		utils.Error("Failed to check for overlapping time entries: " + err.Error())
+		return fmt.Errorf("internal server error")
+	}
+	if overlaps {
+		return &TimeClockError{Reason: "the entry overlaps another time entry of the employee"}
+	}
+	return nil
+}
+
+// getOpenTimeEntry returns the open entry of an employee or a TimeClockError if they are not clocked in
+// or the week the entry was clocked in has been approved
+func getOpenTimeEntry(employeeID uuid.UUID) (*model.TimeEntry, error) {
+	exists, err := repository.CheckEmployeeExists(employeeID)
+	if err != nil {
+		utils.Error("Failed to check employee existence: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	if !exists {
+		return nil, fmt.Errorf("employee not found")
+	}
+
+	entry, err := repository.GetOpenTimeEntry(employeeID)
+	if err != nil {
+		utils.Error("Failed to get open time entry: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	if entry == nil {
+		return nil, &TimeClockError{Reason: "employee is not clocked in"}
+	}
+	if err := checkTimesheetLocked(employeeID, entry.ClockIn); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// findOpenBreak returns the break of an entry that has not ended yet, if any
+func findOpenBreak(entry *model.TimeEntry) *model.TimeEntryBreak {
+	for i := range entry.Breaks {
+		if entry.Breaks[i].EndTime == nil {
+			return &entry.Breaks[i]
+		}
+	}
+	return nil
+}
+
+// workedRanges returns the time actually worked in an entry, i.e. the entry minus its breaks.
+// Open entries and breaks are treated as running until now.
+func workedRanges(entry model.TimeEntry, now time.Time) []model.TimeRange {
+	end := now
+	if entry.ClockOut != nil {
+		end = *entry.ClockOut
+	}
+	if !end.After(entry.ClockIn) {
+		return nil
+	}
+
+	breaks := make([]model.TimeRange, 0, len(entry.Breaks))
+	for _, entryBreak := range entry.Breaks {
+		breakEnd := end
+		if entryBreak.EndTime != nil {
+			breakEnd = *entryBreak.EndTime
+		}
+		breaks = append(breaks, model.TimeRange{Start: entryBreak.StartTime, End: breakEnd})
+	}
+
+	return subtractTimeRanges([]model.TimeRange{{Start: entry.ClockIn, End: end}}, breaks)
+}
+
+// totalMinutes returns the combined length of the ranges in whole minutes
+func totalMinutes(ranges []model.TimeRange) int {
+	var total time.Duration
+	for _, r := range ranges {
+		total += r.End.Sub(r.Start)
+	}
+	return int(total.Minutes())
+}
@@ -0,0 +1,385 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// TimesheetRules holds the overtime thresholds and the night window used to split timesheet hours.
+type TimesheetRules struct {
+	DailyOvertimeMinutes  int       // Minutes per day after which work is overtime, 0 disables daily overtime
+	WeeklyOvertimeMinutes int       // Minutes per week after which work is overtime, 0 disables weekly overtime
+	NightStart            time.Time // Time of day the night window starts
+	NightEnd              time.Time // Time of day the night window ends, before NightStart when it crosses midnight
+}
+
+// TimesheetStatusError represents an error when a timesheet is not in a state that allows the action.
+type TimesheetStatusError struct {
+	Reason string
+}
+
+func (e *TimesheetStatusError) Error() string {
+	return e.Reason
+}
+
+// TimesheetApprovalError represents an error when a week cannot be approved yet.
+type TimesheetApprovalError struct {
+	Reason string
+}
+
+func (e *TimesheetApprovalError) Error() string {
+	return "cannot approve timesheet: " + e.Reason
+}
+
+// LoadTimesheetRules reads the timesheet rules from the environment.
+//
+//	TIMESHEET_DAILY_OVERTIME_HOURS  (default 0, disabled)
+//	TIMESHEET_WEEKLY_OVERTIME_HOURS (default 40)
+//	TIMESHEET_NIGHT_START           (default 22:00)
+//	TIMESHEET_NIGHT_END             (default 06:00)
+//
+// Invalid values are logged and replaced by their default.
+func LoadTimesheetRules() TimesheetRules {
+	return TimesheetRules{
+		DailyOvertimeMinutes:  envHoursAsMinutes("TIMESHEET_DAILY_OVERTIME_HOURS", 0),
+		WeeklyOvertimeMinutes: envHoursAsMinutes("TIMESHEET_WEEKLY_OVERTIME_HOURS", 40),
+		NightStart:            envTimeOfDay("TIMESHEET_NIGHT_START", 22),
+		NightEnd:              envTimeOfDay("TIMESHEET_NIGHT_END", 6),
+	}
+}
+
+// GetTimesheet returns the timesheet of an employee for the week starting on weekStart.
+// An approved week returns the stored snapshot; otherwise hours are computed from the given source.
+func (s *AvailabilityService) GetTimesheet(employeeID uuid.UUID, weekStart time.Time, source string) (*model.Timesheet, error) {
+	exists, err := repository.CheckEmployeeExists(employeeID)
+	if err != nil {
+		utils.Error(fmt.Sprintf("Failed to check employee existence: %v", err))
+		return nil, fmt.Errorf("internal server error")
+	}
+	if !exists {
+		return nil, fmt.Errorf("employee not found")
+	}
+
+	approved, err := repository.GetApprovedTimesheet(employeeID, weekStart)
+	if err != nil {
+		utils.Error(fmt.Sprintf("Failed to get approved timesheet: %v", err))
+		return nil, fmt.Errorf("internal server error")
+	}
+	if approved != nil {
+		return approved, nil
+	}
+
+	return s.computeTimesheet(employeeID, weekStart, source, LoadTimesheetRules())
+}
+
+// ApproveTimesheet computes the hours of an employee's week, stores them and locks the week
+// against further time clock changes. Only finished weeks without running time entries can be approved.
+func (s *AvailabilityService) ApproveTimesheet(employeeID uuid.UUID, weekStart time.Time, source string, approvedBy string) (*model.Timesheet, error) {
+	timesheet, err := s.GetTimesheet(employeeID, weekStart, source)
+	if err != nil {
+		return nil, err
+	}
+	if timesheet.Status == model.TimesheetStatusApproved {
+		return nil, &TimesheetStatusError{Reason: "timesheet is already approved"}
+	}
+
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	if time.Now().UTC().Before(weekEnd) {
+		return nil, &TimesheetApprovalError{Reason: "week has not ended yet"}
+	}
+	if source == model.TimesheetSourceClocked {
+		entries, err := repository.GetTimeEntriesForPeriod(&employeeID, weekStart, weekEnd)
+		if err != nil {
+			utils.Error("Failed to get time entries: " + err.Error())
+			return nil, fmt.Errorf("internal server error")
+		}
+		for _, entry := range entries {
+			if entry.ClockOut == nil {
+				return nil, &TimesheetApprovalError{Reason: "employee is still clocked in on an entry of this week"}
+			}
+		}
+	}
+
+	now := time.Now()
+	timesheet.Status = model.TimesheetStatusApproved
+	timesheet.ApprovedBy = approvedBy
+	timesheet.ApprovedAt = &now
+	if err := repository.SaveApprovedTimesheet(timesheet); err != nil {
+		if strings.Contains(err.Error(), "idx_timesheet_week") {
+			return nil, &TimesheetStatusError{Reason: "timesheet is already approved"}
+		}
+		utils.Error("Failed to save approved timesheet: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	return timesheet, nil
+}
+
+// ReopenTimesheet removes the approval of a timesheet and unlocks its week.
+func ReopenTimesheet(timesheet model.Timesheet) error {
+	if err := repository.DeleteApprovedTimesheet(timesheet.ID); err != nil {
+		utils.Error("Failed to reopen timesheet: " + err.Error())
+		return fmt.Errorf("internal server error")
+	}
+	return nil
+}
+
+// BuildPayrollExport collects the approved timesheets of a week in the payroll export format.
+// Lines are ordered by last name, first name and employee ID so repeated exports are identical.
+func BuildPayrollExport(weekStart time.Time) (*model.PayrollExport, error) {
+	timesheets, err := repository.GetApprovedTimesheetsForWeek(weekStart)
+	if err != nil {
+		utils.Error("Failed to get approved timesheets: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+
+	export := &model.PayrollExport{
+		FormatVersion: "1",
+		WeekStart:     weekStart.Format("2006-01-02"),
+		WeekEnd:       weekStart.AddDate(0, 0, 6).Format("2006-01-02"),
+		GeneratedAt:   time.Now().UTC(),
+		Timesheets:    []model.PayrollExportLine{},
+	}
+
+	for _, timesheet := range timesheets {
+		// Former staff still get paid for their approved weeks
+		employee, err := repository.GetEmployeeByIDUnscoped(timesheet.EmployeeID)
+		if err != nil {
+			utils.Warning(fmt.Sprintf("Skipping timesheet %s of missing employee %s", timesheet.ID, timesheet.EmployeeID))
+			continue
+		}
+		// Role and pay grade are reported as they were during the week
+		employee, _, err = GetEmployeeAsOf(employee, weekStart)
+		if err != nil {
+			return nil, err
+		}
+		line := model.PayrollExportLine{
+			EmployeeID:    employee.ID,
+			Email:         employee.Email,
+			FirstName:     employee.FirstName,
+			LastName:      employee.LastName,
+			WeekStart:     export.WeekStart,
+			Source:        timesheet.Source,
+			RegularHours:  minutesToHours(timesheet.RegularMinutes),
+			OvertimeHours: minutesToHours(timesheet.OvertimeMinutes),
+			NightHours:    minutesToHours(timesheet.NightMinutes),
+			HolidayHours:  minutesToHours(timesheet.HolidayMinutes),
+			TotalHours:    minutesToHours(timesheet.TotalMinutes),
+			ApprovedBy:    timesheet.ApprovedBy,
+			Role:          employee.Role,
+			PayGrade:      employee.PayGrade,
+		}
+		if timesheet.ApprovedAt != nil {
+			line.ApprovedAt = timesheet.ApprovedAt.UTC()
+		}
+		export.Timesheets = append(export.Timesheets, line)
+	}
+
+	sort.SliceStable(export.Timesheets, func(i, j int) bool {
+		a, b := export.Timesheets[i], export.Timesheets[j]
+		if a.LastName != b.LastName {
+			return a.LastName < b.LastName
+		}
+		if a.FirstName != b.FirstName {
+			return a.FirstName < b.FirstName
+		}
+		return a.EmployeeID.String() < b.EmployeeID.String()
+	})
+
+	return export, nil
+}
+
+// PayrollExportCSV returns the header and rows of the CSV payroll export
+func PayrollExportCSV(export *model.PayrollExport) [][]string {
+	rows := [][]string{{
+		"employee_id", "email", "first_name", "last_name", "week_start", "source",
+		"regular_hours", "overtime_hours", "night_hours", "holiday_hours", "total_hours",
+		"approved_by", "approved_at", "role", "pay_grade",
+	}}
+	for _, line := range export.Timesheets {
+		rows = append(rows, []string{
+			line.EmployeeID.String(),
+			line.Email,
+			line.FirstName,
+			line.LastName,
+			line.WeekStart,
+			line.Source,
+			strconv.FormatFloat(line.RegularHours, 'f', 2, 64),
+			strconv.FormatFloat(line.OvertimeHours, 'f', 2, 64),
+			strconv.FormatFloat(line.NightHours, 'f', 2, 64),
+			strconv.FormatFloat(line.HolidayHours, 'f', 2, 64),
+			strconv.FormatFloat(line.TotalHours, 'f', 2, 64),
+			line.ApprovedBy,
+			line.ApprovedAt.Format(time.RFC3339),
+			line.Role,
+			line.PayGrade,
+		})
+	}
+	return rows
+}
+
+// computeTimesheet splits the hours of a week into regular, overtime, night and holiday minutes.
+// Work is attributed to the day it started.
+func (s *AvailabilityService) computeTimesheet(employeeID uuid.UUID, weekStart time.Time, source string, rules TimesheetRules) (*model.Timesheet, error) {
+	weekEnd := weekStart.AddDate(0, 0, 7)
+
+	var worked [7][]model.TimeRange
+	if source == model.TimesheetSourceScheduled {
+		for i := 0; i < 7; i++ {
+			availability, err := s.GetEmployeeAvailability(employeeID, weekStart.AddDate(0, 0, i))
+			if err != nil {
+				if err.Error() == "no schedule found for employee on this date" {
+					continue
+				}
+				return nil, err
+			}
+			worked[i] = toTimeRanges(availability.FreeSlots)
+		}
+	} else {
+		entries, err := repository.GetTimeEntriesForPeriod(&employeeID, weekStart, weekEnd)
+		if err != nil {
+			utils.Error(fmt.Sprintf("Failed to get time entries for employee %s: %v", employeeID, err))
+			return nil, fmt.Errorf("internal server error")
+		}
+		now := time.Now().UTC()
+		for _, entry := range entries {
+			day := int(entry.ClockIn.UTC().Sub(weekStart).Hours() / 24)
+			if day < 0 || day > 6 {
+				continue
+			}
+			worked[day] = append(worked[day], workedRanges(entry, now)...)
+		}
+	}
+
+	// Overnight work on Sunday may run into a holiday on the following Monday
+	holidays, err := repository.GetHolidays(weekStart, weekEnd.AddDate(0, 0, 1))
+	if err != nil {
+		utils.Error("Failed to get holidays: " + err.Error())
+		return nil, fmt.Errorf("internal server error")
+	}
+	holidayRanges := make([]model.TimeRange, 0, len(holidays))
+	for _, holiday := range holidays {
+		holidayRanges = append(holidayRanges, model.TimeRange{Start: holiday.Date, End: holiday.Date.AddDate(0, 0, 1)})
+	}
+
+	timesheet := &model.Timesheet{
+		EmployeeID: employeeID,
+		WeekStart:  weekStart,
+		Status:     model.TimesheetStatusOpen,
+		Source:     source,
+	}
+	splitTimesheetHours(timesheet, worked, holidayRanges, rules)
+
+	return timesheet, nil
+}
+
+// splitTimesheetHours fills the days and totals of a timesheet from the ranges worked on each day
+// of its week. Daily overtime is taken first; the remaining regular minutes then count towards the
+// weekly threshold in day order.
+func splitTimesheetHours(timesheet *model.Timesheet, worked [7][]model.TimeRange, holidayRanges []model.TimeRange, rules TimesheetRules) {
+	timesheet.Days = make([]model.TimesheetDay, 0, 7)
+
+	weeklyRegular := 0
+	for i := 0; i < 7; i++ {
+		date := timesheet.WeekStart.AddDate(0, 0, i)
+		day := model.TimesheetDay{
+			Date:           date,
+			TotalMinutes:   totalMinutes(worked[i]),
+			NightMinutes:   totalMinutes(intersectTimeRanges(worked[i], nightWindows(date, rules))),
+			HolidayMinutes: totalMinutes(intersectTimeRanges(worked[i], holidayRanges)),
+		}
+
+		day.RegularMinutes = day.TotalMinutes
+		if rules.DailyOvertimeMinutes > 0 && day.RegularMinutes > rules.DailyOvertimeMinutes {
+			day.OvertimeMinutes = day.RegularMinutes - rules.DailyOvertimeMinutes
+			day.RegularMinutes = rules.DailyOvertimeMinutes
+		}
+		if rules.WeeklyOvertimeMinutes > 0 && weeklyRegular+day.RegularMinutes > rules.WeeklyOvertimeMinutes {
+			excess := weeklyRegular + day.RegularMinutes - rules.WeeklyOvertimeMinutes
+			day.OvertimeMinutes += excess
+			day.RegularMinutes -= excess
+		}
+		weeklyRegular += day.RegularMinutes
+
+		timesheet.Days = append(timesheet.Days, day)
+		timesheet.TotalMinutes += day.TotalMinutes
+		timesheet.RegularMinutes += day.RegularMinutes
+		timesheet.OvertimeMinutes += day.OvertimeMinutes
+		timesheet.NightMinutes += day.NightMinutes
+		timesheet.HolidayMinutes += day.HolidayMinutes
+	}
+}
+
+// nightWindows returns the night windows that can overlap work started on date,
+// i.e. the windows starting the day before, on the day itself and the day after.
+func nightWindows(date time.Time, rules TimesheetRules) []model.TimeRange {
+	if rules.NightStart.Equal(rules.NightEnd) {
+		return nil
+	}
+	windows := make([]model.TimeRange, 0, 3)
+	for offset := -1; offset <= 1; offset++ {
+		windows = append(windows, timeOfDayRange(date.AddDate(0, 0, offset), rules.NightStart, rules.NightEnd))
+	}
+	return windows
+}
+
+// checkTimesheetLocked returns a TimeClockError when the week containing at has an approved timesheet
+func checkTimesheetLocked(employeeID uuid.UUID, at time.Time) error {
+	locked, err := repository.CheckTimesheetLocked(employeeID, WeekStartOf(at))
+	if err != nil {
+		utils.Error("Failed to check timesheet lock: " + err.Error())
+		return fmt.Errorf("internal server error")
+	}
+	if locked {
+		return &TimeClockError{Reason: "timesheet for this week is approved and locked"}
+	}
+	return nil
+}
+
+// WeekStartOf returns the Monday (UTC) of the week containing t
+func WeekStartOf(t time.Time) time.Time {
+	t = t.UTC()
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+}
+
+// minutesToHours converts minutes to hours rounded to two decimals
+func minutesToHours(minutes int) float64 {
+	return roundHours(float64(minutes) / 60)
+}
+
+// envHoursAsMinutes reads a number of hours from the environment
+func envHoursAsMinutes(key string, defaultHours float64) int {
+	hours := defaultHours
+	if value := os.Getenv(key); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 {
+			utils.Warning(fmt.Sprintf("Invalid %s %q, using %.1f", key, value, defaultHours))
+		} else {
+			hours = parsed
+		}
+	}
+	return int(hours * 60)
+}
+
+// envTimeOfDay reads an HH:MM time of day from the environment
+func envTimeOfDay(key string, defaultHour int) time.Time {
+	if value := os.Getenv(key); value != "" {
+		parsed, err := time.Parse("15:04", value)
+		if err == nil {
+			return parsed
+		}
+		utils.Warning(fmt.Sprintf("Invalid %s %q, using %02d:00", key, value, defaultHour))
+	}
+	return time.Date(0, 1, 1, defaultHour, 0, 0, 0, time.UTC)
+}
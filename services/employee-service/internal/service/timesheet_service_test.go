@@ -0,0 +1,154 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// shift returns hours of work starting at startHour on a day of the test week
+func shift(day, startHour, hours int) model.TimeRange {
+	start := at(startHour, 0).AddDate(0, 0, day)
+	return model.TimeRange{Start: start, End: start.Add(time.Duration(hours) * time.Hour)}
+}
+
+func TestSplitTimesheetHours(t *testing.T) {
+	night := TimesheetRules{NightStart: timeOfDay(22, 0), NightEnd: timeOfDay(6, 0)}
+	weekly40 := night
+	weekly40.WeeklyOvertimeMinutes = 40 * 60
+	daily8 := weekly40
+	daily8.DailyOvertimeMinutes = 8 * 60
+
+	// The week after the test week starts with a holiday
+	nextMonday := at(0, 0).AddDate(0, 0, 7)
+	holidays := []model.TimeRange{{Start: nextMonday, End: nextMonday.AddDate(0, 0, 1)}}
+
+	weekdays := func(startHour, hours, days int) [7][]model.TimeRange {
+		var worked [7][]model.TimeRange
+		for day := 0; day < days; day++ {
+			worked[day] = []model.TimeRange{shift(day, startHour, hours)}
+		}
+		return worked
+	}
+
+	tests := []struct {
+		name     string
+		worked   [7][]model.TimeRange
+		rules    TimesheetRules
+		holidays []model.TimeRange
+		want     model.Timesheet
+		wantDay  int // Index of a day to compare with wantDays
+		wantDays model.TimesheetDay
+	}{
+		{
+			name:     "no overtime rules",
+			worked:   weekdays(8, 10, 5),
+			rules:    night,
+			want:     model.Timesheet{TotalMinutes: 3000, RegularMinutes: 3000},
+			wantDay:  0,
+			wantDays: model.TimesheetDay{TotalMinutes: 600, RegularMinutes: 600},
+		},
+		{
+			name:     "weekly overtime falls on the last days",
+			worked:   weekdays(8, 9, 5),
+			rules:    weekly40,
+			want:     model.Timesheet{TotalMinutes: 2700, RegularMinutes: 2400, OvertimeMinutes: 300},
+			wantDay:  4,
+			wantDays: model.TimesheetDay{TotalMinutes: 540, RegularMinutes: 240, OvertimeMinutes: 300},
+		},
+		{
+			name:     "daily overtime is not counted again weekly",
+			worked:   weekdays(8, 10, 5),
+			rules:    daily8,
+			want:     model.Timesheet{TotalMinutes: 3000, RegularMinutes: 2400, OvertimeMinutes: 600},
+			wantDay:  4,
+			wantDays: model.TimesheetDay{TotalMinutes: 600, RegularMinutes: 480, OvertimeMinutes: 120},
+		},
+		{
+			name:     "sixth day is weekly overtime",
+			worked:   weekdays(8, 8, 6),
+			rules:    daily8,
+			want:     model.Timesheet{TotalMinutes: 2880, RegularMinutes: 2400, OvertimeMinutes: 480},
+			wantDay:  5,
+			wantDays: model.TimesheetDay{TotalMinutes: 480, RegularMinutes: 0, OvertimeMinutes: 480},
+		},
+		{
+			name:     "night shift across midnight",
+			worked:   [7][]model.TimeRange{{shift(0, 20, 6)}},
+			rules:    night,
+			want:     model.Timesheet{TotalMinutes: 360, RegularMinutes: 360, NightMinutes: 240},
+			wantDay:  0,
+			wantDays: model.TimesheetDay{TotalMinutes: 360, RegularMinutes: 360, NightMinutes: 240},
+		},
+		{
+			name:     "early shift ends the night window",
+			worked:   [7][]model.TimeRange{{shift(0, 4, 4)}},
+			rules:    night,
+			want:     model.Timesheet{TotalMinutes: 240, RegularMinutes: 240, NightMinutes: 120},
+			wantDay:  0,
+			wantDays: model.TimesheetDay{TotalMinutes: 240, RegularMinutes: 240, NightMinutes: 120},
+		},
+		{
+			name:     "sunday night shift runs into a holiday",
+			worked:   [7][]model.TimeRange{6: {shift(6, 20, 6)}},
+			rules:    night,
+			holidays: holidays,
+			want:     model.Timesheet{TotalMinutes: 360, RegularMinutes: 360, NightMinutes: 240, HolidayMinutes: 120},
+			wantDay:  6,
+			wantDays: model.TimesheetDay{TotalMinutes: 360, RegularMinutes: 360, NightMinutes: 240, HolidayMinutes: 120},
+		},
+		{
+			name:     "night window disabled",
+			worked:   [7][]model.TimeRange{{shift(0, 20, 6)}},
+			rules:    TimesheetRules{NightStart: timeOfDay(0, 0), NightEnd: timeOfDay(0, 0)},
+			want:     model.Timesheet{TotalMinutes: 360, RegularMinutes: 360},
+			wantDay:  0,
+			wantDays: model.TimesheetDay{TotalMinutes: 360, RegularMinutes: 360},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timesheet := &model.Timesheet{WeekStart: at(0, 0)}
+			splitTimesheetHours(timesheet, tt.worked, tt.holidays, tt.rules)
+
+			if len(timesheet.Days) != 7 {
+				t.Fatalf("got %d days, want 7", len(timesheet.Days))
+			}
+			if timesheet.TotalMinutes != tt.want.TotalMinutes || timesheet.RegularMinutes != tt.want.RegularMinutes ||
+				timesheet.OvertimeMinutes != tt.want.OvertimeMinutes || timesheet.NightMinutes != tt.want.NightMinutes ||
+				timesheet.HolidayMinutes != tt.want.HolidayMinutes {
+				t.Errorf("totals = %d/%d/%d/%d/%d, want %d/%d/%d/%d/%d (total/regular/overtime/night/holiday)",
+					timesheet.TotalMinutes, timesheet.RegularMinutes, timesheet.OvertimeMinutes, timesheet.NightMinutes, timesheet.HolidayMinutes,
+					tt.want.TotalMinutes, tt.want.RegularMinutes, tt.want.OvertimeMinutes, tt.want.NightMinutes, tt.want.HolidayMinutes)
+			}
+
+			day := timesheet.Days[tt.wantDay]
+			tt.wantDays.Date = at(0, 0).AddDate(0, 0, tt.wantDay)
+			if day != tt.wantDays {
+				t.Errorf("day %d = %+v, want %+v", tt.wantDay, day, tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestWeekStartOf(t *testing.T) {
+	monday := at(0, 0)
+	tests := []struct {
+		name string
+		in   time.Time
+	}{
+		{"monday midnight", monday},
+		{"wednesday", at(15, 30).AddDate(0, 0, 2)},
+		{"sunday night", at(23, 59).AddDate(0, 0, 6)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeekStartOf(tt.in); !got.Equal(monday) {
+				t.Errorf("WeekStartOf(%v) = %v, want %v", tt.in, got, monday)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"services/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/model"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// GetVarianceReport compares the time entries of an employee with the schedule, blocks and
+// recurring breaks resolved by the availability engine for every day of the period.
+// Entries are attributed to the day they were clocked in. Differences up to graceMinutes are ignored.
+func (s *AvailabilityService) GetVarianceReport(employeeID uuid.UUID, startDate time.Time, endDate time.Time, graceMinutes int) (*model.VarianceReport, error) {
+	exists, err := repository.CheckEmployeeExists(employeeID)
+	if err != nil {
+		utils.Error(fmt.Sprintf("Failed to check employee existence: %v", err))
+		return nil, fmt.Errorf("internal server error")
+	}
+	if !exists {
+		return nil, fmt.Errorf("employee not found")
+	}
+
+	entries, err := repository.GetTimeEntriesForPeriod(&employeeID, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		utils.Error(fmt.Sprintf("Failed to get time entries for employee %s: %v", employeeID, err))
+		return nil, fmt.Errorf("internal server error")
+	}
+	entriesByDay := make(map[string][]model.TimeEntry)
+	for _, entry := range entries {
+		key := entry.ClockIn.UTC().Format("2006-01-02")
+		entriesByDay[key] = append(entriesByDay[key], entry)
+	}
+
+	report := &model.VarianceReport{
+		EmployeeID:   employeeID,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		GraceMinutes: graceMinutes,
+		Days:         []model.VarianceDay{},
+	}
+
+	now := time.Now().UTC()
+	grace := time.Duration(graceMinutes) * time.Minute
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		availability, err := s.GetEmployeeAvailability(employeeID, date)
+		if err != nil && err.Error() != "no schedule found for employee on this date" {
+			return nil, err
+		}
+
+		dayEntries := entriesByDay[date.Format("2006-01-02")]
+		if dayEntries == nil {
+			dayEntries = []model.TimeEntry{}
+		}
+		day := buildVarianceDay(date, availability, dayEntries, grace, now)
+
+		report.Days = append(report.Days, day)
+		report.ScheduledMinutes += day.ScheduledMinutes
+		report.WorkedMinutes += day.WorkedMinutes
+	}
+	report.VarianceMinutes = report.WorkedMinutes - report.ScheduledMinutes
+
+	return report, nil
+}
+
+// buildVarianceDay compares one day of time entries with the availability of that day.
+// availability is nil when the employee was not scheduled.
+func buildVarianceDay(date time.Time, availability *model.AvailabilityResponse, entries []model.TimeEntry, grace time.Duration, now time.Time) model.VarianceDay {
+	day := model.VarianceDay{
+		Date:    date,
+		Breaks:  []model.AvailabilityBreak{},
+		Entries: entries,
+		Flags:   []model.VarianceFlag{},
+	}
+
+	// Actual work
+	var worked []model.TimeRange
+	var lastClockOut *time.Time
+	openEntry := false
+	for _, entry := range entries {
+		worked = append(worked, workedRanges(entry, now)...)
+		if entry.ClockOut == nil {
+			openEntry = true
+		} else if lastClockOut == nil || entry.ClockOut.After(*lastClockOut) {
+			lastClockOut = entry.ClockOut
+		}
+	}
+	day.WorkedMinutes = totalMinutes(worked)
+
+	// Without a schedule all work is unscheduled
+	if availability == nil || availability.Schedule == nil {
+		if day.WorkedMinutes > int(grace.Minutes()) {
+			day.Flags = append(day.Flags, model.VarianceFlag{
+				Type:    model.VarianceUnscheduled,
+				Minutes: day.WorkedMinutes,
+				Message: "worked on a day without a schedule",
+			})
+		}
+		day.VarianceMinutes = day.WorkedMinutes
+		return day
+	}
+
+	day.Schedule = availability.Schedule
+	day.Breaks = availability.Breaks
+	free := toTimeRanges(availability.FreeSlots)
+	day.ScheduledMinutes = totalMinutes(free)
+	day.VarianceMinutes = day.WorkedMinutes - day.ScheduledMinutes
+
+	// Nothing was expected (e.g. the whole shift is blocked for leave)
+	if len(free) == 0 {
+		if day.WorkedMinutes > int(grace.Minutes()) {
+			day.Flags = append(day.Flags, model.VarianceFlag{
+				Type:    model.VarianceUnscheduled,
+				Minutes: day.WorkedMinutes,
+				Message: "worked while blocked for the whole shift",
+			})
+		}
+		return day
+	}
+
+	expectedStart := free[0].Start
+	expectedEnd := free[len(free)-1].End
+
+	// No show
+	if len(entries) == 0 {
+		if now.After(expectedStart.Add(grace)) {
+			day.Flags = append(day.Flags, model.VarianceFlag{
+				Type:    model.VarianceNoShow,
+				Minutes: day.ScheduledMinutes,
+				Message: "scheduled but did not clock in",
+			})
+		}
+		return day
+	}
+
+	// Late arrival
+	if firstClockIn := entries[0].ClockIn; firstClockIn.After(expectedStart.Add(grace)) {
+		day.Flags = append(day.Flags, model.VarianceFlag{
+			Type:    model.VarianceLateArrival,
+			Minutes: int(firstClockIn.Sub(expectedStart).Minutes()),
+			Message: fmt.Sprintf("clocked in at %s, expected %s", firstClockIn.UTC().Format("15:04"), expectedStart.Format("15:04")),
+		})
+	}
+
+	// Early departure
+	if !openEntry && lastClockOut != nil && lastClockOut.Before(expectedEnd.Add(-grace)) {
+		day.Flags = append(day.Flags, model.VarianceFlag{
+			Type:    model.VarianceEarlyDeparture,
+			Minutes: int(expectedEnd.Sub(*lastClockOut).Minutes()),
+			Message: fmt.Sprintf("clocked out at %s, expected %s", lastClockOut.UTC().Format("15:04"), expectedEnd.Format("15:04")),
+		})
+	}
+
+	// Missed breaks: worked through (most of) a scheduled break
+	for _, scheduledBreak := range availability.Breaks {
+		breakRange := model.TimeRange{Start: scheduledBreak.StartTime, End: scheduledBreak.EndTime}
+		workedDuringBreak := totalMinutes(intersectTimeRanges(worked, []model.TimeRange{breakRange}))
+		if workedDuringBreak > int(grace.Minutes()) {
+			day.Flags = append(day.Flags, model.VarianceFlag{
+				Type:    model.VarianceMissedBreak,
+				Minutes: workedDuringBreak,
+				Message: fmt.Sprintf("worked during break %s-%s", breakRange.Start.Format("15:04"), breakRange.End.Format("15:04")),
+			})
+		}
+	}
+
+	// Unscheduled work: outside the shifts or during one-time blocks
+	scheduled := shiftRanges(availability.Shifts)
+	blocks := make([]model.TimeRange, 0, len(availability.OneTimeBlocks))
+	for _, block := range availability.OneTimeBlocks {
+		blocks = append(blocks, model.TimeRange{Start: block.StartTime, End: block.EndTime})
+	}
+	outside := subtractTimeRanges(worked, subtractTimeRanges(scheduled, blocks))
+	if minutes := totalMinutes(outside); minutes > int(grace.Minutes()) {
+		day.Flags = append(day.Flags, model.VarianceFlag{
+			Type:    model.VarianceUnscheduled,
+			Minutes: minutes,
+			Message: "worked outside the scheduled hours",
+		})
+	}
+
+	return day
+}
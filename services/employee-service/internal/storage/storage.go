@@ -0,0 +1,60 @@
+// Package storage provides the blob stores used for managed assets such as employee photos.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrNotFound is returned when a blob does not exist
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore stores binary objects under slash separated keys
+type BlobStore interface {
+	// Put stores data under key, replacing any existing blob
+	Put(ctx context.Context, key string, contentType string, data []byte) error
+	// Get returns the data and content type stored under key, or ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, string, error)
+	// Delete removes the blob stored under key; deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// NewFromEnv creates the blob store selected by BLOB_STORAGE (local or s3, default local).
+//
+// Local storage:
+//
+//	BLOB_LOCAL_DIR (default ./data/blobs)
+//
+// S3-compatible storage:
+//
+//	S3_ENDPOINT      e.g. https://s3.eu-central-1.amazonaws.com or http://minio:9000
+//	S3_REGION        (default us-east-1)
+//	S3_BUCKET
+//	S3_ACCESS_KEY_ID
+//	S3_SECRET_ACCESS_KEY
+func NewFromEnv() (BlobStore, error) {
+	switch backend := os.Getenv("BLOB_STORAGE"); backend {
+	case "", "local":
+		dir := os.Getenv("BLOB_LOCAL_DIR")
+		if dir == "" {
+			dir = "./data/blobs"
+		}
+		return NewLocalStore(dir)
+	case "s3":
+		region := os.Getenv("S3_REGION")
+		if region == "" {
+			region = "us-east-1"
+		}
+		return NewS3Store(S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          region,
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		})
+	default:
+		return nil, fmt.Errorf("unknown BLOB_STORAGE %q, must be one of: local, s3", backend)
+	}
+}
@@ -85,4 +85,67 @@ func ValidateAvailabilityDateRange(date time.Time) error {
 	}
 	
 	return nil
-} 
\ No newline at end of file
+}
+
+// ValidateChainAvailabilityRequest validates the chained availability request and converts
+// its legs into queries. Step and result limits fall back to their defaults when omitted.
+func ValidateChainAvailabilityRequest(req model.ChainAvailabilityRequest) ([]model.ChainLegQuery, time.Duration, int, error) {
+	if req.Date == "" {
+		return nil, 0, 0, fmt.Errorf("date is required")
+	}
+	if len(req.Legs) == 0 {
+		return nil, 0, 0, fmt.Errorf("at least one leg is required")
+	}
+	if len(req.Legs) > 5 {
+		return nil, 0, 0, fmt.Errorf("a chained search supports at most 5 legs")
+	}
+
+	step := 15 * time.Minute
+	if req.StepMinutes != 0 {
+		if req.StepMinutes < 5 || req.StepMinutes > 60 {
+			return nil, 0, 0, fmt.Errorf("step_minutes must be between 5 and 60")
+		}
+		step = time.Duration(req.StepMinutes) * time.Minute
+	}
+
+	maxResults := 20
+	if req.MaxResults != 0 {
+		if req.MaxResults < 1 || req.MaxResults > 100 {
+			return nil, 0, 0, fmt.Errorf("max_results must be between 1 and 100")
+		}
+		maxResults = req.MaxResults
+	}
+
+	legs := make([]model.ChainLegQuery, len(req.Legs))
+	for i, leg := range req.Legs {
+		if leg.DurationMinutes <= 0 {
+			return nil, 0, 0, fmt.Errorf("legs[%d]: duration_minutes must be greater than 0", i)
+		}
+		if leg.GapAfterMinutes < 0 {
+			return nil, 0, 0, fmt.Errorf("legs[%d]: gap_after_minutes cannot be negative", i)
+		}
+
+		employeeIDs := make([]uuid.UUID, 0, len(leg.EmployeeIDs))
+		for _, employeeIDStr := range leg.EmployeeIDs {
+			employeeID, err := ValidateAvailabilityEmployeeID(employeeIDStr)
+			if err != nil {
+				return nil, 0, 0, fmt.Errorf("legs[%d]: %v", i, err)
+			}
+			employeeIDs = append(employeeIDs, employeeID)
+		}
+
+		resourceIDs, err := ValidateAvailabilityResourceIDs(leg.ResourceIDs)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("legs[%d]: %v", i, err)
+		}
+
+		legs[i] = model.ChainLegQuery{
+			Duration:    time.Duration(leg.DurationMinutes) * time.Minute,
+			GapAfter:    time.Duration(leg.GapAfterMinutes) * time.Minute,
+			EmployeeIDs: employeeIDs,
+			ResourceIDs: resourceIDs,
+		}
+	}
+
+	return legs, step, maxResults, nil
+}
@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+const (
+	maxCustomFieldOptions     = 100
+	maxCustomFieldOptionChars = 100
+	MaxCustomFieldTextLength  = 2000 // Longest text value, also the upper bound of max_length
+)
+
+// customFieldKeyPattern allows lowercase letters, digits and underscores, starting with a letter
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// CustomFieldDefinitionInput represents the data required to define or update a custom field.
+// Key and type cannot be changed once the field exists.
+type CustomFieldDefinitionInput struct {
+	Key       string   `json:"key"`
+	Label     string   `json:"label"`
+	Type      string   `json:"type"` // text, number, date or enum
+	Required  *bool    `json:"required"`
+	MaxLength *int     `json:"max_length"`
+	Pattern   *string  `json:"pattern"`
+	MinValue  *float64 `json:"min_value"`
+	MaxValue  *float64 `json:"max_value"`
+	Options   []string `json:"options"`
+	Position  *int     `json:"position"`
+}
+
+// ValidateCustomFieldRequiredFields validates the fields required to define a custom field
+func ValidateCustomFieldRequiredFields(input CustomFieldDefinitionInput) error {
+	if strings.TrimSpace(input.Key) == "" || strings.TrimSpace(input.Label) == "" || input.Type == "" {
+		return fmt.Errorf("key, label and type are required")
+	}
+	return nil
+}
+
+// ValidateCustomFieldKey normalizes and validates the key of a custom field
+func ValidateCustomFieldKey(key string) (string, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if !customFieldKeyPattern.MatchString(key) {
+		return "", fmt.Errorf("invalid key, must start with a letter and contain only lowercase letters, digits and underscores (max 64)")
+	}
+	return key, nil
+}
+
+// ValidateCustomFieldType validates the type of a custom field
+func ValidateCustomFieldType(fieldType string) (string, error) {
+	fieldType = strings.ToLower(strings.TrimSpace(fieldType))
+	switch fieldType {
+	case model.CustomFieldTypeText, model.CustomFieldTypeNumber, model.CustomFieldTypeDate, model.CustomFieldTypeEnum:
+		return fieldType, nil
+	}
+	return "", fmt.Errorf("invalid type, must be one of: text, number, date, enum")
+}
+
+// ValidateCustomFieldRules checks that the validation rules of a field fit its type
+func ValidateCustomFieldRules(field *model.CustomFieldDefinition) error {
+	if field.Type != model.CustomFieldTypeText && (field.MaxLength != nil || field.Pattern != "") {
+		return fmt.Errorf("max_length and pattern are only allowed for text fields")
+	}
+	if field.Type != model.CustomFieldTypeNumber && (field.MinValue != nil || field.MaxValue != nil) {
+		return fmt.Errorf("min_value and max_value are only allowed for number fields")
+	}
+	if field.Type != model.CustomFieldTypeEnum && len(field.Options) > 0 {
+		return fmt.Errorf("options are only allowed for enum fields")
+	}
+
+	if field.MaxLength != nil && (*field.MaxLength < 1 || *field.MaxLength > MaxCustomFieldTextLength) {
+		return fmt.Errorf("max_length must be between 1 and %d", MaxCustomFieldTextLength)
+	}
+	if field.Pattern != "" {
+		if _, err := regexp.Compile(field.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %v", err)
+		}
+	}
+	if field.MinValue != nil && field.MaxValue != nil && *field.MinValue > *field.MaxValue {
+		return fmt.Errorf("min_value cannot be greater than max_value")
+	}
+
+	if field.Type == model.CustomFieldTypeEnum {
+		options, err := normalizeCustomFieldOptions(field.Options)
+		if err != nil {
+			return err
+		}
+		field.Options = options
+	}
+	return nil
+}
+
+// normalizeCustomFieldOptions trims enum options and rejects empty, long and duplicate ones
+func normalizeCustomFieldOptions(options []string) ([]string, error) {
+	if len(options) == 0 {
+		return nil, fmt.Errorf("enum fields need at least one option")
+	}
+	if len(options) > maxCustomFieldOptions {
+		return nil, fmt.Errorf("enum fields can have at most %d options", maxCustomFieldOptions)
+	}
+
+	normalized := make([]string, 0, len(options))
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return nil, fmt.Errorf("options cannot be empty")
+		}
+		if len(option) > maxCustomFieldOptionChars {
+			return nil, fmt.Errorf("options cannot be longer than %d characters", maxCustomFieldOptionChars)
+		}
+		if seen[strings.ToLower(option)] {
+			return nil, fmt.Errorf("duplicate option: %s", option)
+		}
+		seen[strings.ToLower(option)] = true
+		normalized = append(normalized, option)
+	}
+	return normalized, nil
+}
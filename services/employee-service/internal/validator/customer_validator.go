@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CustomerInput represents the data required to create or update a customer.
+type CustomerInput struct {
+	FirstName             string  `json:"first_name"`
+	LastName              string  `json:"last_name"`
+	Email                 string  `json:"email"`
+	Phone                 string  `json:"phone"`
+	MarketingEmailConsent *bool   `json:"marketing_email_consent"` // Pointer to detect if field was provided
+	MarketingSMSConsent   *bool   `json:"marketing_sms_consent"`   // Pointer to detect if field was provided
+	Notes                 *string `json:"notes"`                   // Pointer to allow clearing notes
+}
+
+// CustomerMergeInput represents the duplicates to merge into a customer.
+type CustomerMergeInput struct {
+	DuplicateIDs []string `json:"duplicate_ids"`
+}
+
+// PreferredEmployeesInput represents the preferred employees of a customer.
+type PreferredEmployeesInput struct {
+	EmployeeIDs []string `json:"employee_ids"`
+}
+
+// ValidateCustomerRequiredFields validates that all required fields for a customer are provided.
+// A customer must be reachable, so at least an email or a phone number is required.
+func ValidateCustomerRequiredFields(input CustomerInput) error {
+	if strings.TrimSpace(input.FirstName) == "" || strings.TrimSpace(input.LastName) == "" {
+		return fmt.Errorf("first_name and last_name are required for customer")
+	}
+	if strings.TrimSpace(input.Email) == "" && strings.TrimSpace(input.Phone) == "" {
+		return fmt.Errorf("either email or phone is required for customer")
+	}
+	return nil
+}
+
+// ValidateAndNormalizeCustomerEmail validates the email format and lower-cases it. Empty is allowed.
+func ValidateAndNormalizeCustomerEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return "", nil
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return "", fmt.Errorf("invalid email format")
+	}
+	return strings.ToLower(email), nil
+}
+
+// ValidateAndNormalizePhone strips formatting characters from a phone number,
+// keeping the digits and an optional leading +. Empty is allowed.
+func ValidateAndNormalizePhone(phone string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	if phone == "" {
+		return "", nil
+	}
+
+	normalized := NormalizePhoneDigits(phone)
+	if strings.HasPrefix(phone, "+") {
+		normalized = "+" + normalized
+	}
+
+	digits := strings.TrimPrefix(normalized, "+")
+	if len(digits) < 6 || len(digits) > 15 {
+		return "", fmt.Errorf("invalid phone number, expected 6 to 15 digits")
+	}
+	return normalized, nil
+}
+
+// NormalizePhoneDigits returns only the digits of a string, used for phone searches
+func NormalizePhoneDigits(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ValidatePagination parses page and page_size query values with defaults of 1 and 20
+func ValidatePagination(pageStr, pageSizeStr string) (int, int, error) {
+	page, pageSize := 1, 20
+
+	if pageStr != "" {
+		parsed, err := strconv.Atoi(pageStr)
+		if err != nil || parsed < 1 {
+			return 0, 0, fmt.Errorf("page must be a positive integer")
+		}
+		page = parsed
+	}
+	if pageSizeStr != "" {
+		parsed, err := strconv.Atoi(pageSizeStr)
+		if err != nil || parsed < 1 || parsed > 100 {
+			return 0, 0, fmt.Errorf("page_size must be between 1 and 100")
+		}
+		pageSize = parsed
+	}
+
+	return page, pageSize, nil
+}
+
+// ValidateUUIDList parses a list of UUID strings, dropping duplicates
+func ValidateUUIDList(values []string, field string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	seen := make(map[uuid.UUID]bool)
+	for _, value := range values {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s format: %s", field, value)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
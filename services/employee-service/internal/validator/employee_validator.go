@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TerminationInput represents the input for terminating an employee
+type TerminationInput struct {
+	TerminationDate string `json:"termination_date"` // YYYY-MM-DD, the last working day
+}
+
+// ValidateAndParseTerminationDate parses the last working day of an employee
+func ValidateAndParseTerminationDate(dateStr string) (time.Time, error) {
+	if dateStr == "" {
+		return time.Time{}, fmt.Errorf("termination_date is required")
+	}
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid termination_date format, use YYYY-MM-DD")
+	}
+	return date, nil
+}
+
+// ValidateEmployeeSort validates the sort and order options of the employee directory,
+// defaulting to last name ascending
+func ValidateEmployeeSort(sort string, order string) (string, bool, error) {
+	if sort == "" {
+		sort = "last_name"
+	}
+	switch sort {
+	case "last_name", "first_name", "email", "role", "created_at":
+	default:
+		return "", false, fmt.Errorf("invalid sort, must be one of: last_name, first_name, email, role, created_at")
+	}
+
+	switch order {
+	case "", "asc":
+		return sort, false, nil
+	case "desc":
+		return sort, true, nil
+	}
+	return "", false, fmt.Errorf("invalid order, must be one of: asc, desc")
+}
+
+// ValidateOptionalBool parses an optional true/false query value
+func ValidateOptionalBool(value string, field string) (*bool, error) {
+	switch value {
+	case "":
+		return nil, nil
+	case "true":
+		result := true
+		return &result, nil
+	case "false":
+		result := false
+		return &result, nil
+	}
+	return nil, fmt.Errorf("%s must be true or false", field)
+}
+
+// UserLinkInput represents the input for linking an employee to an auth user
+type UserLinkInput struct {
+	UserID string `json:"user_id"`
+}
+
+// ValidateUserID parses the ID of an auth user
+func ValidateUserID(userIDStr string) (uuid.UUID, error) {
+	if userIDStr == "" {
+		return uuid.Nil, fmt.Errorf("user_id is required")
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format")
+	}
+	return userID, nil
+}
+
+// ValidateOptionalAsOfDate parses the optional as_of date of the employee endpoints
+func ValidateOptionalAsOfDate(asOfStr string) (*time.Time, error) {
+	if asOfStr == "" {
+		return nil, nil
+	}
+	asOf, err := time.Parse("2006-01-02", asOfStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid as_of format, use YYYY-MM-DD")
+	}
+	return &asOf, nil
+}
+
+// ValidateEffectiveFrom parses the date an employee change takes effect, defaulting to today.
+// Changes can be backdated but not scheduled for the future.
+func ValidateEffectiveFrom(dateStr string) (time.Time, error) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if dateStr == "" {
+		return today, nil
+	}
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid effective_from format, use YYYY-MM-DD")
+	}
+	if date.After(today) {
+		return time.Time{}, fmt.Errorf("effective_from cannot be in the future")
+	}
+	return date, nil
+}
@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// LeaveEntitlementInput represents the data required to create or update a leave entitlement.
+type LeaveEntitlementInput struct {
+	EmployeeID        string   `json:"employee_id"`
+	LeaveType         string   `json:"leave_type"`
+	Year              int      `json:"year"`
+	AllowanceHours    *float64 `json:"allowance_hours"`      // Pointer to detect if field was provided
+	AccrualMethod     string   `json:"accrual_method"`       // Defaults to upfront
+	CarryOverCapHours *float64 `json:"carry_over_cap_hours"` // Pointer to detect if field was provided
+}
+
+// ValidateLeaveEntitlementRequiredFields validates that all required fields for a leave entitlement are provided.
+func ValidateLeaveEntitlementRequiredFields(input LeaveEntitlementInput) error {
+	if input.EmployeeID == "" || input.LeaveType == "" || input.Year == 0 || input.AllowanceHours == nil {
+		return fmt.Errorf("employee_id, leave_type, year, and allowance_hours are required for leave entitlement")
+	}
+	return nil
+}
+
+// ValidateLeaveEntitlementValues validates the year, amounts and accrual method of a leave entitlement.
+func ValidateLeaveEntitlementValues(year int, allowanceHours float64, accrualMethod string, carryOverCapHours float64) error {
+	if year < 2000 || year > 2100 {
+		return fmt.Errorf("year must be between 2000 and 2100")
+	}
+	if allowanceHours < 0 || allowanceHours > 8784 {
+		return fmt.Errorf("allowance_hours must be between 0 and 8784")
+	}
+	if carryOverCapHours < 0 {
+		return fmt.Errorf("carry_over_cap_hours cannot be negative")
+	}
+	if accrualMethod != model.AccrualUpfront && accrualMethod != model.AccrualMonthly {
+		return fmt.Errorf("invalid accrual_method, must be one of: upfront, monthly")
+	}
+	return nil
+}
+
+// ValidateYear parses an optional year query value
+func ValidateYear(yearStr string) (*int, error) {
+	if yearStr == "" {
+		return nil, nil
+	}
+	year, err := strconv.Atoi(yearStr)
+	if err != nil || year < 2000 || year > 2100 {
+		return nil, fmt.Errorf("invalid year, must be between 2000 and 2100")
+	}
+	return &year, nil
+}
+
+// ValidateAndParseAsOfDate parses an optional as_of date, defaulting to today
+func ValidateAndParseAsOfDate(asOfStr string) (time.Time, error) {
+	if asOfStr == "" {
+		now := time.Now().UTC()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	asOf, err := time.Parse("2006-01-02", asOfStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid as_of format, use YYYY-MM-DD")
+	}
+	return asOf, nil
+}
@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// LeaveRequestInput represents the data required to create a leave request.
+type LeaveRequestInput struct {
+	EmployeeID    string `json:"employee_id"`
+	Type          string `json:"type"`
+	StartDateTime string `json:"start_date_time"`
+	EndDateTime   string `json:"end_date_time"`
+	Reason        string `json:"reason"`
+}
+
+// DecisionInput represents the optional note attached to an approval, rejection or cancellation.
+type DecisionInput struct {
+	Note string `json:"note"`
+}
+
+// ValidateLeaveRequestRequiredFields validates that all required fields for a leave request are provided.
+func ValidateLeaveRequestRequiredFields(input LeaveRequestInput) error {
+	if input.EmployeeID == "" || input.Type == "" || input.StartDateTime == "" || input.EndDateTime == "" {
+		return fmt.Errorf("employee_id, type, start_date_time, and end_date_time are required for leave request")
+	}
+	return nil
+}
+
+// ValidateLeaveType validates that the leave type is one of the supported types.
+func ValidateLeaveType(leaveType string) error {
+	switch leaveType {
+	case model.LeaveTypeVacation, model.LeaveTypeSick, model.LeaveTypeTraining:
+		return nil
+	}
+	return fmt.Errorf("invalid leave type, must be one of: vacation, sick, training")
+}
+
+// ValidateLeaveStatus validates a leave status used as a filter. Empty is allowed.
+func ValidateLeaveStatus(status string) error {
+	switch status {
+	case "", model.LeaveStatusPending, model.LeaveStatusApproved, model.LeaveStatusRejected, model.LeaveStatusCancelled:
+		return nil
+	}
+	return fmt.Errorf("invalid status, must be one of: pending, approved, rejected, cancelled")
+}
+
+// ValidateAndParseLeaveDateTimes parses and validates the start and end date-times of a leave request.
+func ValidateAndParseLeaveDateTimes(startDateTimeStr, endDateTimeStr string) (time.Time, time.Time, error) {
+	return ValidateAndParseOnetimeBlockDateTimes(startDateTimeStr, endDateTimeStr)
+}
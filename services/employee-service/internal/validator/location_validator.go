@@ -0,0 +1,158 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// maxCoverageDays limits the period of a location coverage report
+const maxCoverageDays = 31
+
+// LocationInput represents the data required to create or update a location.
+type LocationInput struct {
+	Name       string `json:"name"`
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"` // ISO 3166-1 alpha-2 code
+	Phone      string `json:"phone"`
+	TimeZone   string `json:"time_zone"` // IANA time zone, only UTC is supported, defaults to UTC
+	IsActive   *bool  `json:"is_active"` // Pointer to detect if field was provided (defaults to true)
+}
+
+// LocationOpeningHourInput represents the data required to add opening hours to a location.
+type LocationOpeningHourInput struct {
+	DayOfWeek *int   `json:"day_of_week"` // Pointer to detect if field was provided
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// ValidateLocationRequiredFields validates that all required fields for a location are provided.
+func ValidateLocationRequiredFields(input LocationInput) error {
+	if strings.TrimSpace(input.Name) == "" {
+		return fmt.Errorf("name is required for location")
+	}
+	return nil
+}
+
+// ValidateTimeZone checks a location's time zone and applies the default of UTC. Every schedule,
+// break and opening hour time is stored in UTC, so other zones are rejected rather than ignored.
+func ValidateTimeZone(timeZone string) (string, error) {
+	timeZone = strings.TrimSpace(timeZone)
+	switch timeZone {
+	case "", "UTC", "Etc/UTC":
+		return "UTC", nil
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return "", fmt.Errorf("invalid time_zone, use an IANA time zone such as UTC")
+	}
+	return "", fmt.Errorf("time_zone %s is not supported, schedule and opening hour times are in UTC so time_zone must be UTC", timeZone)
+}
+
+// ValidateCountryCode normalizes an optional two-letter country code
+func ValidateCountryCode(country string) (string, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "" {
+		return "", nil
+	}
+	if len(country) != 2 || country[0] < 'A' || country[0] > 'Z' || country[1] < 'A' || country[1] > 'Z' {
+		return "", fmt.Errorf("invalid country, use a two-letter ISO code")
+	}
+	return country, nil
+}
+
+// ValidateLocationExists validates that the location ID is valid and the location exists
+func ValidateLocationExists(locationIDStr string) (uuid.UUID, error) {
+	locationID, err := uuid.Parse(locationIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid location ID format")
+	}
+
+	if _, err := repository.GetLocationByID(locationID); err != nil {
+		return uuid.Nil, fmt.Errorf("location not found")
+	}
+
+	return locationID, nil
+}
+
+// ValidateLocationOpeningHourRequiredFields validates that all required fields for an opening hour entry are provided.
+func ValidateLocationOpeningHourRequiredFields(input LocationOpeningHourInput) error {
+	if input.DayOfWeek == nil || input.StartTime == "" || input.EndTime == "" {
+		return fmt.Errorf("day_of_week, start_time, and end_time are required for opening hours")
+	}
+	return ValidateDayOfWeek(*input.DayOfWeek, true)
+}
+
+// ValidateWorkLocation resolves the location a shift is worked at. An empty ID defaults to the only
+// active location, so single-salon businesses never have to send one; with several active locations
+// it is required. nil is returned while no locations are set up.
+func ValidateWorkLocation(locationIDStr string) (*uuid.UUID, error) {
+	if strings.TrimSpace(locationIDStr) != "" {
+		locationID, err := ValidateLocationExists(strings.TrimSpace(locationIDStr))
+		if err != nil {
+			return nil, err
+		}
+		location, err := repository.GetLocationByID(locationID)
+		if err != nil {
+			return nil, fmt.Errorf("location not found")
+		}
+		if !location.IsActive {
+			return nil, fmt.Errorf("location is not active")
+		}
+		return &locationID, nil
+	}
+
+	locations, err := repository.GetFilteredLocations(true)
+	if err != nil {
+		return nil, fmt.Errorf("internal server error")
+	}
+	switch len(locations) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &locations[0].ID, nil
+	}
+	return nil, fmt.Errorf("location_id is required when there are several locations")
+}
+
+// ValidateLocationFilter parses the optional location_id filter of list and report endpoints
+func ValidateLocationFilter(locationIDStr string) (*uuid.UUID, error) {
+	if locationIDStr == "" {
+		return nil, nil
+	}
+	locationID, err := uuid.Parse(locationIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location ID format")
+	}
+	return &locationID, nil
+}
+
+// ValidateCoveragePeriod parses the start and end date of a coverage report.
+// The end date defaults to the start date and the period is limited to 31 days.
+func ValidateCoveragePeriod(startDateStr, endDateStr string) (time.Time, time.Time, error) {
+	if startDateStr == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("start_date is required")
+	}
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start_date format, use YYYY-MM-DD")
+	}
+	endDate := startDate
+	if endDateStr != "" {
+		endDate, err = time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end_date format, use YYYY-MM-DD")
+		}
+	}
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end_date must be on or after start_date")
+	}
+	if endDate.Sub(startDate) >= maxCoverageDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("coverage period cannot be longer than %d days", maxCoverageDays)
+	}
+	return startDate, endDate, nil
+}
@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// OpenShiftInput represents the data required to post an open shift.
+type OpenShiftInput struct {
+	ShiftDate  string `json:"shift_date"` // YYYY-MM-DD
+	StartTime  string `json:"start_time"` // HH:MM or HH:MM:SS
+	EndTime    string `json:"end_time"`   // HH:MM or HH:MM:SS, before start_time for shifts crossing midnight
+	Role       string `json:"role"`
+	Headcount  int    `json:"headcount"`
+	ClaimMode  string `json:"claim_mode"` // first_come or approval, defaults to approval
+	Notes      string `json:"notes"`
+	LocationID string `json:"location_id"` // Optional while there is at most one active location
+}
+
+// OpenShiftClaimInput represents an employee claiming an open shift.
+type OpenShiftClaimInput struct {
+	EmployeeID string `json:"employee_id"`
+}
+
+// ValidateOpenShiftRequiredFields validates that all required fields for an open shift are provided.
+func ValidateOpenShiftRequiredFields(input OpenShiftInput) error {
+	if input.ShiftDate == "" || input.StartTime == "" || input.EndTime == "" {
+		return fmt.Errorf("shift_date, start_time, and end_time are required for open shift")
+	}
+	return nil
+}
+
+// ValidateOpenShiftValues validates the headcount and claim mode of an open shift.
+func ValidateOpenShiftValues(headcount int, claimMode string) error {
+	if headcount < 1 || headcount > 50 {
+		return fmt.Errorf("headcount must be between 1 and 50")
+	}
+	if claimMode != model.ClaimModeFirstCome && claimMode != model.ClaimModeApproval {
+		return fmt.Errorf("invalid claim_mode, must be one of: first_come, approval")
+	}
+	return nil
+}
+
+// ValidateOpenShiftStatus validates an open shift status used as a filter. Empty is allowed.
+func ValidateOpenShiftStatus(status string) error {
+	switch status {
+	case "", model.OpenShiftStatusOpen, model.OpenShiftStatusFilled, model.OpenShiftStatusCancelled:
+		return nil
+	}
+	return fmt.Errorf("invalid status, must be one of: open, filled, cancelled")
+}
@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salobook/services/employee-service/internal/repository"
+)
+
+// ResourceInput represents the data required to create or update a resource.
+type ResourceInput struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Capacity *int   `json:"capacity"`  // Pointer to detect if field was provided (defaults to 1)
+	IsActive *bool  `json:"is_active"` // Pointer to detect if field was provided (defaults to true)
+}
+
+// ResourceOpeningHourInput represents the data required to add opening hours to a resource.
+type ResourceOpeningHourInput struct {
+	DayOfWeek *int   `json:"day_of_week"` // Pointer to detect if field was provided
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// ResourceBlockInput represents the data required to create a resource block.
+type ResourceBlockInput struct {
+	ResourceID    string `json:"resource_id"`
+	StartDateTime string `json:"start_date_time"`
+	EndDateTime   string `json:"end_date_time"`
+	Units         *int   `json:"units"` // Optional, the whole resource is blocked when omitted
+	Reason        string `json:"reason"`
+}
+
+// ValidateResourceRequiredFields validates that all required fields for a resource are provided.
+func ValidateResourceRequiredFields(input ResourceInput) error {
+	if strings.TrimSpace(input.Name) == "" || strings.TrimSpace(input.Type) == "" {
+		return fmt.Errorf("name and type are required for resource")
+	}
+	return nil
+}
+
+// ValidateResourceCapacity validates the capacity of a resource and applies the default of 1.
+func ValidateResourceCapacity(capacity *int) (int, error) {
+	if capacity == nil {
+		return 1, nil
+	}
+	if *capacity < 1 {
+		return 0, fmt.Errorf("capacity must be at least 1")
+	}
+	return *capacity, nil
+}
+
+// ValidateResourceExists validates that the resource ID is valid and the resource exists
+func ValidateResourceExists(resourceIDStr string) (uuid.UUID, error) {
+	resourceID, err := uuid.Parse(resourceIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid resource ID format")
+	}
+
+	if _, err := repository.GetResourceByID(resourceID); err != nil {
+		return uuid.Nil, fmt.Errorf("resource not found")
+	}
+
+	return resourceID, nil
+}
+
+// ValidateResourceOpeningHourRequiredFields validates that all required fields for an opening hour entry are provided.
+func ValidateResourceOpeningHourRequiredFields(input ResourceOpeningHourInput) error {
+	if input.DayOfWeek == nil || input.StartTime == "" || input.EndTime == "" {
+		return fmt.Errorf("day_of_week, start_time, and end_time are required for opening hours")
+	}
+	return ValidateDayOfWeek(*input.DayOfWeek, true)
+}
+
+// ValidateResourceBlockRequiredFields validates that all required fields for a resource block are provided.
+func ValidateResourceBlockRequiredFields(input ResourceBlockInput) error {
+	if input.ResourceID == "" || input.StartDateTime == "" || input.EndDateTime == "" || input.Reason == "" {
+		return fmt.Errorf("resource_id, start_date_time, end_date_time, and reason are required for resource block")
+	}
+	return nil
+}
+
+// ValidateResourceBlockUnits validates the optional number of units a resource block takes.
+// The upper bound depends on the resource and is checked together with overlapping blocks.
+func ValidateResourceBlockUnits(units *int) error {
+	if units != nil && *units < 1 {
+		return fmt.Errorf("units must be at least 1")
+	}
+	return nil
+}
+
+// ValidateAndParseResourceBlockDateTimes re-uses the one-time block date-time validation.
+func ValidateAndParseResourceBlockDateTimes(startDateTimeStr, endDateTimeStr string) (time.Time, time.Time, error) {
+	return ValidateAndParseOnetimeBlockDateTimes(startDateTimeStr, endDateTimeStr)
+}
+
+// ValidateAvailabilityResourceIDs validates and parses the optional resource IDs of an availability request
+func ValidateAvailabilityResourceIDs(resourceIDStrs []string) ([]uuid.UUID, error) {
+	resourceIDs := make([]uuid.UUID, 0, len(resourceIDStrs))
+	seen := make(map[uuid.UUID]bool)
+
+	for _, resourceIDStr := range resourceIDStrs {
+		resourceID, err := uuid.Parse(resourceIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource ID format: %s", resourceIDStr)
+		}
+		if seen[resourceID] {
+			continue
+		}
+		seen[resourceID] = true
+		resourceIDs = append(resourceIDs, resourceID)
+	}
+
+	return resourceIDs, nil
+}
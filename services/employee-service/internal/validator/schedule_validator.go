@@ -19,6 +19,7 @@ type ScheduleInput struct {
 	ValidFrom   string `json:"valid_from"`
 	ValidUntil  string `json:"valid_until"`
 	Notes       string `json:"notes"`
+	LocationID  string `json:"location_id"` // Optional while there is at most one active location
 }
 
 
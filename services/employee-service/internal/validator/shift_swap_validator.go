@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/salobook/services/employee-service/internal/model"
+)
+
+// ShiftSwapOfferInput represents the data required to offer a shift for swapping.
+type ShiftSwapOfferInput struct {
+	EmployeeID string `json:"employee_id"` // Employee giving the shift away
+	ShiftDate  string `json:"shift_date"`  // Date of the shift (YYYY-MM-DD)
+	Note       string `json:"note"`
+}
+
+// ShiftSwapAcceptInput represents the employee accepting an offered shift.
+type ShiftSwapAcceptInput struct {
+	EmployeeID string `json:"employee_id"`
+}
+
+// ValidateShiftSwapOfferRequiredFields validates that all required fields for a shift swap offer are provided.
+func ValidateShiftSwapOfferRequiredFields(input ShiftSwapOfferInput) error {
+	if input.EmployeeID == "" || input.ShiftDate == "" {
+		return fmt.Errorf("employee_id and shift_date are required for shift swap")
+	}
+	return nil
+}
+
+// ValidateAndParseShiftDate parses the date of a shift
+func ValidateAndParseShiftDate(shiftDateStr string) (time.Time, error) {
+	shiftDate, err := time.Parse("2006-01-02", shiftDateStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid shift_date format, use YYYY-MM-DD")
+	}
+	return shiftDate, nil
+}
+
+// ValidateShiftSwapStatus validates a shift swap status used as a filter. Empty is allowed.
+func ValidateShiftSwapStatus(status string) error {
+	switch status {
+	case "", model.SwapStatusOffered, model.SwapStatusAccepted, model.SwapStatusApproved, model.SwapStatusRejected, model.SwapStatusCancelled:
+		return nil
+	}
+	return fmt.Errorf("invalid status, must be one of: offered, accepted, approved, rejected, cancelled")
+}
@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EmployeeSkillInput represents the data required to add or update a skill of an employee
+type EmployeeSkillInput struct {
+	Name            string `json:"name"`
+	Level           *int   `json:"level"` // Pointer to detect if field was provided (defaults to 1)
+	IsCertification bool   `json:"is_certification"`
+	IssuedOn        string `json:"issued_on"`  // Optional YYYY-MM-DD
+	ExpiresOn       string `json:"expires_on"` // Optional YYYY-MM-DD
+	Notes           string `json:"notes"`
+}
+
+// RoleRequirementInput represents the data required to add a requirement to a role
+type RoleRequirementInput struct {
+	Role      string `json:"role"`
+	SkillName string `json:"skill_name"`
+	MinLevel  *int   `json:"min_level"` // Pointer to detect if field was provided (defaults to 1)
+}
+
+// NormalizeSkillName trims and lowercases a skill name
+func NormalizeSkillName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// ValidateSkillLevel validates a skill level and applies the default of 1
+func ValidateSkillLevel(level *int) (int, error) {
+	if level == nil {
+		return 1, nil
+	}
+	if *level < 1 || *level > 5 {
+		return 0, fmt.Errorf("level must be between 1 and 5")
+	}
+	return *level, nil
+}
+
+// ValidateAndParseSkillDates parses the optional issue and expiry dates of a skill.
+// Only certifications can expire.
+func ValidateAndParseSkillDates(input EmployeeSkillInput) (*time.Time, *time.Time, error) {
+	var issuedOn, expiresOn *time.Time
+	if input.IssuedOn != "" {
+		parsed, err := time.Parse("2006-01-02", input.IssuedOn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid issued_on format, use YYYY-MM-DD")
+		}
+		issuedOn = &parsed
+	}
+	if input.ExpiresOn != "" {
+		if !input.IsCertification {
+			return nil, nil, fmt.Errorf("only certifications can have an expiry date")
+		}
+		parsed, err := time.Parse("2006-01-02", input.ExpiresOn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid expires_on format, use YYYY-MM-DD")
+		}
+		expiresOn = &parsed
+	}
+	if issuedOn != nil && expiresOn != nil && !expiresOn.After(*issuedOn) {
+		return nil, nil, fmt.Errorf("expires_on must be after issued_on")
+	}
+	return issuedOn, expiresOn, nil
+}
+
+// ValidateSkillFilter parses the optional skill and min_level filters of a list endpoint
+func ValidateSkillFilter(skill string, minLevelStr string) (string, int, error) {
+	skill = NormalizeSkillName(skill)
+	if minLevelStr == "" {
+		return skill, 1, nil
+	}
+	if skill == "" {
+		return "", 0, fmt.Errorf("min_level requires skill")
+	}
+	minLevel, err := strconv.Atoi(minLevelStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("min_level must be between 1 and 5")
+	}
+	minLevel, err = ValidateSkillLevel(&minLevel)
+	return skill, minLevel, err
+}
+
+// ValidateWithinDays parses the within_days window of the expiring certifications report
+func ValidateWithinDays(withinDaysStr string, defaultDays int) (int, error) {
+	if withinDaysStr == "" {
+		return defaultDays, nil
+	}
+	withinDays, err := strconv.Atoi(withinDaysStr)
+	if err != nil || withinDays < 0 || withinDays > 365 {
+		return 0, fmt.Errorf("within_days must be between 0 and 365")
+	}
+	return withinDays, nil
+}
@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// TeamInput represents the data required to create or update a team
+type TeamInput struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	ParentID    *string `json:"parent_id"` // Pointer to detect if field was provided, "" moves the team to the top level
+	LeadID      *string `json:"lead_id"`   // Pointer to detect if field was provided, "" removes the lead
+}
+
+// TeamAssignmentInput represents the input for moving an employee into a team
+type TeamAssignmentInput struct {
+	TeamID string `json:"team_id"` // "" removes the employee from their team
+}
+
+// ManagerAssignmentInput represents the input for setting the manager of an employee
+type ManagerAssignmentInput struct {
+	ManagerID string `json:"manager_id"` // "" removes the manager
+}
+
+// ValidateTeamName trims a team name and checks its length
+func ValidateTeamName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("name is required")
+	}
+	if len(name) > 100 {
+		return "", fmt.Errorf("name must be at most 100 characters")
+	}
+	return name, nil
+}
+
+// ValidateOptionalID parses an optional reference ID, returning nil for ""
+func ValidateOptionalID(idStr string, field string) (*uuid.UUID, error) {
+	idStr = strings.TrimSpace(idStr)
+	if idStr == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s format", field)
+	}
+	return &id, nil
+}
+
+// ValidateManagedBy parses the managed_by list filter, which is "me" or an employee ID.
+// It returns whether the filter was given and the manager ID, nil for "me".
+func ValidateManagedBy(value string) (bool, *uuid.UUID, error) {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "":
+		return false, nil, nil
+	case "me":
+		return true, nil, nil
+	}
+	managerID, err := uuid.Parse(value)
+	if err != nil {
+		return false, nil, fmt.Errorf("invalid managed_by, must be me or an employee ID")
+	}
+	return true, &managerID, nil
+}
@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimeClockInput represents a clock-in, clock-out or break punch.
+type TimeClockInput struct {
+	EmployeeID string `json:"employee_id"`
+	Time       string `json:"time"`  // Optional RFC3339 time of the punch, defaults to now
+	Notes      string `json:"notes"` // Only used on clock-in
+}
+
+// TimeEntryCorrectionInput represents a manager recording a missed time entry or correcting one.
+type TimeEntryCorrectionInput struct {
+	EmployeeID string `json:"employee_id"` // Only used when recording a new entry
+	ClockIn    string `json:"clock_in"`    // RFC3339
+	ClockOut   string `json:"clock_out"`   // RFC3339
+	Notes      string `json:"notes"`
+	Reason     string `json:"reason"` // Why the entry was recorded or corrected
+}
+
+// punchTolerance is how far a punch may differ from the current time, to allow for clock drift
+// and a phone that sends the punch late. Anything else is a correction for a manager.
+const punchTolerance = 5 * time.Minute
+
+// ValidateAndParsePunchTime parses the time of a punch, defaulting to now.
+// Punches may differ from now by at most punchTolerance in either direction.
+func ValidateAndParsePunchTime(timeStr string) (time.Time, error) {
+	now := time.Now().UTC()
+	if timeStr == "" {
+		return now, nil
+	}
+
+	punchTime, err := time.Parse(time.RFC3339, timeStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time format, should be in RFC3339 format (e.g., 2024-05-23T09:00:00Z)")
+	}
+	if punchTime.After(now.Add(punchTolerance)) {
+		return time.Time{}, fmt.Errorf("time cannot be in the future")
+	}
+	if punchTime.Before(now.Add(-punchTolerance)) {
+		return time.Time{}, fmt.Errorf("time cannot be more than %d minutes in the past, ask a manager to correct the time entry", int(punchTolerance.Minutes()))
+	}
+	return punchTime.UTC(), nil
+}
+
+// ValidateAndParseCorrectionTimes parses the clock-in and clock-out times of a correction.
+// Empty times are returned as nil. The times cannot be in the future and clock-out must follow clock-in.
+func ValidateAndParseCorrectionTimes(clockInStr, clockOutStr string) (*time.Time, *time.Time, error) {
+	now := time.Now().UTC()
+	parse := func(name, value string) (*time.Time, error) {
+		if value == "" {
+			return nil, nil
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s format, should be in RFC3339 format (e.g., 2024-05-23T09:00:00Z)", name)
+		}
+		if parsed.After(now.Add(punchTolerance)) {
+			return nil, fmt.Errorf("%s cannot be in the future", name)
+		}
+		parsed = parsed.UTC()
+		return &parsed, nil
+	}
+
+	clockIn, err := parse("clock_in", clockInStr)
+	if err != nil {
+		return nil, nil, err
+	}
+	clockOut, err := parse("clock_out", clockOutStr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if clockIn != nil && clockOut != nil && !clockOut.After(*clockIn) {
+		return nil, nil, fmt.Errorf("clock_out must be after clock_in")
+	}
+	return clockIn, clockOut, nil
+}
+
+// ValidateAndParseReportPeriod parses the start_date and end_date of a report.
+// end_date defaults to start_date and the period may span at most maxDays days.
+func ValidateAndParseReportPeriod(startDateStr, endDateStr string, maxDays int) (time.Time, time.Time, error) {
+	if startDateStr == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("start_date is required")
+	}
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start_date format, use YYYY-MM-DD")
+	}
+
+	endDate := startDate
+	if endDateStr != "" {
+		endDate, err = time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end_date format, use YYYY-MM-DD")
+		}
+	}
+
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end_date must not be before start_date")
+	}
+	if endDate.Sub(startDate) >= time.Duration(maxDays)*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("period cannot span more than %d days", maxDays)
+	}
+	return startDate, endDate, nil
+}
+
+// ValidateGraceMinutes parses the grace_minutes tolerance of the variance report, defaulting to 5
+func ValidateGraceMinutes(graceStr string) (int, error) {
+	if graceStr == "" {
+		return 5, nil
+	}
+	grace, err := strconv.Atoi(graceStr)
+	if err != nil || grace < 0 || grace > 120 {
+		return 0, fmt.Errorf("grace_minutes must be between 0 and 120")
+	}
+	return grace, nil
+}
@@ -0,0 +1,72 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HolidayInput represents the input for creating a holiday
+type HolidayInput struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	Name string `json:"name"`
+}
+
+// TimesheetApprovalInput represents the input for approving an employee's week
+type TimesheetApprovalInput struct {
+	EmployeeID string `json:"employee_id"`
+	WeekStart  string `json:"week_start"` // YYYY-MM-DD, must be a Monday
+	Source     string `json:"source"`     // clocked (default) or scheduled
+}
+
+// ValidateHolidayInput validates a holiday and returns its date
+func ValidateHolidayInput(input HolidayInput) (time.Time, error) {
+	if input.Date == "" {
+		return time.Time{}, fmt.Errorf("date is required")
+	}
+	if strings.TrimSpace(input.Name) == "" {
+		return time.Time{}, fmt.Errorf("name is required")
+	}
+	date, err := time.Parse("2006-01-02", input.Date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date format, use YYYY-MM-DD")
+	}
+	return date, nil
+}
+
+// ValidateAndParseWeekStart parses the first day of a timesheet week, which must be a Monday
+func ValidateAndParseWeekStart(weekStartStr string) (time.Time, error) {
+	if weekStartStr == "" {
+		return time.Time{}, fmt.Errorf("week_start is required")
+	}
+	weekStart, err := time.Parse("2006-01-02", weekStartStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid week_start format, use YYYY-MM-DD")
+	}
+	if weekStart.Weekday() != time.Monday {
+		return time.Time{}, fmt.Errorf("week_start must be a Monday")
+	}
+	return weekStart, nil
+}
+
+// ValidateTimesheetSource validates the source of timesheet hours, defaulting to clocked
+func ValidateTimesheetSource(source string) (string, error) {
+	if source == "" {
+		return "clocked", nil
+	}
+	if source != "clocked" && source != "scheduled" {
+		return "", fmt.Errorf("invalid source, must be one of: clocked, scheduled")
+	}
+	return source, nil
+}
+
+// ValidateExportFormat validates the payroll export format, defaulting to json
+func ValidateExportFormat(format string) (string, error) {
+	if format == "" {
+		return "json", nil
+	}
+	if format != "json" && format != "csv" {
+		return "", fmt.Errorf("invalid format, must be one of: json, csv")
+	}
+	return format, nil
+}
@@ -9,7 +9,7 @@ echo "3. Note: Migration is complete, this script is for reference only"
 echo ""
 echo "📋 FROM AUTH SERVICE:"
 echo "   - USER_DB_URL"
-echo "   - PASETO_SECRET_KEY" 
+echo "   - PASETO_PRIVATE_KEY (openssl rand -hex 32)" 
 echo "   - SMTP_USERNAME"
 echo "   - SMTP_PASSWORD"
 echo ""
@@ -25,7 +25,7 @@ echo "4. Then edit .do/app.yaml and replace:"
 echo "   - YOUR_GITHUB_USERNAME → your username"
 echo "   - YOUR_REPO_NAME → your repo"
 echo "   - YOUR_NEON_DATABASE_URL → your DB URL"
-echo "   - YOUR_PASETO_SECRET → your secret"
+echo "   - YOUR_PASETO_SECRET → your PASETO private key"
 echo "   - YOUR_SMTP_USERNAME → your SMTP user"
 echo "   - YOUR_SMTP_PASSWORD → your SMTP pass"
 echo "   - https://yourapp.vercel.app → your frontend URL"